@@ -2,20 +2,22 @@ package app
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log/slog"
 
 	"github.com/go-telegram/bot"
 	"github.com/ofstudio/voxify/internal/config"
-	"github.com/ofstudio/voxify/internal/platforms"
 	"github.com/ofstudio/voxify/internal/services"
 	"github.com/ofstudio/voxify/internal/store"
 	"github.com/ofstudio/voxify/internal/telegram"
 )
 
 type App struct {
-	cfg config.Config
-	log *slog.Logger
+	cfg  config.Config
+	log  *slog.Logger
+	db   *sql.DB
+	feed feedSource
 }
 
 func New(cfg config.Config, log *slog.Logger) *App {
@@ -33,19 +35,26 @@ func (a *App) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
 	a.log.Info("database connected", "filepath", a.cfg.DB.Filepath, "version", a.cfg.DB.Version)
+	a.db = db
 
 	// Create store
 	st := store.NewSQLiteStore(db)
 	// Create platforms
-	ytDlp := platforms.NewYtDlpPlatform(a.cfg.Settings, a.log)
+	pl, err := a.newPlatforms()
+	if err != nil {
+		return err
+	}
+	a.log.Info("platforms enabled", "platforms", a.cfg.Platforms)
 
 	// Create services
 	var (
 		feedSrv    = services.NewFeedService(&a.cfg.Settings, a.log, st)
-		episodeSrv = services.NewEpisodeService(&a.cfg.Settings, a.log, st, ytDlp)
+		episodeSrv = services.NewEpisodeService(&a.cfg.Settings, a.log, st, feedSrv, pl...)
 		processSrv = services.NewProcessService(&a.cfg.Settings, a.log, st, episodeSrv, feedSrv)
 	)
 
+	a.feed = feedSrv
+
 	// Initialize services
 	if err = episodeSrv.Init(ctx); err != nil {
 		return fmt.Errorf("episode service init failed: %w", err)
@@ -60,7 +69,7 @@ func (a *App) Start(ctx context.Context) error {
 
 	// Initialize Telegram bot
 	middleware := telegram.NewMiddleware(a.cfg.Telegram, a.log)
-	handlers := telegram.NewHandlers(a.cfg.Settings, a.log, processSrv.In(), feedSrv)
+	handlers := telegram.NewHandlers(a.cfg.Settings, a.log, processSrv.In(), feedSrv, processSrv)
 
 	b, err := bot.New(a.cfg.Telegram.BotToken, []bot.Option{
 		bot.WithMiddlewares(middleware.WithAllowedUsers()),
@@ -72,10 +81,15 @@ func (a *App) Start(ctx context.Context) error {
 
 	b.RegisterHandler(bot.HandlerTypeMessageText, "start", bot.MatchTypeCommandStartOnly, handlers.CmdStart())
 	b.RegisterHandler(bot.HandlerTypeMessageText, "build", bot.MatchTypeCommand, handlers.CmdBuild())
+	b.RegisterHandler(bot.HandlerTypeMessageText, "validate", bot.MatchTypeCommand, handlers.CmdValidate())
 	b.RegisterHandler(bot.HandlerTypeMessageText, "info", bot.MatchTypeCommand, handlers.CmdInfo())
+	b.RegisterHandler(bot.HandlerTypeMessageText, "list", bot.MatchTypeCommand, handlers.CmdList())
+	b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "list:", bot.MatchTypePrefix, handlers.CbList())
+	b.RegisterHandler(bot.HandlerTypeMessageText, "add", bot.MatchTypeCommand, handlers.CmdAdd())
+	b.RegisterHandler(bot.HandlerTypeMessageText, "status", bot.MatchTypeCommand, handlers.CmdStatus())
 	b.RegisterHandler(bot.HandlerTypeMessageText, "https://", bot.MatchTypePrefix, handlers.Url())
 
-	notifications := telegram.NewNotifications(a.log, b, processSrv.Out())
+	notifications := telegram.NewNotifications(a.cfg.Settings, a.log, b, processSrv.Out())
 
 	// Start bot and notifications
 	ctxBot, cancelBot := context.WithCancel(ctx)
@@ -90,6 +104,14 @@ func (a *App) Start(ctx context.Context) error {
 	processSrv.Start(ctxSrv)
 	a.log.Info("background services started")
 
+	// Start health check server
+	if a.cfg.HealthAddr != "" {
+		stopHealth := a.startHealthServer()
+		defer stopHealth()
+		a.log.Info("health server started", "addr", a.cfg.HealthAddr, "path", healthPath,
+			"feed_path", a.feedPath(), "serve_public", a.cfg.ServePublic)
+	}
+
 	// Wait for the context to be done
 	a.log.Info("app is running")
 	<-ctx.Done()
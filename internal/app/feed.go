@@ -0,0 +1,83 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ofstudio/voxify/internal/services"
+)
+
+// feedSource provides the encoded feed and its metadata for HTTP serving.
+type feedSource interface {
+	FeedBytes(ctx context.Context) (data []byte, etag string, lastMod time.Time, err error)
+}
+
+// feedPath returns the path of the feed HTTP endpoint:
+// FeedPath if set and /<FeedFileName> otherwise.
+func (a *App) feedPath() string {
+	return cmp.Or(a.cfg.FeedPath, "/"+a.cfg.FeedFileName)
+}
+
+// feedHandler serves the RSS feed built in memory once per request.
+// It supports conditional requests: 304 Not Modified is returned
+// if If-None-Match matches the feed ETag or, in its absence,
+// if the feed was not modified since If-Modified-Since.
+func (a *App) feedHandler(feed feedSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, etag, lastMod, err := feed.FeedBytes(r.Context())
+		if err != nil {
+			a.feedError(w, err)
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastMod.UTC().Format(http.TimeFormat))
+		w.Header().Set("Cache-Control", "no-cache")
+
+		if notModified(r, etag, lastMod) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		_, _ = w.Write(data)
+	}
+}
+
+// feedError responds with 404 Not Found if the feed has no episodes
+// and with 500 Internal Server Error otherwise.
+func (a *App) feedError(w http.ResponseWriter, err error) {
+	if errors.Is(err, services.ErrEmptyFeed) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	a.log.Error("failed to serve feed", "error", err)
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// notModified reports whether the client copy of the feed is up to date.
+// If-None-Match takes precedence over If-Modified-Since as per RFC 9110.
+func notModified(r *http.Request, etag string, lastMod time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		t, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		// HTTP dates have a one-second resolution
+		return !lastMod.Truncate(time.Second).After(t)
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ofstudio/voxify/internal/config"
+	"github.com/ofstudio/voxify/internal/services"
+)
+
+// fakeFeed is a feedSource returning fixed values
+type fakeFeed struct {
+	data    []byte
+	etag    string
+	lastMod time.Time
+	err     error
+	built   int // number of FeedBytes calls
+}
+
+func (f *fakeFeed) FeedBytes(_ context.Context) ([]byte, string, time.Time, error) {
+	f.built++
+	if f.err != nil {
+		return nil, "", time.Time{}, f.err
+	}
+	return f.data, f.etag, f.lastMod, nil
+}
+
+// TestFeedHandlerSuite is a test suite for the feed HTTP handler
+type TestFeedHandlerSuite struct {
+	suite.Suite
+	app  *App
+	feed *fakeFeed
+}
+
+// SetupSubTest is called before each subtest
+func (suite *TestFeedHandlerSuite) SetupSubTest() {
+	suite.app = New(config.Default(), slog.Default())
+	suite.feed = &fakeFeed{
+		data:    []byte("<rss></rss>"),
+		etag:    `"0123456789abcdef"`,
+		lastMod: time.Date(2025, 10, 1, 12, 30, 15, 500, time.UTC),
+	}
+}
+
+// serve performs the request with the given headers
+func (suite *TestFeedHandlerSuite) serve(headers map[string]string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, suite.app.feedPath(), nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	suite.app.feedHandler(suite.feed).ServeHTTP(rec, req)
+	return rec
+}
+
+// TestFeedHandler tests the feed HTTP handler
+func (suite *TestFeedHandlerSuite) TestFeedHandler() {
+	suite.Run("OK", func() {
+		// Act
+		rec := suite.serve(nil)
+
+		// Assert
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Equal("<rss></rss>", rec.Body.String())
+		suite.Equal("application/rss+xml; charset=utf-8", rec.Header().Get("Content-Type"))
+		suite.Equal(`"0123456789abcdef"`, rec.Header().Get("ETag"))
+		suite.Equal("Wed, 01 Oct 2025 12:30:15 GMT", rec.Header().Get("Last-Modified"))
+		suite.Equal("no-cache", rec.Header().Get("Cache-Control"))
+		suite.Equal(1, suite.feed.built, "feed must be built once per request")
+	})
+
+	suite.Run("IfNoneMatch_Match", func() {
+		// Act
+		rec := suite.serve(map[string]string{"If-None-Match": `"other", "0123456789abcdef"`})
+
+		// Assert
+		suite.Equal(http.StatusNotModified, rec.Code)
+		suite.Empty(rec.Body.String())
+		suite.Equal(`"0123456789abcdef"`, rec.Header().Get("ETag"))
+		suite.Equal(1, suite.feed.built, "feed must be built once per request")
+	})
+
+	suite.Run("IfNoneMatch_Changed", func() {
+		// Act
+		rec := suite.serve(map[string]string{
+			"If-None-Match":     `"other"`,
+			"If-Modified-Since": "Wed, 01 Oct 2025 12:30:15 GMT",
+		})
+
+		// Assert
+		suite.Equal(http.StatusOK, rec.Code, "If-None-Match takes precedence over If-Modified-Since")
+		suite.Equal("<rss></rss>", rec.Body.String())
+	})
+
+	suite.Run("IfModifiedSince_NotModified", func() {
+		// Act
+		rec := suite.serve(map[string]string{"If-Modified-Since": "Wed, 01 Oct 2025 12:30:15 GMT"})
+
+		// Assert
+		suite.Equal(http.StatusNotModified, rec.Code)
+		suite.Empty(rec.Body.String())
+	})
+
+	suite.Run("IfModifiedSince_Modified", func() {
+		// Act
+		rec := suite.serve(map[string]string{"If-Modified-Since": "Wed, 01 Oct 2025 12:30:14 GMT"})
+
+		// Assert
+		suite.Equal(http.StatusOK, rec.Code)
+	})
+
+	suite.Run("IfModifiedSince_Invalid", func() {
+		// Act
+		rec := suite.serve(map[string]string{"If-Modified-Since": "yesterday"})
+
+		// Assert
+		suite.Equal(http.StatusOK, rec.Code)
+	})
+
+	suite.Run("EmptyFeed", func() {
+		// Arrange
+		suite.feed.err = services.ErrEmptyFeed
+
+		// Act
+		rec := suite.serve(nil)
+
+		// Assert
+		suite.Equal(http.StatusNotFound, rec.Code)
+	})
+
+	suite.Run("Failure", func() {
+		// Arrange
+		suite.feed.err = errors.New("store error")
+
+		// Act
+		rec := suite.serve(nil)
+
+		// Assert
+		suite.Equal(http.StatusInternalServerError, rec.Code)
+		suite.NotContains(rec.Body.String(), "store error")
+	})
+
+	suite.Run("RssExtension", func() {
+		// Arrange
+		cfg := config.Default()
+		cfg.FeedFileName = "podcast.rss"
+		suite.app = New(cfg, slog.Default())
+		suite.app.feed = suite.feed
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/podcast.rss", nil)
+
+		// Act
+		suite.app.healthMux().ServeHTTP(rec, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Equal("<rss></rss>", rec.Body.String())
+		suite.Equal("application/rss+xml; charset=utf-8", rec.Header().Get("Content-Type"))
+	})
+}
+
+func TestFeedHandler(t *testing.T) {
+	suite.Run(t, new(TestFeedHandlerSuite))
+}
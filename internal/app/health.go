@@ -0,0 +1,127 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"time"
+)
+
+var (
+	ErrHealthDB        = errors.New("database is not available")
+	ErrHealthPublicDir = errors.New("public directory is not writable")
+	ErrHealthYtDlp     = errors.New("yt-dlp is not working")
+	ErrHealthFFMpeg    = errors.New("ffmpeg is not working")
+)
+
+const (
+	healthCheckTimeout = time.Second * 10 // Timeout for all health checks together
+	healthPath         = "/healthz"       // Path of the health check HTTP endpoint
+)
+
+// HealthCheck verifies that the application dependencies are available:
+// the database, the public directory, yt-dlp and ffmpeg.
+// All checks are performed and the failures are joined into a single error.
+func (a *App) HealthCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	return errors.Join(
+		a.checkDB(ctx),
+		a.checkPublicDir(),
+		a.checkCommand(ctx, ErrHealthYtDlp, a.cfg.YtDlpPath, "--version"),
+		a.checkCommand(ctx, ErrHealthFFMpeg, a.cfg.FFMpegPath, "-version"),
+	)
+}
+
+// checkDB pings the database.
+func (a *App) checkDB(ctx context.Context) error {
+	if a.db == nil {
+		return fmt.Errorf("%w: not connected", ErrHealthDB)
+	}
+	if err := a.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("%w: %w", ErrHealthDB, err)
+	}
+	return nil
+}
+
+// checkPublicDir creates and removes a temporary file in the public directory.
+func (a *App) checkPublicDir() error {
+	file, err := os.CreateTemp(a.cfg.PublicDir, ".healthcheck-*")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrHealthPublicDir, err)
+	}
+	_ = file.Close()
+	if err = os.Remove(file.Name()); err != nil {
+		return fmt.Errorf("%w: %w", ErrHealthPublicDir, err)
+	}
+	return nil
+}
+
+// checkCommand runs the executable with the given arguments and wraps a failure with e.
+func (a *App) checkCommand(ctx context.Context, e error, path string, args ...string) error {
+	if path == "" {
+		return fmt.Errorf("%w: path is not configured", e)
+	}
+	if err := exec.CommandContext(ctx, path, args...).Run(); err != nil {
+		return fmt.Errorf("%w: %w", e, err)
+	}
+	return nil
+}
+
+// healthHandler serves the result of HealthCheck.
+// It responds with 200 OK if all checks pass and 503 Service Unavailable otherwise.
+func (a *App) healthHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if err := a.HealthCheck(r.Context()); err != nil {
+			a.log.Error("health check failed", "error", err)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = fmt.Fprintln(w, err.Error())
+			return
+		}
+		_, _ = fmt.Fprintln(w, "ok")
+	}
+}
+
+// healthMux returns the handler of the health check HTTP server routing
+// the health check, the feed endpoint if the feed is set
+// and the public directory files if ServePublic is set.
+func (a *App) healthMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("GET "+healthPath, a.healthHandler())
+	if a.feed != nil {
+		mux.Handle("GET "+a.feedPath(), a.feedHandler(a.feed))
+	}
+	if a.cfg.ServePublic {
+		mux.Handle("GET "+publicPath, a.publicHandler())
+	}
+	return mux
+}
+
+// startHealthServer starts the health check HTTP server in the background.
+// Besides the health check, the server serves the RSS feed built in memory
+// and, optionally, the media files.
+// It returns a function that shuts the server down.
+func (a *App) startHealthServer() func() {
+	srv := &http.Server{
+		Addr:              a.cfg.HealthAddr,
+		Handler:           a.healthMux(),
+		ReadHeaderTimeout: healthCheckTimeout,
+	}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.log.Error("health server failed", "error", err)
+		}
+	}()
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}
+}
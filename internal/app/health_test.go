@@ -0,0 +1,178 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ofstudio/voxify/internal/config"
+	"github.com/ofstudio/voxify/internal/store"
+)
+
+// TestHealthSuite is a test suite for the application health check
+type TestHealthSuite struct {
+	suite.Suite
+	ctx context.Context
+	app *App
+}
+
+// SetupSubTest is called before each subtest
+func (suite *TestHealthSuite) SetupSubTest() {
+	suite.ctx = context.Background()
+
+	db, err := store.NewSQLite(":memory:", 11)
+	suite.Require().NoError(err)
+	suite.T().Cleanup(func() { _ = db.Close() })
+
+	cfg := config.Default()
+	cfg.PublicDir = suite.T().TempDir()
+	cfg.YtDlpPath = suite.fakeCommand("yt-dlp", 0)
+	cfg.FFMpegPath = suite.fakeCommand("ffmpeg", 0)
+
+	suite.app = New(cfg, slog.Default())
+	suite.app.db = db
+}
+
+// fakeCommand creates an executable script that exits with the given code.
+func (suite *TestHealthSuite) fakeCommand(name string, code int) string {
+	path := filepath.Join(suite.T().TempDir(), name)
+	script := "#!/bin/sh\nexit " + strconv.Itoa(code) + "\n"
+	suite.Require().NoError(os.WriteFile(path, []byte(script), 0755))
+	return path
+}
+
+// TestHealthCheck tests the HealthCheck method
+func (suite *TestHealthSuite) TestHealthCheck() {
+	suite.Run("Healthy", func() {
+		// Act
+		err := suite.app.HealthCheck(suite.ctx)
+
+		// Assert
+		suite.NoError(err)
+		entries, err := os.ReadDir(suite.app.cfg.PublicDir)
+		suite.Require().NoError(err)
+		suite.Empty(entries, "health check must not leave files in public directory")
+	})
+
+	suite.Run("DatabaseClosed", func() {
+		// Arrange
+		suite.Require().NoError(suite.app.db.Close())
+
+		// Act
+		err := suite.app.HealthCheck(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrHealthDB)
+		suite.NotErrorIs(err, ErrHealthPublicDir)
+		suite.NotErrorIs(err, ErrHealthYtDlp)
+		suite.NotErrorIs(err, ErrHealthFFMpeg)
+	})
+
+	suite.Run("DatabaseNotConnected", func() {
+		// Arrange
+		suite.app.db = nil
+
+		// Act
+		err := suite.app.HealthCheck(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrHealthDB)
+	})
+
+	suite.Run("PublicDirMissing", func() {
+		// Arrange
+		suite.app.cfg.PublicDir = filepath.Join(suite.T().TempDir(), "missing")
+
+		// Act
+		err := suite.app.HealthCheck(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrHealthPublicDir)
+		suite.NotErrorIs(err, ErrHealthDB)
+		suite.NotErrorIs(err, ErrHealthYtDlp)
+		suite.NotErrorIs(err, ErrHealthFFMpeg)
+	})
+
+	suite.Run("YtDlpFailing", func() {
+		// Arrange
+		suite.app.cfg.YtDlpPath = suite.fakeCommand("yt-dlp", 1)
+
+		// Act
+		err := suite.app.HealthCheck(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrHealthYtDlp)
+		suite.NotErrorIs(err, ErrHealthDB)
+		suite.NotErrorIs(err, ErrHealthPublicDir)
+		suite.NotErrorIs(err, ErrHealthFFMpeg)
+	})
+
+	suite.Run("FFMpegMissing", func() {
+		// Arrange
+		suite.app.cfg.FFMpegPath = filepath.Join(suite.T().TempDir(), "ffmpeg")
+
+		// Act
+		err := suite.app.HealthCheck(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrHealthFFMpeg)
+		suite.NotErrorIs(err, ErrHealthDB)
+		suite.NotErrorIs(err, ErrHealthPublicDir)
+		suite.NotErrorIs(err, ErrHealthYtDlp)
+	})
+
+	suite.Run("MultipleFailures", func() {
+		// Arrange
+		suite.app.cfg.YtDlpPath = ""
+		suite.app.cfg.FFMpegPath = ""
+
+		// Act
+		err := suite.app.HealthCheck(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrHealthYtDlp)
+		suite.ErrorIs(err, ErrHealthFFMpeg)
+		suite.NotErrorIs(err, ErrHealthDB)
+	})
+}
+
+// TestHealthHandler tests the health check HTTP handler
+func (suite *TestHealthSuite) TestHealthHandler() {
+	suite.Run("OK", func() {
+		// Arrange
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, healthPath, nil)
+
+		// Act
+		suite.app.healthHandler().ServeHTTP(rec, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Equal("ok\n", rec.Body.String())
+	})
+
+	suite.Run("Unavailable", func() {
+		// Arrange
+		suite.app.cfg.FFMpegPath = suite.fakeCommand("ffmpeg", 1)
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, healthPath, nil)
+
+		// Act
+		suite.app.healthHandler().ServeHTTP(rec, req)
+
+		// Assert
+		suite.Equal(http.StatusServiceUnavailable, rec.Code)
+		suite.Contains(rec.Body.String(), ErrHealthFFMpeg.Error())
+	})
+}
+
+func TestHealth(t *testing.T) {
+	suite.Run(t, new(TestHealthSuite))
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ofstudio/voxify/internal/platforms"
+	"github.com/ofstudio/voxify/internal/services"
+)
+
+// hostsSeparator separates host patterns of a platform in PLATFORM_HOSTS.
+const hostsSeparator = "|"
+
+// registry returns the registry of all download platforms supported by the app.
+func (a *App) registry() *services.PlatformRegistry {
+	return services.NewPlatformRegistry().
+		Register("youtube", platforms.YouTubeHosts, func(hosts []string) services.Platform {
+			return platforms.NewYtDlpPlatform(a.cfg.Settings, a.log, hosts...)
+		}).
+		Register("soundcloud", platforms.SoundCloudHosts, func(hosts []string) services.Platform {
+			return platforms.NewSoundCloudPlatform(a.cfg.Settings, a.log, hosts...)
+		}).
+		Register("vimeo", platforms.VimeoHosts, func(hosts []string) services.Platform {
+			return platforms.NewVimeoPlatform(a.cfg.Settings, a.log, hosts...)
+		})
+}
+
+// newPlatforms creates the download platforms enabled in the config in the order of priority.
+func (a *App) newPlatforms() ([]services.Platform, error) {
+	hosts := make(map[string][]string, len(a.cfg.PlatformHosts))
+	for name, patterns := range a.cfg.PlatformHosts {
+		var list []string
+		for _, pattern := range strings.Split(patterns, hostsSeparator) {
+			if pattern = strings.TrimSpace(pattern); pattern != "" {
+				list = append(list, pattern)
+			}
+		}
+		hosts[name] = list
+	}
+	p, err := a.registry().Build(a.cfg.Platforms, hosts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create platforms: %w", err)
+	}
+	return p, nil
+}
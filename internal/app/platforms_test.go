@@ -0,0 +1,95 @@
+package app
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ofstudio/voxify/internal/config"
+)
+
+// TestPlatformsSuite is a test suite for creating platforms from the config
+type TestPlatformsSuite struct {
+	suite.Suite
+	cfg config.Config
+}
+
+// SetupSubTest is called before each subtest
+func (suite *TestPlatformsSuite) SetupSubTest() {
+	suite.cfg = config.Default()
+}
+
+// TestNewPlatforms tests the newPlatforms method
+func (suite *TestPlatformsSuite) TestNewPlatforms() {
+	suite.Run("Default", func() {
+		// Act
+		p, err := New(suite.cfg, slog.Default()).newPlatforms()
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(p, 1)
+		suite.True(p[0].Match("https://www.youtube.com/watch?v=abc"))
+		suite.False(p[0].Match("https://soundcloud.com/artist/track"))
+		suite.Equal("youtube", p[0].ID())
+	})
+
+	suite.Run("Priority", func() {
+		// Arrange
+		suite.cfg.Platforms = []string{"vimeo", "soundcloud"}
+
+		// Act
+		p, err := New(suite.cfg, slog.Default()).newPlatforms()
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(p, 2)
+		suite.True(p[0].Match("https://vimeo.com/123"))
+		suite.True(p[1].Match("https://soundcloud.com/artist/track"))
+		suite.Equal("vimeo", p[0].ID())
+		suite.Equal("soundcloud", p[1].ID())
+		suite.False(p[0].Match("https://www.youtube.com/watch?v=abc"))
+		suite.False(p[1].Match("https://www.youtube.com/watch?v=abc"))
+	})
+
+	suite.Run("HostsOverride", func() {
+		// Arrange
+		suite.cfg.PlatformHosts = map[string]string{"youtube": "yt.example.com | *.yt.example.com"}
+
+		// Act
+		p, err := New(suite.cfg, slog.Default()).newPlatforms()
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(p, 1)
+		suite.True(p[0].Match("https://yt.example.com/watch?v=abc"))
+		suite.True(p[0].Match("https://www.yt.example.com/watch?v=abc"))
+		suite.False(p[0].Match("https://www.youtube.com/watch?v=abc"))
+	})
+
+	suite.Run("EmptyHosts", func() {
+		// Arrange
+		suite.cfg.PlatformHosts = map[string]string{"youtube": " | "}
+
+		// Act
+		_, err := New(suite.cfg, slog.Default()).newPlatforms()
+
+		// Assert
+		suite.ErrorContains(err, "no hosts configured for platform: youtube")
+	})
+
+	suite.Run("UnknownPlatform", func() {
+		// Arrange
+		suite.cfg.Platforms = []string{"youtube", "dailymotion"}
+
+		// Act
+		_, err := New(suite.cfg, slog.Default()).newPlatforms()
+
+		// Assert
+		suite.ErrorContains(err, "unknown platform: dailymotion")
+	})
+}
+
+func TestPlatforms(t *testing.T) {
+	suite.Run(t, new(TestPlatformsSuite))
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ofstudio/voxify/internal/entities"
+)
+
+// publicPath is the pattern of the public directory files HTTP endpoint.
+// The public directory is flat, so a file is a single path segment.
+const publicPath = "/{file}"
+
+// publicFileTypes are the content types of the public directory files served over HTTP.
+// Other files, e.g. temporary files of the feed or downloads, are not served.
+var publicFileTypes = map[string]string{
+	".mp3": string(entities.MediaMp3),
+	".m4a": string(entities.MediaM4a),
+	".jpg": "image/jpeg",
+}
+
+// publicHandler serves the media and thumbnail files of the public directory.
+// The files are served with http.ServeContent, so range requests
+// for seeking in podcast players and conditional requests are supported.
+func (a *App) publicHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("file")
+		contentType, ok := publicFileTypes[strings.ToLower(filepath.Ext(name))]
+		if !ok || strings.HasPrefix(name, ".") {
+			http.NotFound(w, r)
+			return
+		}
+
+		file, err := os.OpenInRoot(a.cfg.PublicDir, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			a.log.Error("failed to open public file", "file", name, "error", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		//goland:noinspection GoUnhandledErrorResult
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		http.ServeContent(w, r, name, info.ModTime(), file)
+	}
+}
@@ -0,0 +1,167 @@
+package app
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ofstudio/voxify/internal/config"
+)
+
+// TestPublicHandlerSuite is a test suite for the public directory HTTP endpoint
+type TestPublicHandlerSuite struct {
+	suite.Suite
+	app *App
+}
+
+// SetupSubTest is called before each subtest
+func (suite *TestPublicHandlerSuite) SetupSubTest() {
+	cfg := config.Default()
+	cfg.PublicDir = suite.T().TempDir()
+	cfg.ServePublic = true
+	suite.app = New(cfg, slog.Default())
+	suite.app.feed = &fakeFeed{data: []byte("<rss></rss>"), etag: `"etag"`}
+	suite.writeFile("episode.mp3", "0123456789")
+}
+
+// writeFile creates a file in the public directory
+func (suite *TestPublicHandlerSuite) writeFile(name, content string) {
+	suite.Require().NoError(os.WriteFile(filepath.Join(suite.app.cfg.PublicDir, name), []byte(content), 0644))
+}
+
+// serve performs the GET request with the given headers
+func (suite *TestPublicHandlerSuite) serve(path string, headers map[string]string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	suite.app.healthMux().ServeHTTP(rec, req)
+	return rec
+}
+
+// TestPublicHandler tests serving the public directory files
+func (suite *TestPublicHandlerSuite) TestPublicHandler() {
+	suite.Run("OK", func() {
+		// Act
+		rec := suite.serve("/episode.mp3", nil)
+
+		// Assert
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Equal("0123456789", rec.Body.String())
+		suite.Equal("audio/mpeg", rec.Header().Get("Content-Type"))
+		suite.Equal("bytes", rec.Header().Get("Accept-Ranges"))
+		suite.NotEmpty(rec.Header().Get("Last-Modified"))
+	})
+
+	suite.Run("Range", func() {
+		// Act
+		rec := suite.serve("/episode.mp3", map[string]string{"Range": "bytes=2-5"})
+
+		// Assert
+		suite.Equal(http.StatusPartialContent, rec.Code)
+		suite.Equal("2345", rec.Body.String())
+		suite.Equal("bytes 2-5/10", rec.Header().Get("Content-Range"))
+		suite.Equal("audio/mpeg", rec.Header().Get("Content-Type"))
+	})
+
+	suite.Run("RangeNotSatisfiable", func() {
+		// Act
+		rec := suite.serve("/episode.mp3", map[string]string{"Range": "bytes=20-"})
+
+		// Assert
+		suite.Equal(http.StatusRequestedRangeNotSatisfiable, rec.Code)
+	})
+
+	suite.Run("Thumbnail", func() {
+		// Arrange
+		suite.writeFile("episode.jpg", "jpeg")
+
+		// Act
+		rec := suite.serve("/episode.jpg", nil)
+
+		// Assert
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Equal("image/jpeg", rec.Header().Get("Content-Type"))
+	})
+
+	suite.Run("NotFound", func() {
+		// Act
+		rec := suite.serve("/missing.mp3", nil)
+
+		// Assert
+		suite.Equal(http.StatusNotFound, rec.Code)
+	})
+
+	suite.Run("NotServedFiles", func() {
+		// Arrange
+		suite.writeFile("rss.xml.123.tmp", "<rss>")
+		suite.writeFile(".hidden.mp3", "hidden")
+		suite.Require().NoError(os.Mkdir(filepath.Join(suite.app.cfg.PublicDir, "dir.mp3"), 0755))
+
+		for _, path := range []string{"/rss.xml.123.tmp", "/.hidden.mp3", "/dir.mp3", "/../episode.mp3", "/sub/episode.mp3"} {
+			// Act
+			rec := suite.serve(path, nil)
+
+			// Assert
+			suite.NotEqual(http.StatusOK, rec.Code, path)
+			suite.NotEqual(http.StatusPartialContent, rec.Code, path)
+		}
+	})
+
+	suite.Run("Disabled", func() {
+		// Arrange
+		suite.app.cfg.ServePublic = false
+
+		// Act
+		rec := suite.serve("/episode.mp3", nil)
+
+		// Assert
+		suite.Equal(http.StatusNotFound, rec.Code)
+	})
+
+	suite.Run("FeedPath", func() {
+		// Arrange
+		suite.app.cfg.FeedPath = "/podcast/feed"
+
+		// Act
+		rec := suite.serve("/podcast/feed", nil)
+
+		// Assert
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Equal("<rss></rss>", rec.Body.String())
+		suite.Equal("application/rss+xml; charset=utf-8", rec.Header().Get("Content-Type"))
+	})
+
+	suite.Run("FeedTakesPrecedence", func() {
+		// Arrange
+		suite.writeFile("rss.xml", "<rss>stale</rss>")
+
+		// Act
+		rec := suite.serve("/rss.xml", nil)
+
+		// Assert
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Equal("<rss></rss>", rec.Body.String())
+	})
+
+	suite.Run("Health", func() {
+		// Arrange
+		suite.app.cfg.YtDlpPath = ""
+
+		// Act
+		rec := suite.serve(healthPath, nil)
+
+		// Assert
+		suite.Equal(http.StatusServiceUnavailable, rec.Code, "health check must not be served as a file")
+	})
+}
+
+func TestPublicHandler(t *testing.T) {
+	suite.Run(t, new(TestPublicHandlerSuite))
+}
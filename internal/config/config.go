@@ -27,28 +27,90 @@ type Telegram struct {
 
 // Settings - application settings
 type Settings struct {
-	PublicUrl       url.URL                 `env:"PUBLIC_URL,required"`   // Public URL where the public directory is accessible (used in feed)
-	PublicDir       string                  `env:"PUBLIC_DIR,required"`   // Path to public directory where feed and media files are stored
-	DownloadDir     string                  `env:"DOWNLOAD_DIR,required"` // Path to temporary download directory
-	DownloadTimeout time.Duration           `env:"DOWNLOAD_TIMEOUT"`      // Timeout for downloading media
-	DownloadFormat  entities.DownloadFormat `env:"DOWNLOAD_FORMAT"`       // Media download format by default (mp3 or m4a)
-	DownloadQuality string                  `env:"DOWNLOAD_QUALITY"`      // Media download quality by default (e.g., 192k)
-	DownloadWorkers int                     `env:"DOWNLOAD_WORKERS"`      // Number of concurrent download workers
-	ThumbnailSize   int                     `env:"THUMBNAIL_SIZE"`        // Size of the square thumbnail to generate (in pixels)
-	YtDlpPath       string                  `env:"YT_DLP_PATH"`           // Path to yt-dlp executable
-	FFMpegPath      string                  `env:"FFMPEG_PATH"`           // Path to ffmpeg executable
-	FeedFileName    string                  `env:"FEED_FILENAME"`         // Name of the RSS feed file (will be created in PublicDir)
-	FeedTitle       string                  `env:"FEED_TITLE"`            // Title of the RSS feed
-	FeedDescription string                  `env:"FEED_DESC"`             // Description of the RSS feed
-	FeedImage       string                  `env:"FEED_IMAGE"`            // URL of the RSS feed cover image
-	FeedLanguage    string                  `env:"FEED_LANGUAGE"`         // Language of the RSS feed (e.g., en)
-	FeedCategories  []string                `env:"FEED_CATEGORIES"`       // Categories of the RSS feed
-	FeedCategories2 []string                `env:"FEED_CATEGORIES2"`      // Additional categories of the RSS feed
-	FeedCategories3 []string                `env:"FEED_CATEGORIES3"`      // Additional categories of the RSS feed
-	FeedIsExplicit  bool                    `env:"FEED_IS_EXPLICIT"`      // Whether the feed contains explicit content
-	FeedAuthor      string                  `env:"FEED_AUTHOR"`           // Author of the RSS feed
-	FeedLink        string                  `env:"FEED_LINK"`             // Link to the website of the RSS feed
-	FeedKeywords    string                  `env:"FEED_KEYWORDS"`         // Comma-separated keywords for the RSS feed
+	PublicUrl          url.URL                 `env:"PUBLIC_URL,required"`                    // Public URL where the public directory is accessible (used in feed)
+	MediaBaseUrl       url.URL                 `env:"MEDIA_BASE_URL"`                         // Base URL of media and thumbnail files (e.g., CDN). PublicUrl if not set
+	PublicDir          string                  `env:"PUBLIC_DIR,required"`                    // Path to public directory where feed and media files are stored
+	DownloadDir        string                  `env:"DOWNLOAD_DIR,required"`                  // Path to temporary download directory
+	DownloadTimeout    time.Duration           `env:"DOWNLOAD_TIMEOUT"`                       // Default timeout for each download step (metadata, thumbnail, media) and post-processing
+	MetaTimeout        time.Duration           `env:"META_TIMEOUT"`                           // Timeout for downloading metadata. DownloadTimeout if not set
+	ThumbnailTimeout   time.Duration           `env:"THUMBNAIL_TIMEOUT"`                      // Timeout for downloading thumbnail. DownloadTimeout if not set
+	MediaTimeout       time.Duration           `env:"MEDIA_TIMEOUT"`                          // Timeout for downloading media. DownloadTimeout if not set
+	PostProcessTimeout time.Duration           `env:"POSTPROCESS_TIMEOUT"`                    // Timeout for post-processing the media (normalization, checksum). DownloadTimeout if not set
+	DownloadFormat     entities.DownloadFormat `env:"DOWNLOAD_FORMAT"`                        // Media download format by default (mp3 or m4a)
+	DownloadQuality    string                  `env:"DOWNLOAD_QUALITY"`                       // Media download quality by default (e.g., 192k)
+	DownloadRetries    int                     `env:"DOWNLOAD_RETRIES"`                       // Number of retries of a download failed with a transient error
+	DownloadRetryDelay time.Duration           `env:"DOWNLOAD_RETRY_DELAY"`                   // Delay between download retries
+	DownloadWorkers    int                     `env:"DOWNLOAD_WORKERS"`                       // Number of concurrent download workers
+	MaxMediaSize       int64                   `env:"MAX_MEDIA_SIZE"`                         // Maximum size of the media file in bytes. Not limited if not set
+	MinDuration        time.Duration           `env:"MIN_DURATION"`                           // Minimum duration of the media. Not limited if not set
+	AllowPlaylists     bool                    `env:"ALLOW_PLAYLISTS"`                        // Whether to download the entries of a playlist URL as separate requests. Playlists are rejected if not set
+	ThumbnailSize      int                     `env:"THUMBNAIL_SIZE"`                         // Size of the square thumbnail to generate (in pixels)
+	NormalizeAudio     bool                    `env:"NORMALIZE_AUDIO"`                        // Whether to normalize audio loudness to -16 LUFS with ffmpeg
+	PurgeOrphans       bool                    `env:"PURGE_ORPHANS"`                          // Whether to remove media and thumbnail files of the public directory not referenced by any episode on startup
+	PurgeGracePeriod   time.Duration           `env:"PURGE_GRACE_PERIOD"`                     // Minimum age of an orphaned file to remove
+	CleanupGracePeriod time.Duration           `env:"CLEANUP_GRACE_PERIOD"`                   // Minimum age of a stale yt-dlp temporary directory to remove on startup. Download directory is cleaned entirely if not set
+	Platforms          []string                `env:"PLATFORMS"`                              // Enabled download platforms in the order of priority (youtube, soundcloud, vimeo)
+	PlatformHosts      map[string]string       `env:"PLATFORM_HOSTS"`                         // Host patterns overriding the platform defaults (e.g., youtube:youtube.com|*.youtube.com)
+	YtDlpPath          string                  `env:"YT_DLP_PATH"`                            // Path to yt-dlp executable
+	YtDlpUserAgent     string                  `env:"YT_DLP_USER_AGENT"`                      // User-Agent passed to yt-dlp with --user-agent
+	YtDlpExtractorArgs []string                `env:"YT_DLP_EXTRACTOR_ARGS" envSeparator:" "` // Extractor arguments passed to yt-dlp with --extractor-args (e.g., youtube:player_client=web)
+	YtDlpExtraArgs     []string                `env:"YT_DLP_EXTRA_ARGS" envSeparator:" "`     // Additional command line arguments passed to yt-dlp as is
+	FFMpegPath         string                  `env:"FFMPEG_PATH"`                            // Path to ffmpeg executable
+	FeedFileName       string                  `env:"FEED_FILENAME"`                          // Name of the RSS feed file (will be created in PublicDir)
+	FeedTitle          string                  `env:"FEED_TITLE"`                             // Title of the RSS feed
+	FeedDescription    string                  `env:"FEED_DESC"`                              // Description of the RSS feed
+	FeedImage          string                  `env:"FEED_IMAGE"`                             // URL of the RSS feed cover image
+	FeedRssImage       bool                    `env:"FEED_RSS_IMAGE"`                         // Whether to add the standard RSS <image> tag besides <itunes:image>
+	FeedLanguage       string                  `env:"FEED_LANGUAGE"`                          // Language of the RSS feed (e.g., en)
+	FeedCategories     []string                `env:"FEED_CATEGORIES"`                        // Categories of the RSS feed
+	FeedCategories2    []string                `env:"FEED_CATEGORIES2"`                       // Additional categories of the RSS feed
+	FeedCategories3    []string                `env:"FEED_CATEGORIES3"`                       // Additional categories of the RSS feed
+	FeedIsExplicit     bool                    `env:"FEED_IS_EXPLICIT"`                       // Whether the feed contains explicit content
+	FeedAuthor         string                  `env:"FEED_AUTHOR"`                            // Author of the RSS feed
+	FeedLink           string                  `env:"FEED_LINK"`                              // Link to the website of the RSS feed
+	FeedKeywords       string                  `env:"FEED_KEYWORDS"`                          // Comma-separated keywords for the RSS feed
+	FeedCopyright      string                  `env:"FEED_COPYRIGHT"`                         // Copyright notice of the RSS feed
+	FeedOwnerName      string                  `env:"FEED_OWNER_NAME"`                        // Name of the podcast owner
+	FeedOwnerEmail     string                  `env:"FEED_OWNER_EMAIL"`                       // Email of the podcast owner
+	FeedBlocked        bool                    `env:"FEED_BLOCKED"`                           // Whether to hide the podcast from Apple Podcasts
+	FeedComplete       bool                    `env:"FEED_COMPLETE"`                          // Whether the podcast is complete and no new episodes will be added
+	FeedType           entities.FeedType       `env:"FEED_TYPE"`                              // Type of the show (episodic or serial)
+	FeedSortOrder      entities.FeedSortOrder  `env:"FEED_SORT_ORDER"`                        // Order of episodes in the feed (newest or oldest first)
+	FeedSkipInvalid    bool                    `env:"FEED_SKIP_INVALID"`                      // Whether to skip invalid episodes instead of failing the feed build
+	HubURL             string                  `env:"FEED_HUB_URL"`                           // WebSub hub URL to advertise in the feed and notify on feed updates
+	FeedCheckArtwork   bool                    `env:"FEED_CHECK_ARTWORK"`                     // Whether to check that the feed artwork is reachable on feed validation
+	HttpTimeout        time.Duration           `env:"HTTP_TIMEOUT"`                           // Timeout of outgoing HTTP requests (hub notifications, artwork checks), retries included
+	HttpRetries        int                     `env:"HTTP_RETRIES"`                           // Number of retries of an outgoing HTTP request failed with a network or server error
+	HttpRetryDelay     time.Duration           `env:"HTTP_RETRY_DELAY"`                       // Delay between outgoing HTTP request retries
+	HealthAddr         string                  `env:"HEALTH_ADDR"`                            // Address of the health check HTTP server (e.g., :8080). Disabled if empty
+	BotLanguage        string                  `env:"BOT_LANGUAGE"`                           // Language of the bot messages for users with an unsupported language (en or ru)
+
+	EpisodeDefaultExplicit *bool `env:"EPISODE_DEFAULT_EXPLICIT"` // Whether the episodes without their own value contain explicit content. FeedIsExplicit if not set
+
+	DefaultEpisodeImage string `env:"DEFAULT_EPISODE_IMAGE"` // URL of the image of the episodes without thumbnail. Channel image is inherited if not set
+
+	MediaFilenameTemplate string `env:"MEDIA_FILENAME_TEMPLATE"` // Name of the media and thumbnail files without extension with {id}, {videoid} and {date} placeholders
+
+	FeedPath    string `env:"FEED_PATH"`    // Path of the feed endpoint of the HTTP server at HealthAddr. /<FeedFileName> if not set
+	ServePublic bool   `env:"SERVE_PUBLIC"` // Whether to serve the media and thumbnail files of the public directory at HealthAddr
+
+	MaxTitleLength int `env:"FEED_MAX_TITLE_LENGTH"` // Maximum length of the episode titles in the feed in characters. Not limited if not set
+
+	DownloadQueueSize int `env:"DOWNLOAD_QUEUE_SIZE"` // Maximum number of requests waiting for a download worker
+
+	MaxPlaylistEntries int `env:"MAX_PLAYLIST_ENTRIES"` // Maximum number of the playlist entries queued for download. Not limited if not set
 
 	SupportedDownloadFormats []entities.DownloadFormat // Supported media download formats
 }
+
+// MediaUrl returns the public URL of the media or thumbnail file.
+// The file is served from MediaBaseUrl if set and from PublicUrl otherwise.
+// The file name is path-escaped, so spaces, percent signs, slashes
+// and non-ASCII characters are kept as part of the name.
+func (s *Settings) MediaUrl(fileName string) string {
+	base := s.PublicUrl
+	if s.MediaBaseUrl.Host != "" {
+		base = s.MediaBaseUrl
+	}
+	return base.JoinPath(url.PathEscape(fileName)).String()
+}
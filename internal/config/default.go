@@ -10,23 +10,38 @@ import (
 func Default() Config {
 	return Config{
 		DB: DB{
-			Version: 1,
+			Version: 11,
 		},
 		Settings: Settings{
-			DownloadTimeout: 1 * time.Hour,
-			YtDlpPath:       "yt-dlp",
-			FFMpegPath:      "ffmpeg",
-			ThumbnailSize:   3000,
-			DownloadFormat:  entities.DownloadMp3,
-			DownloadQuality: "192k",
-			DownloadWorkers: 2,
-			FeedFileName:    "rss.xml",
-			FeedTitle:       "Voxify Podcast",
-			FeedDescription: "This is a podcast feed generated by Voxify — https://github.com/ofstudio/voxify",
-			FeedLink:        "https://github.com/ofstudio/voxify",
-			FeedImage:       "https://raw.githubusercontent.com/ofstudio/voxify/refs/heads/master/assets/voxify-cover-dark.png",
-			FeedLanguage:    "en",
-			FeedCategories:  []string{"Technology"},
+			DownloadTimeout:    1 * time.Hour,
+			Platforms:          []string{"youtube"},
+			YtDlpPath:          "yt-dlp",
+			FFMpegPath:         "ffmpeg",
+			ThumbnailSize:      3000,
+			DownloadFormat:     entities.DownloadMp3,
+			DownloadQuality:    "192k",
+			DownloadWorkers:    2,
+			DownloadRetries:    2,
+			DownloadRetryDelay: 30 * time.Second,
+			PurgeGracePeriod:   24 * time.Hour,
+			FeedFileName:       "rss.xml",
+			FeedTitle:          "Voxify Podcast",
+			FeedDescription:    "This is a podcast feed generated by Voxify — https://github.com/ofstudio/voxify",
+			FeedLink:           "https://github.com/ofstudio/voxify",
+			FeedImage:          "https://raw.githubusercontent.com/ofstudio/voxify/refs/heads/master/assets/voxify-cover-dark.png",
+			FeedLanguage:       "en",
+			FeedCategories:     []string{"Technology"},
+			FeedSortOrder:      entities.FeedSortNewest,
+			HttpTimeout:        10 * time.Second,
+			HttpRetries:        2,
+			HttpRetryDelay:     time.Second,
+			BotLanguage:        "en",
+
+			MediaFilenameTemplate: "{id}",
+
+			DownloadQueueSize: 20,
+
+			MaxPlaylistEntries: 50,
 
 			SupportedDownloadFormats: []entities.DownloadFormat{
 				entities.DownloadMp3,
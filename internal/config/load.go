@@ -7,10 +7,14 @@ import (
 )
 
 // Load loads configuration from [Default] and environment variables
+// and validates it with [Config.Validate].
 func Load() (Config, error) {
 	c := Default()
 	if err := env.Parse(&c); err != nil {
 		return Config{}, fmt.Errorf("failed to parse environment variables: %w", err)
 	}
+	if err := c.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid configuration:\n%w", err)
+	}
 	return c, nil
 }
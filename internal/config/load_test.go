@@ -0,0 +1,80 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// TestLoadSuite is a test suite for configuration loading
+type TestLoadSuite struct {
+	suite.Suite
+}
+
+// SetupSubTest is called before each subtest
+func (suite *TestLoadSuite) SetupSubTest() {
+	suite.T().Setenv("DB_FILEPATH", "voxify.db")
+	suite.T().Setenv("TELEGRAM_BOT_TOKEN", "token")
+	suite.T().Setenv("TELEGRAM_ALLOWED_USERS", "123")
+	suite.T().Setenv("PUBLIC_URL", "https://example.com/public")
+	suite.T().Setenv("PUBLIC_DIR", suite.T().TempDir())
+	suite.T().Setenv("DOWNLOAD_DIR", suite.T().TempDir())
+}
+
+// TestLoad tests the Load function
+func (suite *TestLoadSuite) TestLoad() {
+	suite.Run("Defaults", func() {
+		// Act
+		cfg, err := Load()
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("rss.xml", cfg.FeedFileName)
+	})
+
+	suite.Run("RssExtension", func() {
+		// Arrange
+		suite.T().Setenv("FEED_FILENAME", "podcast.RSS")
+
+		// Act
+		cfg, err := Load()
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("podcast.RSS", cfg.FeedFileName)
+	})
+
+	suite.Run("InvalidSettings", func() {
+		// Arrange
+		suite.T().Setenv("FEED_TITLE", " ")
+		suite.T().Setenv("FFMPEG_PATH", " ")
+
+		// Act
+		_, err := Load()
+
+		// Assert
+		suite.Require().Error(err)
+		suite.Contains(err.Error(), "invalid configuration")
+		suite.Contains(err.Error(), "FEED_TITLE must not be empty")
+		suite.Contains(err.Error(), "FFMPEG_PATH must not be empty")
+	})
+
+	for _, name := range []string{"feed", "feed.json", "feeds/rss.xml", "../rss.xml", ".xml"} {
+		suite.Run("InvalidFeedFileName "+name, func() {
+			// Arrange
+			suite.T().Setenv("FEED_FILENAME", name)
+
+			// Act
+			_, err := Load()
+
+			// Assert
+			suite.Require().Error(err)
+			suite.Contains(err.Error(), "invalid feed file name")
+		})
+	}
+}
+
+// Run the test suite
+func TestLoad(t *testing.T) {
+	suite.Run(t, new(TestLoadSuite))
+}
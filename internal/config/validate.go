@@ -0,0 +1,87 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ofstudio/voxify/internal/locales"
+)
+
+// feedFileExts are the allowed extensions of the feed file name
+var feedFileExts = []string{".xml", ".rss"}
+
+// Validate checks the configuration and returns an error listing every problem found.
+func (c *Config) Validate() error {
+	var errs []error
+	if strings.TrimSpace(c.BotToken) == "" {
+		errs = append(errs, errors.New("TELEGRAM_BOT_TOKEN must not be empty"))
+	}
+	if err := c.Settings.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the required settings and returns an error listing every problem found.
+// The settings are checked as configured: directories and executables are checked by the services on start.
+func (s *Settings) Validate() error {
+	var errs []error
+	required := []struct {
+		name  string
+		value string
+	}{
+		{"PUBLIC_DIR", s.PublicDir},
+		{"YT_DLP_PATH", s.YtDlpPath},
+		{"FFMPEG_PATH", s.FFMpegPath},
+		{"FEED_TITLE", s.FeedTitle},
+		{"FEED_DESC", s.FeedDescription},
+		{"FEED_IMAGE", s.FeedImage},
+		{"FEED_LANGUAGE", s.FeedLanguage},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			errs = append(errs, fmt.Errorf("%s must not be empty", r.name))
+		}
+	}
+	if len(s.FeedCategories) == 0 {
+		errs = append(errs, errors.New("FEED_CATEGORIES must contain at least one category"))
+	}
+	if err := validateFeedFileName(s.FeedFileName); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateFeedPath(s.FeedPath); err != nil {
+		errs = append(errs, err)
+	}
+	if !locales.Supported(s.BotLanguage) {
+		errs = append(errs, fmt.Errorf("unsupported BOT_LANGUAGE %q: must be one of %s, %s", s.BotLanguage, locales.LangEn, locales.LangRu))
+	}
+	return errors.Join(errs...)
+}
+
+// validateFeedPath checks that the feed path, if set, is an absolute URL path
+// of a single resource, e.g. /feed or /podcast/rss.xml.
+func validateFeedPath(path string) error {
+	if path == "" {
+		return nil
+	}
+	if !strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") || strings.ContainsAny(path, " ?#{}") {
+		return fmt.Errorf("invalid feed path %q: must start with / and not end with /", path)
+	}
+	return nil
+}
+
+// validateFeedFileName checks that the feed file name is a plain file name
+// with one of the feedFileExts extensions, e.g. rss.xml or podcast.rss.
+func validateFeedFileName(name string) error {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid feed file name %q: must be a file name without directories", name)
+	}
+	if !slices.Contains(feedFileExts, strings.ToLower(filepath.Ext(name))) {
+		return fmt.Errorf("invalid feed file name %q: extension must be one of %s",
+			name, strings.Join(feedFileExts, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// TestValidateSuite is a test suite for configuration validation
+type TestValidateSuite struct {
+	suite.Suite
+}
+
+// validConfig returns the default configuration with the required values set
+func (suite *TestValidateSuite) validConfig() Config {
+	c := Default()
+	c.BotToken = "token"
+	c.PublicDir = "/var/lib/voxify/public"
+	return c
+}
+
+// TestValidate tests the Validate method
+func (suite *TestValidateSuite) TestValidate() {
+	suite.Run("Valid", func() {
+		// Arrange
+		c := suite.validConfig()
+
+		// Act
+		err := c.Validate()
+
+		// Assert
+		suite.NoError(err)
+	})
+
+	suite.Run("MissingFields", func() {
+		// Arrange
+		c := suite.validConfig()
+		c.BotToken = ""
+		c.PublicDir = ""
+		c.YtDlpPath = " "
+		c.FFMpegPath = ""
+		c.FeedTitle = ""
+		c.FeedDescription = ""
+		c.FeedImage = ""
+		c.FeedLanguage = ""
+		c.FeedCategories = nil
+		c.FeedFileName = "feed.json"
+		c.FeedPath = "feed/"
+		c.BotLanguage = "de"
+
+		// Act
+		err := c.Validate()
+
+		// Assert
+		suite.Require().Error(err)
+		for _, problem := range []string{
+			"TELEGRAM_BOT_TOKEN must not be empty",
+			"PUBLIC_DIR must not be empty",
+			"YT_DLP_PATH must not be empty",
+			"FFMPEG_PATH must not be empty",
+			"FEED_TITLE must not be empty",
+			"FEED_DESC must not be empty",
+			"FEED_IMAGE must not be empty",
+			"FEED_LANGUAGE must not be empty",
+			"FEED_CATEGORIES must contain at least one category",
+			`invalid feed file name "feed.json"`,
+			`invalid feed path "feed/"`,
+			`unsupported BOT_LANGUAGE "de"`,
+		} {
+			suite.Contains(err.Error(), problem)
+		}
+	})
+
+	suite.Run("SettingsOnly", func() {
+		// Arrange
+		c := suite.validConfig()
+		c.BotToken = ""
+
+		// Act
+		err := c.Settings.Validate()
+
+		// Assert
+		suite.NoError(err)
+	})
+}
+
+// Run the test suite
+func TestValidate(t *testing.T) {
+	suite.Run(t, new(TestValidateSuite))
+}
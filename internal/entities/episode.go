@@ -17,10 +17,16 @@ type Episode struct {
 	MediaType     MediaType
 	MediaDuration int64
 	MediaSize     int64
+	MediaHash     string // SHA-256 checksum of the media file, hex encoded. Empty if not computed
 	Author        string
 	OriginalURL   string
 	CanonicalURL  string
+	EpisodeNumber int       // Episode number set by the user, 0 if not set
+	SeasonNumber  int       // Season number of the episodes of a playlist, 0 if not set
+	PublishedAt   time.Time // Original publish time of the media on the platform, zero if not reported
+	Explicit      *bool     // Parental advisory of the episode, nil to apply the feed default
 	CreatedAt     time.Time
+	UpdatedAt     time.Time // Time of the last metadata change, equal to CreatedAt if never updated
 }
 
 // MediaType is the MIME type of the media file.
@@ -31,17 +37,24 @@ const (
 	MediaM4a = feedcast.M4a
 )
 
-// LogValue implements slog.LogValuer interface for automatic logging
+// PubDate returns the publish date of the episode in the feed:
+// the original publish time if reported and the download time otherwise.
+func (p *Episode) PubDate() time.Time {
+	if !p.PublishedAt.IsZero() {
+		return p.PublishedAt
+	}
+	return p.CreatedAt
+}
+
+// LogValue implements slog.LogValuer interface for automatic logging.
+// Only the identifying fields are logged, the description is omitted.
 func (p *Episode) LogValue() slog.Value {
 	return slog.GroupValue(
 		slog.Int64("id", p.ID),
-		slog.String("thumbnail_filename", p.ThumbnailFile),
-		slog.String("media_filename", p.MediaFile),
-		slog.Int64("media_duration", p.MediaDuration),
-		slog.String("media_type", string(p.MediaType)),
+		slog.String("title", p.Title),
+		slog.String("media_file", p.MediaFile),
 		slog.Int64("media_size", p.MediaSize),
+		slog.Int64("media_duration", p.MediaDuration),
 		slog.String("original_url", p.OriginalURL),
-		slog.String("canonical_url", p.CanonicalURL),
-		slog.Time("created_at", p.CreatedAt),
 	)
 }
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// TestEpisodeSuite is a test suite for the Episode entity
+type TestEpisodeSuite struct {
+	suite.Suite
+}
+
+// TestLogValue tests that only the identifying fields of the episode are logged
+func (suite *TestEpisodeSuite) TestLogValue() {
+	// Arrange
+	episode := &Episode{
+		ID:            42,
+		Title:         "Test Episode",
+		Description:   "A very long description of the episode",
+		MediaFile:     "episode42.mp3",
+		MediaSize:     1024000,
+		MediaDuration: 3600,
+		OriginalURL:   "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+	}
+	var buf bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&buf, nil))
+
+	// Act
+	log.Info("test", "episode", episode)
+
+	// Assert
+	out := buf.String()
+	suite.Contains(out, "episode.id=42")
+	suite.Contains(out, `episode.title="Test Episode"`)
+	suite.Contains(out, "episode.media_file=episode42.mp3")
+	suite.Contains(out, "episode.media_size=1024000")
+	suite.Contains(out, "episode.media_duration=3600")
+	suite.Contains(out, `episode.original_url="https://www.youtube.com/watch?v=dQw4w9WgXcQ"`)
+	suite.NotContains(out, "description")
+	suite.NotContains(out, episode.Description)
+}
+
+// TestPubDate tests that the original publish time takes precedence over the download time
+func (suite *TestEpisodeSuite) TestPubDate() {
+	createdAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	publishedAt := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	suite.Run("Published", func() {
+		episode := &Episode{PublishedAt: publishedAt, CreatedAt: createdAt}
+		suite.Equal(publishedAt, episode.PubDate())
+	})
+
+	suite.Run("NotReported", func() {
+		episode := &Episode{CreatedAt: createdAt}
+		suite.Equal(createdAt, episode.PubDate())
+	})
+}
+
+// Run the test suite
+func TestEpisode(t *testing.T) {
+	suite.Run(t, new(TestEpisodeSuite))
+}
@@ -29,6 +29,13 @@ type Feed struct {
 	EpisodeCount  int            // Number of episodes in the feed
 }
 
+// FeedBuildReport contains the summary of the feed build
+type FeedBuildReport struct {
+	EpisodeCount int           // Number of episodes included in the feed
+	FeedSize     int64         // Size of the feed file in bytes
+	Duration     time.Duration // Time taken to build the feed
+}
+
 // FeedOwner contains information about podcast owner
 type FeedOwner struct {
 	Name  string // Owner name
@@ -48,3 +55,11 @@ const (
 	FeedTypeEpisodic = feedcast.TypeEpisodic
 	FeedTypeSerial   = feedcast.TypeSerial
 )
+
+// FeedSortOrder is the order in which episodes are listed in the feed.
+type FeedSortOrder string
+
+const (
+	FeedSortNewest FeedSortOrder = "newest" // Newest episodes first
+	FeedSortOldest FeedSortOrder = "oldest" // Oldest episodes first
+)
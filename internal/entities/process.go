@@ -15,6 +15,17 @@ type Process struct {
 	Episode   *Episode
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// StepTimings holds the durations of the completed steps
+	StepTimings map[Step]time.Duration
+
+	// QueuePosition is the position of the accepted process in the download queue.
+	// It is set only in the notification sent when the process waits for a worker and is not stored.
+	QueuePosition int
+
+	// Expanded is the number of playlist entries queued by the process.
+	// It is set only in the notification sent when the playlist is expanded and is not stored.
+	Expanded int
 }
 
 // Step is the current step of the processing task.
@@ -49,6 +60,11 @@ func (p *Process) LogValue() slog.Value {
 	if p.Episode != nil {
 		attrs = append(attrs, slog.Any("episode", p.Episode.LogValue()))
 	}
+	for _, step := range []Step{StepCreating, StepDownloading, StepPublishing} {
+		if d, ok := p.StepTimings[step]; ok {
+			attrs = append(attrs, slog.Duration(string(step)+"_time", d))
+		}
+	}
 	attrs = append(attrs,
 		slog.Time("created_at", p.CreatedAt),
 		slog.Time("updated_at", p.UpdatedAt),
@@ -12,6 +12,10 @@ type Request struct {
 	DownloadFormat  DownloadFormat
 	DownloadQuality string
 	Force           bool
+	TitleOverride   string // Episode title replacing the detected one, if set
+	EpisodeNumber   int    // Episode number set by the user, 0 if not set
+	SeasonNumber    int    // Season number of the playlist entries, 0 if not set
+	Lang            string // Language code of the user for notifications (e.g., en)
 }
 
 // DownloadFormat is the format in which media should be downloaded.
@@ -40,5 +44,14 @@ func (p *Request) LogValue() slog.Value {
 	if p.Force {
 		attrs = append(attrs, slog.Bool("force", p.Force))
 	}
+	if p.TitleOverride != "" {
+		attrs = append(attrs, slog.String("title_override", p.TitleOverride))
+	}
+	if p.EpisodeNumber != 0 {
+		attrs = append(attrs, slog.Int("episode_number", p.EpisodeNumber))
+	}
+	if p.SeasonNumber != 0 {
+		attrs = append(attrs, slog.Int("season_number", p.SeasonNumber))
+	}
 	return slog.GroupValue(attrs...)
 }
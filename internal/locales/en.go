@@ -1,9 +1,8 @@
 package locales
 
-const (
-	// Message templates
-
-	MsgStart = `🎧 **Welcome to Voxify Bot!**
+// en is the table of English messages.
+var en = Messages{
+	Start: `🎧 **Welcome to Voxify Bot!**
 
 I help you convert videos into audio RSS feeds. Simply send me a URL from YouTube, and I'll:
 
@@ -14,38 +13,74 @@ I help you convert videos into audio RSS feeds. Simply send me a URL from YouTub
 
 Just paste any video or podcast URL to get started! 
 
-Perfect for creating your own podcast collection or listening to content offline.`
+Perfect for creating your own podcast collection or listening to content offline.`,
+
+	DownloadStarted:  "🔄 Started downloading podcast...",
+	DownloadQueued:   "🕒 Added to the download queue, position %d.",
+	DownloadPlaylist: "📋 This link is a playlist: %d of its episodes will be downloaded separately.",
+	DownloadBusy:     "⏳ Another download is in progress. Please try again later...",
+	DownloadSuccess:  "✅ Podcast downloaded successfully!\n\n🎧 %s",
+	DownloadLinks:    "\n\n🔗 <a href=\"%s\">Media file</a> · <a href=\"%s\">RSS feed</a>",
 
-	MsgDownloadStarted = "🔄 Started downloading podcast..."
-	MsgDownloadBusy    = "⏳ Another download is in progress. Please try again later..."
-	MsgDownloadSuccess = "✅ Podcast downloaded successfully!\n\n🎧 %s"
+	StepTimings:     "\n\n⏱️ %s",
+	StepCreating:    "creating",
+	StepDownloading: "downloading",
+	StepPublishing:  "publishing",
 
 	// General error messages
 
-	MsgSomethingWentWrong         = "⚠️ Something went wrong while downloading the podcast."
-	MsgSomethingWentWrongWithCode = "⚠️ Something went wrong while downloading the podcast (error %d)."
+	SomethingWentWrong:         "⚠️ Something went wrong while downloading the podcast.",
+	SomethingWentWrongWithCode: "⚠️ Something went wrong while downloading the podcast (error %d).",
 
 	// Error messages for codes 100-199
 
-	MsgNoMatchingPlatform = "⚠️ This URL is not supported. Please provide a valid video URL."  // services.ErrNoMatchingPlatform
-	MsgDownloadFailed     = "⚠️ Download failed. The media might be unavailable or protected." // services.ErrDownloadFailed
-	MsgEpisodeInProgress  = "⚠️ This episode is already being processed. Please wait."         // services.ErrEpisodeInProgress
-	MsgEpisodeExists      = "⚠️ This episode has already been downloaded."                     // services.ErrEpisodeExists
-	MsgProcessInterrupted = "⚠️ Download was interrupted. Please try again."                   // services.ErrProcessInterrupted
-	MsgEmptyFeed          = "⚠️ The feed has no items to process."                             // services.ErrEmptyFeed
-	MsgInvalidRequest     = "⚠️ This request is invalid."                                      // services.ErrInvalidRequest
-
-	MsgBuildSuccess = "✅ RSS feed built successfully!"
-
-	MsgFeedInfoBasic      = "📻 Podcast information\n\n<b>%s</b>\n\n%s\n\n"
-	MsgFeedInfoAuthor     = "👨‍💻 By %s\n"
-	MsgFeedInfoLanguage   = "🌐 Language: %s\n"
-	MsgFeedInfoCategories = "📚 Categories: %s\n"
-	MsgFeedInfoKeywords   = "🔑 Keywords: %s\n"
-	MsgFeedInfoArtwork    = "🖼️ <a href=\"%s\">Artwork</a>\n"
-	MsgFeedInfoWebsite    = "🔗 <a href=\"%s\">Website</a>\n"
-	MsgFeedInfoEpisodes   = "🎧 Number of episodes: %d\n"
-	MsgFeedInfoNoEpisodes = "📭 No episodes yet\n"
-	MsgFeedInfoExplicit   = "🔞 Explicit content\n"
-	MsgFeedInfoRSS        = "\n📡 RSS: %s"
-)
+	NoMatchingPlatform: "⚠️ This URL is not supported. Please provide a valid video URL.",
+	DownloadFailed:     "⚠️ Download failed. The media might be unavailable or protected.",
+	EpisodeInProgress:  "⚠️ This episode is already being processed. Please wait.",
+	EpisodeExists:      "⚠️ This episode has already been downloaded.",
+	ProcessInterrupted: "⚠️ Download was interrupted. Please try again.",
+	EmptyFeed:          "⚠️ The feed has no items to process.",
+	InvalidRequest:     "⚠️ This request is invalid.",
+	EpisodeNotFound:    "⚠️ Episode not found.",
+	FeedInvalid:        "⚠️ The feed is invalid. Use /validate to see the details.",
+	DownloadTimeout:    "⚠️ Download timed out. The media might be too large, try again later.",
+	DiskFull:           "⚠️ Server storage is full. Please free up some space and try again.",
+	TooShort:           "⚠️ This media is too short to be added to the podcast.",
+
+	PlaylistNotSupported: "⚠️ Playlists are not supported. Send a link to a single video.",
+
+	UnsupportedFormatForPlatform: "⚠️ This format is not available for this link. Try another format.",
+
+	BuildSuccess: "✅ RSS feed built successfully!",
+	BuildReport:  "\n\n🎧 Episodes: %d\n📄 Feed size: %s\n⏱️ Built in %s",
+
+	ValidateSuccess: "✅ RSS feed is valid!",
+	ValidateFailed:  "⚠️ RSS feed is invalid: %s",
+
+	FeedInfoBasic:      "📻 Podcast information\n\n<b>%s</b>\n\n%s\n\n",
+	FeedInfoAuthor:     "👨‍💻 By %s\n",
+	FeedInfoOwner:      "👤 Owner: %s\n",
+	FeedInfoCopyright:  "©️ %s\n",
+	FeedInfoLanguage:   "🌐 Language: %s\n",
+	FeedInfoCategories: "📚 Categories: %s\n",
+	FeedInfoKeywords:   "🔑 Keywords: %s\n",
+	FeedInfoArtwork:    "🖼️ <a href=\"%s\">Artwork</a>\n",
+	FeedInfoWebsite:    "🔗 <a href=\"%s\">Website</a>\n",
+	FeedInfoEpisodes:   "🎧 Number of episodes: %d\n",
+	FeedInfoNoEpisodes: "📭 No episodes yet\n",
+	FeedInfoExplicit:   "🔞 Explicit content\n",
+	FeedInfoCompleted:  "🏁 Show completed, no new episodes\n",
+	FeedInfoBlocked:    "🚫 Hidden from Apple Podcasts\n",
+	FeedInfoRSS:        "\n📡 RSS: %s",
+
+	ListHeader: "🎧 <b>Recent episodes</b>\n\n",
+	ListItem:   "%d. <a href=\"%s\">%s</a> (%s)\n",
+	ListEmpty:  "📭 No episodes yet. Send me a video URL to add the first one!",
+	ListNext:   "Next ▶️",
+
+	StatusHeader: "⏳ <b>Active downloads</b>\n\n",
+	StatusItem:   "%d. %s — %s, %s\n",
+	StatusEmpty:  "💤 No active downloads.",
+
+	AddUsage: "⚠️ Usage: /add <url> | title | episode number\n\nTitle and episode number are optional.",
+}
@@ -0,0 +1,125 @@
+// Package locales provides the bot messages in the supported languages.
+package locales
+
+import (
+	"strings"
+)
+
+// Lang is a language of the bot messages.
+type Lang string
+
+const (
+	LangEn Lang = "en" // English
+	LangRu Lang = "ru" // Russian
+
+	DefaultLang = LangEn
+)
+
+// tables holds the messages of the supported languages.
+var tables = map[Lang]*Messages{
+	LangEn: &en,
+	LangRu: &ru,
+}
+
+// Get returns the messages in the language of the given code.
+// The code is a Telegram user language_code, i.e. IETF language tag (e.g. "en" or "pt-br").
+// If the language is not supported, the messages in the fallback language are returned,
+// and the messages in DefaultLang if the fallback language is not supported either.
+func Get(code, fallback string) *Messages {
+	if m, ok := tables[parseLang(code)]; ok {
+		return m
+	}
+	if m, ok := tables[parseLang(fallback)]; ok {
+		return m
+	}
+	return tables[DefaultLang]
+}
+
+// Supported reports whether the language of the code is supported, e.g. "en" or "ru-RU".
+func Supported(code string) bool {
+	_, ok := tables[parseLang(code)]
+	return ok
+}
+
+// parseLang returns the language of the IETF language tag ignoring the region.
+func parseLang(code string) Lang {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	return Lang(code)
+}
+
+// Messages is a table of the bot messages in one language.
+// Messages with formatting verbs are used with fmt.Sprintf.
+type Messages struct {
+	Start            string
+	DownloadStarted  string
+	DownloadQueued   string // Position in the download queue
+	DownloadPlaylist string // Number of queued playlist entries
+	DownloadBusy     string
+	DownloadSuccess  string // Episode title
+	DownloadLinks    string // Media file URL, feed URL
+
+	StepTimings     string // Comma-separated step durations
+	StepCreating    string
+	StepDownloading string
+	StepPublishing  string
+
+	// General error messages
+
+	SomethingWentWrong         string
+	SomethingWentWrongWithCode string // Error code
+
+	// Error messages for codes 100-199
+
+	NoMatchingPlatform string // services.ErrNoMatchingPlatform
+	DownloadFailed     string // services.ErrDownloadFailed
+	EpisodeInProgress  string // services.ErrEpisodeInProgress
+	EpisodeExists      string // services.ErrEpisodeExists
+	ProcessInterrupted string // services.ErrProcessInterrupted
+	EmptyFeed          string // services.ErrEmptyFeed
+	InvalidRequest     string // services.ErrInvalidRequest
+	EpisodeNotFound    string // services.ErrEpisodeNotFound
+	FeedInvalid        string // services.ErrFeedInvalid
+	DownloadTimeout    string // services.ErrDownloadTimeout
+	DiskFull           string // services.ErrDiskFull
+	TooShort           string // services.ErrTooShort
+
+	PlaylistNotSupported string // services.ErrPlaylistNotSupported
+
+	UnsupportedFormatForPlatform string // services.ErrUnsupportedFormatForPlatform
+
+	BuildSuccess string
+	BuildReport  string // Episodes count, feed size, build duration
+
+	ValidateSuccess string
+	ValidateFailed  string // Validation error
+
+	FeedInfoBasic      string // Title, description
+	FeedInfoAuthor     string // Author
+	FeedInfoOwner      string // Owner name and email
+	FeedInfoCopyright  string // Copyright
+	FeedInfoLanguage   string // Language
+	FeedInfoCategories string // Categories
+	FeedInfoKeywords   string // Keywords
+	FeedInfoArtwork    string // Artwork URL
+	FeedInfoWebsite    string // Website URL
+	FeedInfoEpisodes   string // Number of episodes
+	FeedInfoNoEpisodes string
+	FeedInfoExplicit   string
+	FeedInfoCompleted  string
+	FeedInfoBlocked    string
+	FeedInfoRSS        string // RSS URL
+
+	ListHeader string
+	ListItem   string // Number, media URL, title, duration
+	ListEmpty  string
+	ListNext   string
+
+	StatusHeader string
+	StatusItem   string // Number, request URL, step, elapsed time
+	StatusEmpty  string
+
+	AddUsage string
+}
@@ -0,0 +1,95 @@
+package locales
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// TestLocalesSuite is a test suite for the message tables
+type TestLocalesSuite struct {
+	suite.Suite
+}
+
+// TestGet tests that messages are selected by the language code
+func (suite *TestLocalesSuite) TestGet() {
+	tests := []struct {
+		name     string
+		code     string
+		fallback string
+		want     *Messages
+	}{
+		{"English", "en", "ru", &en},
+		{"Russian", "ru", "en", &ru},
+		{"RegionTag", "ru-RU", "en", &ru},
+		{"UpperCase", "EN", "ru", &en},
+		{"Underscore", "ru_ua", "en", &ru},
+		{"UnknownFallsBack", "de", "ru", &ru},
+		{"EmptyFallsBack", "", "ru", &ru},
+		{"UnknownFallbackFallsBackToDefault", "de", "fr", tables[DefaultLang]},
+		{"EmptyFallbackFallsBackToDefault", "", "", tables[DefaultLang]},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Act
+			m := Get(tt.code, tt.fallback)
+
+			// Assert
+			suite.Same(tt.want, m)
+		})
+	}
+
+	suite.Run("LanguageStrings", func() {
+		suite.Equal("🔄 Started downloading podcast...", Get("en", "").DownloadStarted)
+		suite.Equal("🔄 Начинаю скачивать подкаст...", Get("ru", "").DownloadStarted)
+	})
+}
+
+// TestSupported tests the supported language codes
+func (suite *TestLocalesSuite) TestSupported() {
+	suite.True(Supported("en"))
+	suite.True(Supported("ru-RU"))
+	suite.False(Supported("de"))
+	suite.False(Supported(""))
+}
+
+// TestTablesComplete tests that every supported language has all the messages
+func (suite *TestLocalesSuite) TestTablesComplete() {
+	for lang, m := range tables {
+		v := reflect.ValueOf(*m)
+		for i := 0; i < v.NumField(); i++ {
+			suite.NotEmpty(v.Field(i).String(), "language %s: message %s is empty", lang, v.Type().Field(i).Name)
+		}
+	}
+}
+
+// TestTablesFormatVerbs tests that translations keep the formatting verbs of the default language
+func (suite *TestLocalesSuite) TestTablesFormatVerbs() {
+	def := reflect.ValueOf(*tables[DefaultLang])
+	for lang, m := range tables {
+		v := reflect.ValueOf(*m)
+		for i := 0; i < v.NumField(); i++ {
+			suite.Equal(verbs(def.Field(i).String()), verbs(v.Field(i).String()),
+				"language %s: message %s has different formatting verbs", lang, v.Type().Field(i).Name)
+		}
+	}
+}
+
+// verbs returns the formatting verbs of the message in order
+func verbs(s string) []string {
+	var res []string
+	for i := 0; i < len(s)-1; i++ {
+		if s[i] == '%' {
+			res = append(res, s[i:i+2])
+			i++
+		}
+	}
+	return res
+}
+
+// Run the test suite
+func TestLocales(t *testing.T) {
+	suite.Run(t, new(TestLocalesSuite))
+}
@@ -0,0 +1,86 @@
+package locales
+
+// ru is the table of Russian messages.
+var ru = Messages{
+	Start: `🎧 **Добро пожаловать в Voxify Bot!**
+
+Я превращаю видео в аудио RSS-ленты. Просто пришлите мне ссылку на YouTube, и я:
+
+🔽 Скачаю аудио
+🎵 Создам аудиофайл в высоком качестве
+📡 Добавлю его в вашу личную RSS-ленту
+🔔 Пришлю уведомление, когда всё будет готово
+
+Чтобы начать, просто отправьте ссылку на видео или подкаст!
+
+Отлично подходит для собственной коллекции подкастов или прослушивания без интернета.`,
+
+	DownloadStarted:  "🔄 Начинаю скачивать подкаст...",
+	DownloadQueued:   "🕒 Добавлено в очередь загрузки, позиция %d.",
+	DownloadPlaylist: "📋 Это плейлист: его выпуски (%d) будут скачаны отдельно.",
+	DownloadBusy:     "⏳ Идёт другая загрузка. Пожалуйста, попробуйте позже...",
+	DownloadSuccess:  "✅ Подкаст успешно скачан!\n\n🎧 %s",
+	DownloadLinks:    "\n\n🔗 <a href=\"%s\">Медиафайл</a> · <a href=\"%s\">RSS-лента</a>",
+
+	StepTimings:     "\n\n⏱️ %s",
+	StepCreating:    "создание",
+	StepDownloading: "загрузка",
+	StepPublishing:  "публикация",
+
+	// General error messages
+
+	SomethingWentWrong:         "⚠️ Что-то пошло не так при скачивании подкаста.",
+	SomethingWentWrongWithCode: "⚠️ Что-то пошло не так при скачивании подкаста (ошибка %d).",
+
+	// Error messages for codes 100-199
+
+	NoMatchingPlatform: "⚠️ Эта ссылка не поддерживается. Пришлите ссылку на видео.",
+	DownloadFailed:     "⚠️ Не удалось скачать. Возможно, медиафайл недоступен или защищён.",
+	EpisodeInProgress:  "⚠️ Этот выпуск уже обрабатывается. Пожалуйста, подождите.",
+	EpisodeExists:      "⚠️ Этот выпуск уже скачан.",
+	ProcessInterrupted: "⚠️ Загрузка была прервана. Пожалуйста, попробуйте ещё раз.",
+	EmptyFeed:          "⚠️ В ленте нет выпусков.",
+	InvalidRequest:     "⚠️ Некорректный запрос.",
+	EpisodeNotFound:    "⚠️ Выпуск не найден.",
+	FeedInvalid:        "⚠️ Лента некорректна. Подробности — по команде /validate.",
+	DownloadTimeout:    "⚠️ Время загрузки истекло. Возможно, файл слишком большой, попробуйте позже.",
+	DiskFull:           "⚠️ На сервере закончилось место. Освободите место и попробуйте ещё раз.",
+	TooShort:           "⚠️ Этот медиафайл слишком короткий для добавления в подкаст.",
+
+	PlaylistNotSupported: "⚠️ Плейлисты не поддерживаются. Пришлите ссылку на одно видео.",
+
+	UnsupportedFormatForPlatform: "⚠️ Этот формат недоступен для этой ссылки. Попробуйте другой формат.",
+
+	BuildSuccess: "✅ RSS-лента успешно собрана!",
+	BuildReport:  "\n\n🎧 Выпусков: %d\n📄 Размер ленты: %s\n⏱️ Собрана за %s",
+
+	ValidateSuccess: "✅ RSS-лента корректна!",
+	ValidateFailed:  "⚠️ RSS-лента некорректна: %s",
+
+	FeedInfoBasic:      "📻 Информация о подкасте\n\n<b>%s</b>\n\n%s\n\n",
+	FeedInfoAuthor:     "👨‍💻 Автор: %s\n",
+	FeedInfoOwner:      "👤 Владелец: %s\n",
+	FeedInfoCopyright:  "©️ %s\n",
+	FeedInfoLanguage:   "🌐 Язык: %s\n",
+	FeedInfoCategories: "📚 Категории: %s\n",
+	FeedInfoKeywords:   "🔑 Ключевые слова: %s\n",
+	FeedInfoArtwork:    "🖼️ <a href=\"%s\">Обложка</a>\n",
+	FeedInfoWebsite:    "🔗 <a href=\"%s\">Сайт</a>\n",
+	FeedInfoEpisodes:   "🎧 Количество выпусков: %d\n",
+	FeedInfoNoEpisodes: "📭 Выпусков пока нет\n",
+	FeedInfoExplicit:   "🔞 Контент для взрослых\n",
+	FeedInfoCompleted:  "🏁 Подкаст завершён, новых выпусков не будет\n",
+	FeedInfoBlocked:    "🚫 Скрыт в Apple Podcasts\n",
+	FeedInfoRSS:        "\n📡 RSS: %s",
+
+	ListHeader: "🎧 <b>Последние выпуски</b>\n\n",
+	ListItem:   "%d. <a href=\"%s\">%s</a> (%s)\n",
+	ListEmpty:  "📭 Выпусков пока нет. Пришлите ссылку на видео, чтобы добавить первый!",
+	ListNext:   "Далее ▶️",
+
+	StatusHeader: "⏳ <b>Активные загрузки</b>\n\n",
+	StatusItem:   "%d. %s — %s, %s\n",
+	StatusEmpty:  "💤 Активных загрузок нет.",
+
+	AddUsage: "⚠️ Использование: /add <ссылка> | название | номер выпуска\n\nНазвание и номер выпуска необязательны.",
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"github.com/ofstudio/voxify/internal/entities"
+	"github.com/ofstudio/voxify/internal/store"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -39,8 +40,8 @@ func (_m *MockDownloader) EXPECT() *MockDownloader_Expecter {
 }
 
 // Download provides a mock function for the type MockDownloader
-func (_mock *MockDownloader) Download(ctx context.Context, req entities.Request) (*entities.Episode, error) {
-	ret := _mock.Called(ctx, req)
+func (_mock *MockDownloader) Download(ctx context.Context, req entities.Request, save func(ctx context.Context, tx store.Store, episode *entities.Episode) error) (*entities.Episode, error) {
+	ret := _mock.Called(ctx, req, save)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Download")
@@ -48,18 +49,18 @@ func (_mock *MockDownloader) Download(ctx context.Context, req entities.Request)
 
 	var r0 *entities.Episode
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.Request) (*entities.Episode, error)); ok {
-		return returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.Request, func(context.Context, store.Store, *entities.Episode) error) (*entities.Episode, error)); ok {
+		return returnFunc(ctx, req, save)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.Request) *entities.Episode); ok {
-		r0 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.Request, func(context.Context, store.Store, *entities.Episode) error) *entities.Episode); ok {
+		r0 = returnFunc(ctx, req, save)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Episode)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.Request) error); ok {
-		r1 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.Request, func(context.Context, store.Store, *entities.Episode) error) error); ok {
+		r1 = returnFunc(ctx, req, save)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -74,11 +75,12 @@ type MockDownloader_Download_Call struct {
 // Download is a helper method to define mock.On call
 //   - ctx context.Context
 //   - req entities.Request
-func (_e *MockDownloader_Expecter) Download(ctx interface{}, req interface{}) *MockDownloader_Download_Call {
-	return &MockDownloader_Download_Call{Call: _e.mock.On("Download", ctx, req)}
+//   - save func(ctx context.Context, tx store.Store, episode *entities.Episode) error
+func (_e *MockDownloader_Expecter) Download(ctx interface{}, req interface{}, save interface{}) *MockDownloader_Download_Call {
+	return &MockDownloader_Download_Call{Call: _e.mock.On("Download", ctx, req, save)}
 }
 
-func (_c *MockDownloader_Download_Call) Run(run func(ctx context.Context, req entities.Request)) *MockDownloader_Download_Call {
+func (_c *MockDownloader_Download_Call) Run(run func(ctx context.Context, req entities.Request, save func(ctx context.Context, tx store.Store, episode *entities.Episode) error)) *MockDownloader_Download_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -88,9 +90,14 @@ func (_c *MockDownloader_Download_Call) Run(run func(ctx context.Context, req en
 		if args[1] != nil {
 			arg1 = args[1].(entities.Request)
 		}
+		var arg2 func(ctx context.Context, tx store.Store, episode *entities.Episode) error
+		if args[2] != nil {
+			arg2 = args[2].(func(ctx context.Context, tx store.Store, episode *entities.Episode) error)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -101,7 +108,7 @@ func (_c *MockDownloader_Download_Call) Return(episode *entities.Episode, err er
 	return _c
 }
 
-func (_c *MockDownloader_Download_Call) RunAndReturn(run func(ctx context.Context, req entities.Request) (*entities.Episode, error)) *MockDownloader_Download_Call {
+func (_c *MockDownloader_Download_Call) RunAndReturn(run func(ctx context.Context, req entities.Request, save func(ctx context.Context, tx store.Store, episode *entities.Episode) error) (*entities.Episode, error)) *MockDownloader_Download_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/ofstudio/voxify/internal/entities"
 	mock "github.com/stretchr/testify/mock"
@@ -89,6 +90,68 @@ func (_c *MockFeeder_Build_Call) RunAndReturn(run func(ctx context.Context) erro
 	return _c
 }
 
+// BuildReport provides a mock function for the type MockFeeder
+func (_mock *MockFeeder) BuildReport(ctx context.Context) (*entities.FeedBuildReport, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Feed")
+	}
+
+	var r0 *entities.FeedBuildReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*entities.FeedBuildReport, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *entities.FeedBuildReport); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.FeedBuildReport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFeeder_BuildReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BuildReport'
+type MockFeeder_BuildReport_Call struct {
+	*mock.Call
+}
+
+// BuildReport is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockFeeder_Expecter) BuildReport(ctx interface{}) *MockFeeder_BuildReport_Call {
+	return &MockFeeder_BuildReport_Call{Call: _e.mock.On("BuildReport", ctx)}
+}
+
+func (_c *MockFeeder_BuildReport_Call) Run(run func(ctx context.Context)) *MockFeeder_BuildReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFeeder_BuildReport_Call) Return(feedBuildReport *entities.FeedBuildReport, err error) *MockFeeder_BuildReport_Call {
+	_c.Call.Return(feedBuildReport, err)
+	return _c
+}
+
+func (_c *MockFeeder_BuildReport_Call) RunAndReturn(run func(ctx context.Context) (*entities.FeedBuildReport, error)) *MockFeeder_BuildReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Feed provides a mock function for the type MockFeeder
 func (_mock *MockFeeder) Feed(ctx context.Context) (*entities.Feed, error) {
 	ret := _mock.Called(ctx)
@@ -150,3 +213,202 @@ func (_c *MockFeeder_Feed_Call) RunAndReturn(run func(ctx context.Context) (*ent
 	_c.Call.Return(run)
 	return _c
 }
+
+// FeedBytes provides a mock function for the type MockFeeder
+func (_mock *MockFeeder) FeedBytes(ctx context.Context) ([]byte, string, time.Time, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FeedBytes")
+	}
+
+	var r0 []byte
+	var r1 string
+	var r2 time.Time
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]byte, string, time.Time, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []byte); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) string); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context) time.Time); ok {
+		r2 = returnFunc(ctx)
+	} else {
+		r2 = ret.Get(2).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context) error); ok {
+		r3 = returnFunc(ctx)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
+}
+
+// MockFeeder_FeedBytes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FeedBytes'
+type MockFeeder_FeedBytes_Call struct {
+	*mock.Call
+}
+
+// FeedBytes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockFeeder_Expecter) FeedBytes(ctx interface{}) *MockFeeder_FeedBytes_Call {
+	return &MockFeeder_FeedBytes_Call{Call: _e.mock.On("FeedBytes", ctx)}
+}
+
+func (_c *MockFeeder_FeedBytes_Call) Run(run func(ctx context.Context)) *MockFeeder_FeedBytes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFeeder_FeedBytes_Call) Return(data []byte, etag string, lastMod time.Time, err error) *MockFeeder_FeedBytes_Call {
+	_c.Call.Return(data, etag, lastMod, err)
+	return _c
+}
+
+func (_c *MockFeeder_FeedBytes_Call) RunAndReturn(run func(ctx context.Context) ([]byte, string, time.Time, error)) *MockFeeder_FeedBytes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecentEpisodes provides a mock function for the type MockFeeder
+func (_mock *MockFeeder) RecentEpisodes(ctx context.Context, limit int, offset int) ([]*entities.Episode, error) {
+	ret := _mock.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecentEpisodes")
+	}
+
+	var r0 []*entities.Episode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) ([]*entities.Episode, error)); ok {
+		return returnFunc(ctx, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []*entities.Episode); ok {
+		r0 = returnFunc(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Episode)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = returnFunc(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFeeder_RecentEpisodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecentEpisodes'
+type MockFeeder_RecentEpisodes_Call struct {
+	*mock.Call
+}
+
+// RecentEpisodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - offset int
+func (_e *MockFeeder_Expecter) RecentEpisodes(ctx interface{}, limit interface{}, offset interface{}) *MockFeeder_RecentEpisodes_Call {
+	return &MockFeeder_RecentEpisodes_Call{Call: _e.mock.On("RecentEpisodes", ctx, limit, offset)}
+}
+
+func (_c *MockFeeder_RecentEpisodes_Call) Run(run func(ctx context.Context, limit int, offset int)) *MockFeeder_RecentEpisodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFeeder_RecentEpisodes_Call) Return(episodes []*entities.Episode, err error) *MockFeeder_RecentEpisodes_Call {
+	_c.Call.Return(episodes, err)
+	return _c
+}
+
+func (_c *MockFeeder_RecentEpisodes_Call) RunAndReturn(run func(ctx context.Context, limit int, offset int) ([]*entities.Episode, error)) *MockFeeder_RecentEpisodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Validate provides a mock function for the type MockFeeder
+func (_mock *MockFeeder) Validate(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFeeder_Validate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Validate'
+type MockFeeder_Validate_Call struct {
+	*mock.Call
+}
+
+// Validate is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockFeeder_Expecter) Validate(ctx interface{}) *MockFeeder_Validate_Call {
+	return &MockFeeder_Validate_Call{Call: _e.mock.On("Validate", ctx)}
+}
+
+func (_c *MockFeeder_Validate_Call) Run(run func(ctx context.Context)) *MockFeeder_Validate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFeeder_Validate_Call) Return(err error) *MockFeeder_Validate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFeeder_Validate_Call) RunAndReturn(run func(ctx context.Context) error) *MockFeeder_Validate_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -251,3 +251,54 @@ func (_c *MockPlatform_Match_Call) RunAndReturn(run func(url string) bool) *Mock
 	_c.Call.Return(run)
 	return _c
 }
+
+// SupportsFormat provides a mock function for the type MockPlatform
+func (_mock *MockPlatform) SupportsFormat(f entities.DownloadFormat) bool {
+	ret := _mock.Called(f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SupportsFormat")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(entities.DownloadFormat) bool); ok {
+		r0 = returnFunc(f)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockPlatform_SupportsFormat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SupportsFormat'
+type MockPlatform_SupportsFormat_Call struct {
+	*mock.Call
+}
+
+// SupportsFormat is a helper method to define mock.On call
+//   - f entities.DownloadFormat
+func (_e *MockPlatform_Expecter) SupportsFormat(f interface{}) *MockPlatform_SupportsFormat_Call {
+	return &MockPlatform_SupportsFormat_Call{Call: _e.mock.On("SupportsFormat", f)}
+}
+
+func (_c *MockPlatform_SupportsFormat_Call) Run(run func(f entities.DownloadFormat)) *MockPlatform_SupportsFormat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 entities.DownloadFormat
+		if args[0] != nil {
+			arg0 = args[0].(entities.DownloadFormat)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPlatform_SupportsFormat_Call) Return(b bool) *MockPlatform_SupportsFormat_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockPlatform_SupportsFormat_Call) RunAndReturn(run func(f entities.DownloadFormat) bool) *MockPlatform_SupportsFormat_Call {
+	_c.Call.Return(run)
+	return _c
+}
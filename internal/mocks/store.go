@@ -296,6 +296,322 @@ func (_c *MockStore_EpisodeCreate_Call) RunAndReturn(run func(ctx context.Contex
 	return _c
 }
 
+// EpisodeCreateBatch provides a mock function for the type MockStore
+func (_mock *MockStore) EpisodeCreateBatch(ctx context.Context, episodes []*entities.Episode) error {
+	ret := _mock.Called(ctx, episodes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EpisodeCreateBatch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*entities.Episode) error); ok {
+		r0 = returnFunc(ctx, episodes)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_EpisodeCreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EpisodeCreateBatch'
+type MockStore_EpisodeCreateBatch_Call struct {
+	*mock.Call
+}
+
+// EpisodeCreateBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - episodes []*entities.Episode
+func (_e *MockStore_Expecter) EpisodeCreateBatch(ctx interface{}, episodes interface{}) *MockStore_EpisodeCreateBatch_Call {
+	return &MockStore_EpisodeCreateBatch_Call{Call: _e.mock.On("EpisodeCreateBatch", ctx, episodes)}
+}
+
+func (_c *MockStore_EpisodeCreateBatch_Call) Run(run func(ctx context.Context, episodes []*entities.Episode)) *MockStore_EpisodeCreateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*entities.Episode
+		if args[1] != nil {
+			arg1 = args[1].([]*entities.Episode)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_EpisodeCreateBatch_Call) Return(err error) *MockStore_EpisodeCreateBatch_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_EpisodeCreateBatch_Call) RunAndReturn(run func(ctx context.Context, episodes []*entities.Episode) error) *MockStore_EpisodeCreateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EpisodeDelete provides a mock function for the type MockStore
+func (_mock *MockStore) EpisodeDelete(ctx context.Context, id int64) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EpisodeDelete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_EpisodeDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EpisodeDelete'
+type MockStore_EpisodeDelete_Call struct {
+	*mock.Call
+}
+
+// EpisodeDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockStore_Expecter) EpisodeDelete(ctx interface{}, id interface{}) *MockStore_EpisodeDelete_Call {
+	return &MockStore_EpisodeDelete_Call{Call: _e.mock.On("EpisodeDelete", ctx, id)}
+}
+
+func (_c *MockStore_EpisodeDelete_Call) Run(run func(ctx context.Context, id int64)) *MockStore_EpisodeDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_EpisodeDelete_Call) Return(err error) *MockStore_EpisodeDelete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_EpisodeDelete_Call) RunAndReturn(run func(ctx context.Context, id int64) error) *MockStore_EpisodeDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EpisodeExistsByOriginalUrl provides a mock function for the type MockStore
+func (_mock *MockStore) EpisodeExistsByOriginalUrl(ctx context.Context, url string) (bool, error) {
+	ret := _mock.Called(ctx, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EpisodeExistsByOriginalUrl")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, url)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, url)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_EpisodeExistsByOriginalUrl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EpisodeExistsByOriginalUrl'
+type MockStore_EpisodeExistsByOriginalUrl_Call struct {
+	*mock.Call
+}
+
+// EpisodeExistsByOriginalUrl is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+func (_e *MockStore_Expecter) EpisodeExistsByOriginalUrl(ctx interface{}, url interface{}) *MockStore_EpisodeExistsByOriginalUrl_Call {
+	return &MockStore_EpisodeExistsByOriginalUrl_Call{Call: _e.mock.On("EpisodeExistsByOriginalUrl", ctx, url)}
+}
+
+func (_c *MockStore_EpisodeExistsByOriginalUrl_Call) Run(run func(ctx context.Context, url string)) *MockStore_EpisodeExistsByOriginalUrl_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_EpisodeExistsByOriginalUrl_Call) Return(exists bool, err error) *MockStore_EpisodeExistsByOriginalUrl_Call {
+	_c.Call.Return(exists, err)
+	return _c
+}
+
+func (_c *MockStore_EpisodeExistsByOriginalUrl_Call) RunAndReturn(run func(ctx context.Context, url string) (bool, error)) *MockStore_EpisodeExistsByOriginalUrl_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EpisodeGetByHash provides a mock function for the type MockStore
+func (_mock *MockStore) EpisodeGetByHash(ctx context.Context, hash string) ([]*entities.Episode, error) {
+	ret := _mock.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EpisodeGetByHash")
+	}
+
+	var r0 []*entities.Episode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*entities.Episode, error)); ok {
+		return returnFunc(ctx, hash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*entities.Episode); ok {
+		r0 = returnFunc(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Episode)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_EpisodeGetByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EpisodeGetByHash'
+type MockStore_EpisodeGetByHash_Call struct {
+	*mock.Call
+}
+
+// EpisodeGetByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash string
+func (_e *MockStore_Expecter) EpisodeGetByHash(ctx interface{}, hash interface{}) *MockStore_EpisodeGetByHash_Call {
+	return &MockStore_EpisodeGetByHash_Call{Call: _e.mock.On("EpisodeGetByHash", ctx, hash)}
+}
+
+func (_c *MockStore_EpisodeGetByHash_Call) Run(run func(ctx context.Context, hash string)) *MockStore_EpisodeGetByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_EpisodeGetByHash_Call) Return(episodes []*entities.Episode, err error) *MockStore_EpisodeGetByHash_Call {
+	_c.Call.Return(episodes, err)
+	return _c
+}
+
+func (_c *MockStore_EpisodeGetByHash_Call) RunAndReturn(run func(ctx context.Context, hash string) ([]*entities.Episode, error)) *MockStore_EpisodeGetByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EpisodeGetByID provides a mock function for the type MockStore
+func (_mock *MockStore) EpisodeGetByID(ctx context.Context, id int64) (*entities.Episode, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EpisodeGetByID")
+	}
+
+	var r0 *entities.Episode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (*entities.Episode, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) *entities.Episode); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Episode)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_EpisodeGetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EpisodeGetByID'
+type MockStore_EpisodeGetByID_Call struct {
+	*mock.Call
+}
+
+// EpisodeGetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockStore_Expecter) EpisodeGetByID(ctx interface{}, id interface{}) *MockStore_EpisodeGetByID_Call {
+	return &MockStore_EpisodeGetByID_Call{Call: _e.mock.On("EpisodeGetByID", ctx, id)}
+}
+
+func (_c *MockStore_EpisodeGetByID_Call) Run(run func(ctx context.Context, id int64)) *MockStore_EpisodeGetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_EpisodeGetByID_Call) Return(episode *entities.Episode, err error) *MockStore_EpisodeGetByID_Call {
+	_c.Call.Return(episode, err)
+	return _c
+}
+
+func (_c *MockStore_EpisodeGetByID_Call) RunAndReturn(run func(ctx context.Context, id int64) (*entities.Episode, error)) *MockStore_EpisodeGetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EpisodeGetByOriginalUrl provides a mock function for the type MockStore
 func (_mock *MockStore) EpisodeGetByOriginalUrl(ctx context.Context, url string) ([]*entities.Episode, error) {
 	ret := _mock.Called(ctx, url)
@@ -364,6 +680,66 @@ func (_c *MockStore_EpisodeGetByOriginalUrl_Call) RunAndReturn(run func(ctx cont
 	return _c
 }
 
+// EpisodeGetLastSeason provides a mock function for the type MockStore
+func (_mock *MockStore) EpisodeGetLastSeason(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EpisodeGetLastSeason")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_EpisodeGetLastSeason_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EpisodeGetLastSeason'
+type MockStore_EpisodeGetLastSeason_Call struct {
+	*mock.Call
+}
+
+// EpisodeGetLastSeason is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) EpisodeGetLastSeason(ctx interface{}) *MockStore_EpisodeGetLastSeason_Call {
+	return &MockStore_EpisodeGetLastSeason_Call{Call: _e.mock.On("EpisodeGetLastSeason", ctx)}
+}
+
+func (_c *MockStore_EpisodeGetLastSeason_Call) Run(run func(ctx context.Context)) *MockStore_EpisodeGetLastSeason_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_EpisodeGetLastSeason_Call) Return(n int, err error) *MockStore_EpisodeGetLastSeason_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockStore_EpisodeGetLastSeason_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockStore_EpisodeGetLastSeason_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EpisodeGetLastTime provides a mock function for the type MockStore
 func (_mock *MockStore) EpisodeGetLastTime(ctx context.Context) (time.Time, error) {
 	ret := _mock.Called(ctx)
@@ -424,6 +800,80 @@ func (_c *MockStore_EpisodeGetLastTime_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// EpisodeList provides a mock function for the type MockStore
+func (_mock *MockStore) EpisodeList(ctx context.Context, limit int, offset int) ([]*entities.Episode, error) {
+	ret := _mock.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EpisodeList")
+	}
+
+	var r0 []*entities.Episode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) ([]*entities.Episode, error)); ok {
+		return returnFunc(ctx, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []*entities.Episode); ok {
+		r0 = returnFunc(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Episode)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = returnFunc(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_EpisodeList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EpisodeList'
+type MockStore_EpisodeList_Call struct {
+	*mock.Call
+}
+
+// EpisodeList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - offset int
+func (_e *MockStore_Expecter) EpisodeList(ctx interface{}, limit interface{}, offset interface{}) *MockStore_EpisodeList_Call {
+	return &MockStore_EpisodeList_Call{Call: _e.mock.On("EpisodeList", ctx, limit, offset)}
+}
+
+func (_c *MockStore_EpisodeList_Call) Run(run func(ctx context.Context, limit int, offset int)) *MockStore_EpisodeList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_EpisodeList_Call) Return(episodes []*entities.Episode, err error) *MockStore_EpisodeList_Call {
+	_c.Call.Return(episodes, err)
+	return _c
+}
+
+func (_c *MockStore_EpisodeList_Call) RunAndReturn(run func(ctx context.Context, limit int, offset int) ([]*entities.Episode, error)) *MockStore_EpisodeList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EpisodeListAll provides a mock function for the type MockStore
 func (_mock *MockStore) EpisodeListAll(ctx context.Context) ([]*entities.Episode, error) {
 	ret := _mock.Called(ctx)
@@ -486,9 +936,140 @@ func (_c *MockStore_EpisodeListAll_Call) RunAndReturn(run func(ctx context.Conte
 	return _c
 }
 
+// EpisodeListByDateRange provides a mock function for the type MockStore
+func (_mock *MockStore) EpisodeListByDateRange(ctx context.Context, from time.Time, to time.Time) ([]*entities.Episode, error) {
+	ret := _mock.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EpisodeListByDateRange")
+	}
+
+	var r0 []*entities.Episode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]*entities.Episode, error)); ok {
+		return returnFunc(ctx, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []*entities.Episode); ok {
+		r0 = returnFunc(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Episode)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_EpisodeListByDateRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EpisodeListByDateRange'
+type MockStore_EpisodeListByDateRange_Call struct {
+	*mock.Call
+}
+
+// EpisodeListByDateRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from time.Time
+//   - to time.Time
+func (_e *MockStore_Expecter) EpisodeListByDateRange(ctx interface{}, from interface{}, to interface{}) *MockStore_EpisodeListByDateRange_Call {
+	return &MockStore_EpisodeListByDateRange_Call{Call: _e.mock.On("EpisodeListByDateRange", ctx, from, to)}
+}
+
+func (_c *MockStore_EpisodeListByDateRange_Call) Run(run func(ctx context.Context, from time.Time, to time.Time)) *MockStore_EpisodeListByDateRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_EpisodeListByDateRange_Call) Return(episodes []*entities.Episode, err error) *MockStore_EpisodeListByDateRange_Call {
+	_c.Call.Return(episodes, err)
+	return _c
+}
+
+func (_c *MockStore_EpisodeListByDateRange_Call) RunAndReturn(run func(ctx context.Context, from time.Time, to time.Time) ([]*entities.Episode, error)) *MockStore_EpisodeListByDateRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EpisodeUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) EpisodeUpdate(ctx context.Context, episode *entities.Episode) error {
+	ret := _mock.Called(ctx, episode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EpisodeUpdate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.Episode) error); ok {
+		r0 = returnFunc(ctx, episode)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_EpisodeUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EpisodeUpdate'
+type MockStore_EpisodeUpdate_Call struct {
+	*mock.Call
+}
+
+// EpisodeUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - episode *entities.Episode
+func (_e *MockStore_Expecter) EpisodeUpdate(ctx interface{}, episode interface{}) *MockStore_EpisodeUpdate_Call {
+	return &MockStore_EpisodeUpdate_Call{Call: _e.mock.On("EpisodeUpdate", ctx, episode)}
+}
+
+func (_c *MockStore_EpisodeUpdate_Call) Run(run func(ctx context.Context, episode *entities.Episode)) *MockStore_EpisodeUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.Episode
+		if args[1] != nil {
+			arg1 = args[1].(*entities.Episode)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_EpisodeUpdate_Call) Return(err error) *MockStore_EpisodeUpdate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_EpisodeUpdate_Call) RunAndReturn(run func(ctx context.Context, episode *entities.Episode) error) *MockStore_EpisodeUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ProcessCountByUrlAndStatus provides a mock function for the type MockStore
-func (_mock *MockStore) ProcessCountByUrlAndStatus(ctx context.Context, url string, status entities.Status) (int, error) {
-	ret := _mock.Called(ctx, url, status)
+func (_mock *MockStore) ProcessCountByUrlAndStatus(ctx context.Context, url string, status entities.Status, beforeID int64) (int, error) {
+	ret := _mock.Called(ctx, url, status, beforeID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ProcessCountByUrlAndStatus")
@@ -496,16 +1077,16 @@ func (_mock *MockStore) ProcessCountByUrlAndStatus(ctx context.Context, url stri
 
 	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.Status) (int, error)); ok {
-		return returnFunc(ctx, url, status)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.Status, int64) (int, error)); ok {
+		return returnFunc(ctx, url, status, beforeID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.Status) int); ok {
-		r0 = returnFunc(ctx, url, status)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.Status, int64) int); ok {
+		r0 = returnFunc(ctx, url, status, beforeID)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, entities.Status) error); ok {
-		r1 = returnFunc(ctx, url, status)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, entities.Status, int64) error); ok {
+		r1 = returnFunc(ctx, url, status, beforeID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -521,11 +1102,12 @@ type MockStore_ProcessCountByUrlAndStatus_Call struct {
 //   - ctx context.Context
 //   - url string
 //   - status entities.Status
-func (_e *MockStore_Expecter) ProcessCountByUrlAndStatus(ctx interface{}, url interface{}, status interface{}) *MockStore_ProcessCountByUrlAndStatus_Call {
-	return &MockStore_ProcessCountByUrlAndStatus_Call{Call: _e.mock.On("ProcessCountByUrlAndStatus", ctx, url, status)}
+//   - beforeID int64
+func (_e *MockStore_Expecter) ProcessCountByUrlAndStatus(ctx interface{}, url interface{}, status interface{}, beforeID interface{}) *MockStore_ProcessCountByUrlAndStatus_Call {
+	return &MockStore_ProcessCountByUrlAndStatus_Call{Call: _e.mock.On("ProcessCountByUrlAndStatus", ctx, url, status, beforeID)}
 }
 
-func (_c *MockStore_ProcessCountByUrlAndStatus_Call) Run(run func(ctx context.Context, url string, status entities.Status)) *MockStore_ProcessCountByUrlAndStatus_Call {
+func (_c *MockStore_ProcessCountByUrlAndStatus_Call) Run(run func(ctx context.Context, url string, status entities.Status, beforeID int64)) *MockStore_ProcessCountByUrlAndStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -539,10 +1121,15 @@ func (_c *MockStore_ProcessCountByUrlAndStatus_Call) Run(run func(ctx context.Co
 		if args[2] != nil {
 			arg2 = args[2].(entities.Status)
 		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -553,7 +1140,81 @@ func (_c *MockStore_ProcessCountByUrlAndStatus_Call) Return(n int, err error) *M
 	return _c
 }
 
-func (_c *MockStore_ProcessCountByUrlAndStatus_Call) RunAndReturn(run func(ctx context.Context, url string, status entities.Status) (int, error)) *MockStore_ProcessCountByUrlAndStatus_Call {
+func (_c *MockStore_ProcessCountByUrlAndStatus_Call) RunAndReturn(run func(ctx context.Context, url string, status entities.Status, beforeID int64) (int, error)) *MockStore_ProcessCountByUrlAndStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessGetByMessage provides a mock function for the type MockStore
+func (_mock *MockStore) ProcessGetByMessage(ctx context.Context, chatID int64, messageID int) (*entities.Process, error) {
+	ret := _mock.Called(ctx, chatID, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessGetByMessage")
+	}
+
+	var r0 *entities.Process
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) (*entities.Process, error)); ok {
+		return returnFunc(ctx, chatID, messageID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) *entities.Process); ok {
+		r0 = returnFunc(ctx, chatID, messageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Process)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = returnFunc(ctx, chatID, messageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_ProcessGetByMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessGetByMessage'
+type MockStore_ProcessGetByMessage_Call struct {
+	*mock.Call
+}
+
+// ProcessGetByMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - messageID int
+func (_e *MockStore_Expecter) ProcessGetByMessage(ctx interface{}, chatID interface{}, messageID interface{}) *MockStore_ProcessGetByMessage_Call {
+	return &MockStore_ProcessGetByMessage_Call{Call: _e.mock.On("ProcessGetByMessage", ctx, chatID, messageID)}
+}
+
+func (_c *MockStore_ProcessGetByMessage_Call) Run(run func(ctx context.Context, chatID int64, messageID int)) *MockStore_ProcessGetByMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_ProcessGetByMessage_Call) Return(process *entities.Process, err error) *MockStore_ProcessGetByMessage_Call {
+	_c.Call.Return(process, err)
+	return _c
+}
+
+func (_c *MockStore_ProcessGetByMessage_Call) RunAndReturn(run func(ctx context.Context, chatID int64, messageID int) (*entities.Process, error)) *MockStore_ProcessGetByMessage_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,63 @@
+package platforms
+
+import (
+	"fmt"
+	"regexp"
+	"syscall"
+
+	"github.com/ofstudio/voxify/internal/services"
+)
+
+// ErrNoSpace is returned when the download failed because the disk is full.
+// It wraps syscall.ENOSPC, so callers detect it the same way as file system errors.
+var ErrNoSpace = fmt.Errorf("%w: %w", services.ErrPermanent, syscall.ENOSPC)
+
+// reNoSpace matches yt-dlp and ffmpeg messages of the full disk.
+var reNoSpace = regexp.MustCompile(`(?i)no space left on device`)
+
+// rePermanent matches yt-dlp messages of failures that will not go away on retry.
+// It is checked first: a removed video may also be reported with a network error.
+// Messages of the video itself are matched, not any "not available" one, e.g. of a format.
+var rePermanent = regexp.MustCompile(`(?i)` +
+	`private video|` +
+	`video unavailable|` +
+	`video is not available|` +
+	`has been removed|` +
+	`unsupported url|` +
+	`sign in to confirm your age|` +
+	`members-only|` +
+	`http error 4(00|01|03|04|10)`)
+
+// reRetryable matches yt-dlp messages of transient network and server failures:
+// rate limiting, server errors, timeouts and dropped connections.
+// Other "unable to download" failures, e.g. HTTP 403, are not retried.
+var reRetryable = regexp.MustCompile(`(?i)` +
+	`http error (5\d\d|429)|` +
+	`temporary failure|` +
+	`connection (reset|refused|aborted)|` +
+	`timed out|` +
+	`remote end closed connection`)
+
+// classifyError wraps the failed yt-dlp command error with services.ErrRetryable or services.ErrPermanent
+// depending on the command output. Unknown failures are considered permanent.
+func classifyError(err error, output string) error {
+	if err == nil {
+		return nil
+	}
+	if reNoSpace.MatchString(output) {
+		return fmt.Errorf("%w: %w", ErrNoSpace, err)
+	}
+	if !rePermanent.MatchString(output) && reRetryable.MatchString(output) {
+		return fmt.Errorf("%w: %w", services.ErrRetryable, err)
+	}
+	return fmt.Errorf("%w: %w", services.ErrPermanent, err)
+}
+
+// noSpaceError wraps the failed command error with ErrNoSpace if the command output reports the full disk.
+// Otherwise, the error is returned as is.
+func noSpaceError(err error, output string) error {
+	if err != nil && reNoSpace.MatchString(output) {
+		return fmt.Errorf("%w: %w", ErrNoSpace, err)
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+package platforms
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ofstudio/voxify/internal/entities"
+)
+
+// defaultFilenameTemplate names the media and thumbnail files after the request ID.
+const defaultFilenameTemplate = "{id}"
+
+// filenamePlaceholders are the placeholders supported in the media file name template:
+// {id} is the request ID, {videoid} is the media ID reported by the platform
+// and {date} is the upload date (YYYY-MM-DD), the current date if not reported.
+var filenamePlaceholders = []string{"{id}", "{videoid}", "{date}"}
+
+// maxFilenameLength is the maximum length of the file name without extension.
+const maxFilenameLength = 100
+
+var placeholderRe = regexp.MustCompile(`\{[^{}]*}`)
+
+// validateFilenameTemplate checks that the template uses the supported placeholders only.
+// The empty template is valid: defaultFilenameTemplate is used instead.
+func validateFilenameTemplate(tmpl string) error {
+	if tmpl == "" {
+		return nil
+	}
+	for _, ph := range placeholderRe.FindAllString(tmpl, -1) {
+		if !slices.Contains(filenamePlaceholders, ph) {
+			return fmt.Errorf("unknown placeholder %s, supported: %s", ph, strings.Join(filenamePlaceholders, ", "))
+		}
+	}
+	if sanitizeFilename(placeholderRe.ReplaceAllString(tmpl, "x")) == "" {
+		return fmt.Errorf("template %q produces an empty file name", tmpl)
+	}
+	return nil
+}
+
+// reservedNames are the file names taken by the downloads in progress.
+// A name is reserved until its files are published, so concurrent downloads
+// of the platforms sharing the public directory never pick the same name.
+var reservedNames = struct {
+	sync.Mutex
+	names map[string]bool
+}{names: make(map[string]bool)}
+
+// fileName returns the name of the media and thumbnail files without extension
+// expanded from MediaFilenameTemplate, and reserves it until release is called.
+// The request ID is appended to the name if a file of another episode is already
+// published under the name or the name is reserved by another download,
+// and the request ID is used alone if the name is empty.
+func (p YtDlp) fileName(req entities.Request, meta *youtubeMeta) (name string, release func()) {
+	name = sanitizeFilename(expandFilename(cmp.Or(p.cfg.MediaFilenameTemplate, defaultFilenameTemplate), req, meta))
+	if name == "" {
+		name = req.ID
+	}
+
+	reservedNames.Lock()
+	defer reservedNames.Unlock()
+	if name != req.ID && (reservedNames.names[name] || p.published(name, "."+string(req.DownloadFormat), ".jpg")) {
+		name += "-" + req.ID
+	}
+	reservedNames.names[name] = true
+	return name, func() {
+		reservedNames.Lock()
+		defer reservedNames.Unlock()
+		delete(reservedNames.names, name)
+	}
+}
+
+// published reports whether a file of the name with any of the extensions exists in the public directory.
+func (p YtDlp) published(name string, exts ...string) bool {
+	for _, ext := range exts {
+		if _, err := os.Stat(filepath.Join(p.cfg.PublicDir, name+ext)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// expandFilename replaces the placeholders of the template with the request and metadata values.
+func expandFilename(tmpl string, req entities.Request, meta *youtubeMeta) string {
+	date := time.Now().UTC().Format(time.DateOnly)
+	if t, err := time.Parse("20060102", meta.UploadDate); err == nil {
+		date = t.Format(time.DateOnly)
+	}
+	return strings.NewReplacer(
+		"{id}", req.ID,
+		"{videoid}", meta.ID,
+		"{date}", date,
+	).Replace(tmpl)
+}
+
+// sanitizeFilename makes the name safe for the file system and URLs:
+// characters other than ASCII letters, digits, dots, hyphens and underscores
+// are replaced with underscores, repeated underscores are collapsed,
+// and leading and trailing separators are trimmed. The name is truncated to maxFilenameLength.
+func sanitizeFilename(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			b.WriteRune(r)
+		case !strings.HasSuffix(b.String(), "_"):
+			b.WriteRune('_')
+		}
+	}
+	name = b.String()
+	if len(name) > maxFilenameLength {
+		name = name[:maxFilenameLength]
+	}
+	return strings.Trim(name, "._-")
+}
@@ -0,0 +1,159 @@
+package platforms
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ofstudio/voxify/internal/config"
+	"github.com/ofstudio/voxify/internal/entities"
+)
+
+// TestFilenameSuite is a test suite for the media file name template
+type TestFilenameSuite struct {
+	suite.Suite
+	cfg config.Settings
+	req entities.Request
+}
+
+// SetupTest is called before each test method
+func (suite *TestFilenameSuite) SetupTest() {
+	suite.cfg = config.Settings{PublicDir: suite.T().TempDir()}
+	suite.req = entities.Request{ID: "req123", DownloadFormat: entities.DownloadMp3}
+}
+
+// fileName returns the file name expanded from the template, releasing the name
+func (suite *TestFilenameSuite) fileName(tmpl string, meta youtubeMeta) string {
+	cfg := suite.cfg
+	cfg.MediaFilenameTemplate = tmpl
+	name, release := NewYtDlpPlatform(cfg, slog.Default()).fileName(suite.req, &meta)
+	release()
+	return name
+}
+
+// TestFileName tests the expansion of the template
+func (suite *TestFilenameSuite) TestFileName() {
+	meta := youtubeMeta{ID: "dQw4w9WgXcQ", UploadDate: "20240131"}
+
+	tests := []struct {
+		name string
+		tmpl string
+		meta youtubeMeta
+		want string
+	}{
+		{name: "Default", tmpl: "", meta: meta, want: "req123"},
+		{name: "ID", tmpl: "{id}", meta: meta, want: "req123"},
+		{name: "VideoID", tmpl: "{videoid}", meta: meta, want: "dQw4w9WgXcQ"},
+		{name: "Date", tmpl: "{date}-{videoid}", meta: meta, want: "2024-01-31-dQw4w9WgXcQ"},
+		{name: "Literal", tmpl: "episode_{id}", meta: meta, want: "episode_req123"},
+		{name: "UnsafeVideoID", tmpl: "{videoid}", meta: youtubeMeta{ID: "../../etc/passwd"}, want: "etc_passwd"},
+		{name: "UnsafeLiteral", tmpl: "my podcast: {id}?", meta: meta, want: "my_podcast_req123"},
+		{name: "EmptyVideoID", tmpl: "{videoid}", meta: youtubeMeta{}, want: "req123"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.Equal(tt.want, suite.fileName(tt.tmpl, tt.meta))
+		})
+	}
+
+	suite.Run("DateNotReported", func() {
+		// Act
+		name := suite.fileName("{date}", youtubeMeta{UploadDate: "unknown"})
+
+		// Assert
+		suite.Equal(time.Now().UTC().Format(time.DateOnly), name)
+	})
+
+	suite.Run("Published", func() {
+		// Arrange
+		suite.Require().NoError(os.WriteFile(filepath.Join(suite.cfg.PublicDir, "dQw4w9WgXcQ.mp3"), nil, 0644))
+
+		// Act
+		name := suite.fileName("{videoid}", meta)
+
+		// Assert
+		suite.Equal("dQw4w9WgXcQ-req123", name)
+	})
+
+	suite.Run("Reserved", func() {
+		// Arrange
+		cfg := suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		cfg.MediaFilenameTemplate = "{videoid}"
+		platform := NewYtDlpPlatform(cfg, slog.Default())
+		other := entities.Request{ID: "req456", DownloadFormat: entities.DownloadMp3}
+
+		// Act
+		first, releaseFirst := platform.fileName(suite.req, &meta)
+		second, releaseSecond := platform.fileName(other, &meta)
+		releaseFirst()
+		releaseSecond()
+		third, releaseThird := platform.fileName(other, &meta)
+		releaseThird()
+
+		// Assert
+		suite.Equal("dQw4w9WgXcQ", first)
+		suite.Equal("dQw4w9WgXcQ-req456", second, "name reserved by another download must not be reused")
+		suite.Equal("dQw4w9WgXcQ", third, "released name must be available")
+	})
+}
+
+// TestSanitizeFilename tests that the unsafe characters are removed from the file name
+func (suite *TestFilenameSuite) TestSanitizeFilename() {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "Safe", in: "abc-DEF_123.v2", want: "abc-DEF_123.v2"},
+		{name: "Slashes", in: "a/b\\c", want: "a_b_c"},
+		{name: "Spaces", in: "a  b\tc", want: "a_b_c"},
+		{name: "URLUnsafe", in: "a?b#c%d&e", want: "a_b_c_d_e"},
+		{name: "NonASCII", in: "Привет мир", want: ""},
+		{name: "Trimmed", in: "..hidden-", want: "hidden"},
+		{name: "Control", in: "a\x00b\nc", want: "a_b_c"},
+		{name: "Truncated", in: strings.Repeat("a", 150), want: strings.Repeat("a", maxFilenameLength)},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.Equal(tt.want, sanitizeFilename(tt.in))
+		})
+	}
+}
+
+// TestValidateFilenameTemplate tests the validation of the template
+func (suite *TestFilenameSuite) TestValidateFilenameTemplate() {
+	tests := []struct {
+		name    string
+		tmpl    string
+		wantErr bool
+	}{
+		{name: "Empty", tmpl: ""},
+		{name: "Placeholders", tmpl: "{date}_{videoid}_{id}"},
+		{name: "Literal", tmpl: "episode"},
+		{name: "Unknown", tmpl: "{title}", wantErr: true},
+		{name: "OnlyUnsafe", tmpl: "???", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			err := validateFilenameTemplate(tt.tmpl)
+			if tt.wantErr {
+				suite.Error(err)
+			} else {
+				suite.NoError(err)
+			}
+		})
+	}
+}
+
+func TestFilename(t *testing.T) {
+	suite.Run(t, new(TestFilenameSuite))
+}
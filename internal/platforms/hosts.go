@@ -0,0 +1,35 @@
+package platforms
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Default host patterns of the supported platforms.
+var (
+	YouTubeHosts    = []string{"www.youtube.com", "youtube.com", "m.youtube.com", "youtu.be"}
+	SoundCloudHosts = []string{"soundcloud.com", "*.soundcloud.com"}
+	VimeoHosts      = []string{"vimeo.com", "*.vimeo.com"}
+)
+
+// matchHost reports whether the https URL belongs to one of the host patterns.
+// A pattern is either an exact host name (e.g. youtube.com)
+// or a wildcard matching any subdomain of a domain (e.g. *.youtube.com).
+func matchHost(href string, patterns []string) bool {
+	u, err := url.Parse(href)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if domain, ok := strings.CutPrefix(pattern, "*."); ok {
+			if strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		} else if host == pattern {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package platforms
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ofstudio/voxify/internal/config"
+	"github.com/ofstudio/voxify/internal/entities"
+)
+
+// soundcloudPayload is a trimmed yt-dlp JSON metadata of a SoundCloud track.
+const soundcloudPayload = `{
+	"id": "123456789",
+	"title": "Test Track",
+	"description": "Track description",
+	"duration": 245.3,
+	"uploader": "Label Account",
+	"artist": "Track Artist",
+	"webpage_url": "https://soundcloud.com/label/test-track",
+	"thumbnail": "https://i1.sndcdn.com/avatars-000111-aaa-original.jpg",
+	"artwork_url": "https://i1.sndcdn.com/artworks-000222-bbb-large.jpg",
+	"filesize": 1000
+}`
+
+// TestSoundCloudSuite is a test suite for SoundCloud platform
+type TestSoundCloudSuite struct {
+	suite.Suite
+	ctx      context.Context
+	cfg      config.Settings
+	argsFile string
+	platform *SoundCloud
+}
+
+// SetupTest is called before each test method
+func (suite *TestSoundCloudSuite) SetupTest() {
+	suite.ctx = context.Background()
+	binDir := suite.T().TempDir()
+	ytDlpPath := filepath.Join(binDir, "yt-dlp")
+	suite.Require().NoError(os.WriteFile(ytDlpPath, []byte(mockYtDlpScript), 0755))
+	ffmpegPath := filepath.Join(binDir, "ffmpeg")
+	suite.Require().NoError(os.WriteFile(ffmpegPath, []byte(mockFFMpegScript), 0755))
+	suite.argsFile = filepath.Join(binDir, "ffmpeg-args")
+
+	suite.cfg = config.Settings{
+		PublicDir:     suite.T().TempDir(),
+		DownloadDir:   suite.T().TempDir(),
+		YtDlpPath:     ytDlpPath,
+		FFMpegPath:    ffmpegPath,
+		ThumbnailSize: 3000,
+	}
+	suite.platform = NewSoundCloudPlatform(suite.cfg, slog.Default())
+}
+
+// TestMatch tests URL matching of SoundCloud tracks
+func (suite *TestSoundCloudSuite) TestMatch() {
+	suite.Equal("soundcloud", suite.platform.ID())
+	suite.True(suite.platform.Match("https://soundcloud.com/artist/track"))
+	suite.True(suite.platform.Match("https://m.soundcloud.com/artist/track"))
+	suite.True(suite.platform.Match("https://on.soundcloud.com/AbCdEf"))
+	suite.False(suite.platform.Match("http://soundcloud.com/artist/track"))
+	suite.False(suite.platform.Match("https://soundcloud.com.example.com/artist/track"))
+	suite.False(suite.platform.Match("https://www.youtube.com/watch?v=abc"))
+}
+
+// TestParseMeta tests mapping of the SoundCloud metadata
+func (suite *TestSoundCloudSuite) TestParseMeta() {
+	suite.Run("Track", func() {
+		// Act
+		meta, err := suite.platform.parseMeta([]byte(soundcloudPayload))
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("Test Track", meta.Title)
+		suite.Equal("Track description", meta.Description)
+		suite.Equal("Track Artist", meta.Uploader)
+		suite.Equal("https://i1.sndcdn.com/artworks-000222-bbb-t500x500.jpg", meta.Thumbnail)
+		suite.Equal("https://soundcloud.com/label/test-track", meta.WebpageURL)
+		suite.Equal(int64(1000), meta.Filesize)
+	})
+
+	suite.Run("NoArtist", func() {
+		// Act
+		meta, err := suite.platform.parseMeta([]byte(`{"title":"Test Track","uploader":"Label Account"}`))
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("Label Account", meta.Uploader)
+	})
+
+	suite.Run("NoArtwork", func() {
+		// Act
+		meta, err := suite.platform.parseMeta([]byte(`{"title":"Test Track","thumbnail":"https://i1.sndcdn.com/avatars-000111-aaa-original.jpg"}`))
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("https://i1.sndcdn.com/avatars-000111-aaa-original.jpg", meta.Thumbnail)
+	})
+
+	suite.Run("AlreadyLargest", func() {
+		// Act
+		meta, err := suite.platform.parseMeta([]byte(`{"title":"Test Track","artwork_url":"https://i1.sndcdn.com/artworks-000222-bbb-t500x500.jpg"}`))
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("https://i1.sndcdn.com/artworks-000222-bbb-t500x500.jpg", meta.Thumbnail)
+	})
+
+	suite.Run("YouTubeUnchanged", func() {
+		// Act
+		meta, err := NewYtDlpPlatform(suite.cfg, slog.Default()).parseMeta([]byte(soundcloudPayload))
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("Label Account", meta.Uploader)
+		suite.Equal("https://i1.sndcdn.com/avatars-000111-aaa-original.jpg", meta.Thumbnail)
+	})
+
+	suite.Run("InvalidJSON", func() {
+		// Act
+		meta, err := suite.platform.parseMeta([]byte(`{"title":`))
+
+		// Assert
+		suite.Nil(meta)
+		suite.ErrorContains(err, "failed to parse yt-dlp json")
+	})
+}
+
+// TestDownload tests the Download method
+func (suite *TestSoundCloudSuite) TestDownload() {
+	// Arrange
+	suite.T().Setenv("MOCK_META_JSON", soundcloudPayload)
+	suite.T().Setenv("MOCK_ACTUAL_SIZE", "1000")
+	suite.T().Setenv("MOCK_NORMALIZED_SIZE", "100")
+	suite.T().Setenv("MOCK_FFMPEG_ARGS", suite.argsFile)
+	req := entities.Request{
+		ID:              "track",
+		Url:             "https://soundcloud.com/label/test-track",
+		DownloadFormat:  entities.DownloadMp3,
+		DownloadQuality: "192k",
+	}
+
+	// Act
+	episode, err := suite.platform.Download(suite.ctx, req)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Equal("Test Track", episode.Title)
+	suite.Equal("Track Artist", episode.Author)
+	suite.Equal(int64(245), episode.MediaDuration)
+	suite.Equal("https://soundcloud.com/label/test-track", episode.CanonicalURL)
+	suite.Equal("track.jpg", episode.ThumbnailFile)
+	suite.FileExists(filepath.Join(suite.cfg.PublicDir, episode.ThumbnailFile))
+	suite.FileExists(filepath.Join(suite.cfg.PublicDir, episode.MediaFile))
+
+	args, err := os.ReadFile(suite.argsFile)
+	suite.Require().NoError(err)
+	suite.Contains(string(args), "-i https://i1.sndcdn.com/artworks-000222-bbb-t500x500.jpg")
+}
+
+// TestDownload_Error tests that download errors name the platform
+func (suite *TestSoundCloudSuite) TestDownload_Error() {
+	// Arrange
+	suite.T().Setenv("MOCK_META_STDERR", "ERROR: [soundcloud] 123: Unable to download JSON metadata: HTTP Error 404: Not Found")
+	req := entities.Request{
+		ID:              "track",
+		Url:             "https://soundcloud.com/label/test-track",
+		DownloadFormat:  entities.DownloadMp3,
+		DownloadQuality: "192k",
+	}
+
+	// Act
+	episode, err := suite.platform.Download(suite.ctx, req)
+
+	// Assert
+	suite.Nil(episode)
+	suite.ErrorContains(err, "failed to fetch metadata from soundcloud")
+	suite.NotContains(err.Error(), "youtube")
+}
+
+func TestSoundCloud(t *testing.T) {
+	suite.Run(t, new(TestSoundCloudSuite))
+}
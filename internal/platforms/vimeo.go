@@ -0,0 +1,19 @@
+package platforms
+
+import (
+	"log/slog"
+
+	"github.com/ofstudio/voxify/internal/config"
+)
+
+// NewVimeoPlatform creates a Vimeo platform matching URLs of the given host patterns.
+// Videos are downloaded with yt-dlp as YtDlp does, the platform differs only in its ID.
+// VimeoHosts are matched if no hosts are given.
+func NewVimeoPlatform(cfg config.Settings, log *slog.Logger, hosts ...string) *YtDlp {
+	if len(hosts) == 0 {
+		hosts = VimeoHosts
+	}
+	p := NewYtDlpPlatform(cfg, log, hosts...)
+	p.id = "vimeo"
+	return p
+}
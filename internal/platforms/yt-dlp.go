@@ -1,45 +1,73 @@
 package platforms
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/ofstudio/voxify/internal/config"
 	"github.com/ofstudio/voxify/internal/entities"
+	"github.com/ofstudio/voxify/internal/services"
 	"github.com/ofstudio/voxify/pkg/files"
 )
 
 // YtDlp is a services.Platform implementation using yt-dlp for downloading YouTube videos.
 // It extracts media, thumbnail and metadata using yt-dlp and ffmpeg.
 type YtDlp struct {
-	cfg config.Settings
-	log *slog.Logger
+	id      string // Platform ID in logs and errors
+	cfg     config.Settings
+	log     *slog.Logger
+	hosts   []string
+	mapMeta metaMapper // Platform-specific metadata mapping, if any
+
+	moveFile func(src, dst string) error // Moves the downloaded files to the public directory
 }
 
-func NewYtDlpPlatform(cfg config.Settings, log *slog.Logger) *YtDlp {
+// metaMapper maps platform-specific fields of the yt-dlp JSON metadata in data to meta.
+type metaMapper func(data []byte, meta *youtubeMeta) error
+
+// NewYtDlpPlatform creates a yt-dlp platform matching URLs of the given host patterns.
+// YouTubeHosts are matched if no hosts are given.
+func NewYtDlpPlatform(cfg config.Settings, log *slog.Logger, hosts ...string) *YtDlp {
+	if len(hosts) == 0 {
+		hosts = YouTubeHosts
+	}
 	return &YtDlp{
-		cfg: cfg,
-		log: log,
+		id:    "youtube",
+		cfg:   cfg,
+		log:   log,
+		hosts: hosts,
+
+		moveFile: files.MoveFile,
 	}
 }
 
 func (p YtDlp) ID() string {
-	return "yt-dlp"
+	return p.id
 }
 
 func (p YtDlp) Match(url string) bool {
-	return strings.HasPrefix(url, "https://www.youtube.com/") ||
-		strings.HasPrefix(url, "https://youtu.be/") ||
-		strings.HasPrefix(url, "https://youtube.com/") ||
-		strings.HasPrefix(url, "https://m.youtube.com/")
+	return matchHost(url, p.hosts)
+}
+
+// SupportsFormat reports whether the format is one of the audio formats yt-dlp extracts.
+func (p YtDlp) SupportsFormat(f entities.DownloadFormat) bool {
+	_, ok := mediaTypes[f]
+	return ok
 }
 
 const initCheckTimeout = time.Second * 10
@@ -63,9 +91,114 @@ func (p YtDlp) Init(ctx context.Context) error {
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("yt-dlp not found or not working: %w", err)
 	}
+	if err := p.validateArgs(); err != nil {
+		return fmt.Errorf("invalid yt-dlp arguments: %w", err)
+	}
+	if err := validateFilenameTemplate(p.cfg.MediaFilenameTemplate); err != nil {
+		return fmt.Errorf("invalid media file name template: %w", err)
+	}
+	if p.cfg.CleanupGracePeriod > 0 {
+		if err := p.removeStaleDirs(time.Now().Add(-p.cfg.CleanupGracePeriod)); err != nil {
+			return fmt.Errorf("failed to clean download directory: %w", err)
+		}
+	}
 	return nil
 }
 
+// removeStaleDirs removes the temporary directories left in the download directory
+// by downloads interrupted by a crash, which were last modified before the threshold.
+// Directories of the downloads running concurrently are newer and kept.
+func (p YtDlp) removeStaleDirs(threshold time.Time) error {
+	dirs, err := filepath.Glob(filepath.Join(p.cfg.DownloadDir, ytDlpPattern))
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		info, err := os.Stat(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to stat '%s': %w", dir, err)
+		}
+		if !info.IsDir() || info.ModTime().After(threshold) {
+			continue
+		}
+		if err = os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to remove '%s': %w", dir, err)
+		}
+		p.log.Info("[yt-dlp] stale temporary directory removed", "dir", dir, "modified", info.ModTime())
+	}
+	return nil
+}
+
+// forbiddenArgs are yt-dlp options not allowed in YtDlpExtraArgs:
+// they run arbitrary commands or change where and how the output files are written.
+var forbiddenArgs = []string{
+	"--exec", "--exec-before-download", "--batch-file", "-a",
+	"--output", "-o", "--paths", "-P", "--config-locations", "--config-location",
+}
+
+// forbiddenArg returns the forbidden option the argument sets, if any.
+// Long options may carry their value after "=", short options right after the letter.
+func forbiddenArg(arg string) (string, bool) {
+	if strings.HasPrefix(arg, "--") {
+		name, _, _ := strings.Cut(arg, "=")
+		return name, slices.Contains(forbiddenArgs, name)
+	}
+	for _, name := range forbiddenArgs {
+		if !strings.HasPrefix(name, "--") && strings.HasPrefix(arg, name) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// validateArgs checks the configured user-agent, extractor and extra yt-dlp arguments.
+// The arguments are passed to yt-dlp as argv, not through a shell,
+// so only control characters and options that break the download are rejected.
+func (p YtDlp) validateArgs() error {
+	if strings.ContainsFunc(p.cfg.YtDlpUserAgent, unicode.IsControl) {
+		return fmt.Errorf("user-agent contains control characters")
+	}
+	for _, arg := range p.cfg.YtDlpExtractorArgs {
+		if strings.ContainsFunc(arg, unicode.IsControl) {
+			return fmt.Errorf("extractor args contain control characters: %q", arg)
+		}
+		if key, _, ok := strings.Cut(arg, ":"); arg != "" && (!ok || key == "") {
+			return fmt.Errorf("extractor args must be in the form key:args: %q", arg)
+		}
+	}
+	for _, arg := range p.cfg.YtDlpExtraArgs {
+		if strings.ContainsFunc(arg, unicode.IsControl) {
+			return fmt.Errorf("extra args contain control characters: %q", arg)
+		}
+		if name, ok := forbiddenArg(arg); ok {
+			return fmt.Errorf("extra arg is not allowed: %s", name)
+		}
+	}
+	return nil
+}
+
+// commandArgs returns the yt-dlp command arguments: the given arguments
+// followed by the configured user-agent, extractor and extra arguments, if any, and the URL.
+func (p YtDlp) commandArgs(url string, args ...string) []string {
+	if p.cfg.YtDlpUserAgent != "" {
+		args = append(args, "--user-agent", p.cfg.YtDlpUserAgent)
+	}
+	for _, arg := range p.cfg.YtDlpExtractorArgs {
+		if arg != "" {
+			args = append(args, "--extractor-args", arg)
+		}
+	}
+	for _, arg := range p.cfg.YtDlpExtraArgs {
+		if arg != "" {
+			args = append(args, arg)
+		}
+	}
+	return append(args, url)
+}
+
 const ytDlpPattern = "yt-dlp-*"
 
 var errTempDir = errors.New("failed to create temporary directory")
@@ -97,48 +230,129 @@ func (p YtDlp) Download(ctx context.Context, req entities.Request) (*entities.Ep
 	//goland:noinspection GoUnhandledErrorResult
 	defer os.RemoveAll(mediaDir)
 
+	// Playlists are not downloaded as a single episode
+	if isPlaylistURL(req.Url) {
+		return nil, p.playlistError(ctx, req, metaDir)
+	}
+
 	// Fetch metadata
 	p.log.Info("[yt-dlp] downloading metadata", "request", req.LogValue())
 
-	meta, err := p.fetchMeta(ctx, req, metaDir)
+	metaCtx, cancel := p.stepContext(ctx, p.cfg.MetaTimeout)
+	meta, err := p.fetchMeta(metaCtx, req, metaDir)
+	err = stepError(metaCtx, "metadata download", err)
+	cancel()
+	if errors.Is(err, services.ErrPlaylist) && p.cfg.AllowPlaylists {
+		return nil, p.playlistError(ctx, req, metaDir)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch metadata from youtube: %w", err)
+		return nil, fmt.Errorf("failed to fetch metadata from %s: %w", p.id, err)
 	}
 	p.log.Info("[yt-dlp] metadata downloaded", "request", req.LogValue())
 
+	// Bail out before a long download if the media is known to be too large
+	if err = p.checkMediaSize(expectedSize(meta)); err != nil {
+		return nil, fmt.Errorf("media size check failed: %w", err)
+	}
+
+	// Skip accidentally requested short clips
+	if err = p.checkDuration(meta.Duration); err != nil {
+		return nil, fmt.Errorf("media duration check failed: %w", err)
+	}
+
 	episode := &entities.Episode{
 		Title:         meta.Title,
 		Description:   meta.Description,
 		MediaType:     mediaType,
-		MediaDuration: meta.Duration,
+		MediaDuration: int64(math.Round(meta.Duration)),
 		Author:        meta.Uploader,
 		OriginalURL:   req.Url,
 		CanonicalURL:  meta.WebpageURL,
+		PublishedAt:   meta.publishedAt(),
+	}
+
+	// Some extractors do not report the page URL
+	if episode.CanonicalURL == "" {
+		episode.CanonicalURL = normalizeURL(req.Url)
+		p.log.Warn("[yt-dlp] webpage url is missing in metadata, using request url",
+			"request", req.LogValue(), "canonical_url", episode.CanonicalURL)
 	}
 
+	// Name media and thumbnail files
+	fileName, release := p.fileName(req, meta)
+	defer release()
+
 	// Fetch thumbnail
 	if meta.Thumbnail != "" {
 		p.log.Info("[yt-dlp] downloading thumbnail", "request", req.LogValue())
-		if episode.ThumbnailFile, err = p.fetchThumbnail(ctx, req, meta.Thumbnail, thumbDir); err != nil {
-			return nil, fmt.Errorf("failed to fetch thumbnail from youtube: %w", err)
+		thumbCtx, cancel := p.stepContext(ctx, p.cfg.ThumbnailTimeout)
+		episode.ThumbnailFile, err = p.fetchThumbnail(thumbCtx, fileName, meta.Thumbnail, thumbDir)
+		err = stepError(thumbCtx, "thumbnail download", err)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch thumbnail from %s: %w", p.id, err)
 		}
 		p.log.Info("[yt-dlp] thumbnail downloaded", "request", req.LogValue())
 	}
 
 	// Fetch media
 	p.log.Info("[yt-dlp] downloading media", "request", req.LogValue())
-	if episode.MediaFile, episode.MediaSize, err = p.fetchMedia(ctx, req, mediaDir); err != nil {
-		return nil, fmt.Errorf("failed to fetch media from youtube: %w", err)
+	mediaCtx, cancel := p.stepContext(ctx, p.cfg.MediaTimeout)
+	episode.MediaFile, episode.MediaSize, err = p.fetchMedia(mediaCtx, req, fileName, mediaDir)
+	err = stepError(mediaCtx, "media download", err)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch media from %s: %w", p.id, err)
 	}
 
 	p.log.Info("[yt-dlp] media downloaded", "request", req.LogValue())
 
+	// Drop the media file exceeding the maximum size
+	if err = p.checkMediaSize(episode.MediaSize); err != nil {
+		_ = os.Remove(filepath.Join(mediaDir, episode.MediaFile))
+		return nil, fmt.Errorf("media size check failed: %w", err)
+	}
+
+	// Verify the downloaded source file is not truncated: the size reported by yt-dlp
+	// is of the source format, not of the extracted audio
+	source, err := sourceFile(mediaDir, episode.MediaFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find source media file: %w", err)
+	}
+	sourceInfo, err := os.Stat(filepath.Join(mediaDir, source))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source media file size: %w", err)
+	}
+	if err = p.verifyMediaSize(meta, sourceInfo.Size()); err != nil {
+		return nil, fmt.Errorf("media verification failed: %w", err)
+	}
+	// Do not keep a second full-size copy during post-processing
+	if source != episode.MediaFile {
+		_ = os.Remove(filepath.Join(mediaDir, source))
+	}
+
+	// Post-process the media within its own timeout
+	postCtx, cancel := p.stepContext(ctx, p.cfg.PostProcessTimeout)
+	defer cancel()
+
+	// Normalize audio loudness
+	if p.cfg.NormalizeAudio && isAudio(episode.MediaType) {
+		p.log.Info("[yt-dlp] normalizing audio", "request", req.LogValue())
+		episode.MediaSize, err = p.normalizeAudio(postCtx, req, episode.MediaFile, mediaDir)
+		if err = stepError(postCtx, "audio normalization", err); err != nil {
+			return nil, fmt.Errorf("failed to normalize audio: %w", err)
+		}
+		p.log.Info("[yt-dlp] audio normalized", "request", req.LogValue())
+	}
+
+	// Do not publish the files if post-processing has timed out
+	if err = stepError(postCtx, "post-processing", postCtx.Err()); err != nil {
+		return nil, err
+	}
+
 	// Move thumbnail file to public directory
 	if episode.ThumbnailFile != "" {
-		if err = files.MoveFile(
-			filepath.Join(thumbDir, episode.ThumbnailFile),
-			filepath.Join(p.cfg.PublicDir, episode.ThumbnailFile),
-		); err != nil {
+		if err = p.publishFile(thumbDir, episode.ThumbnailFile); err != nil {
 			return nil, fmt.Errorf("failed to move thumbnail: %w", err)
 		}
 	}
@@ -147,24 +361,64 @@ func (p YtDlp) Download(ctx context.Context, req entities.Request) (*entities.Ep
 	if episode.MediaFile == "" {
 		return nil, fmt.Errorf("media file name is empty")
 	}
-	if err = files.MoveFile(
-		filepath.Join(mediaDir, episode.MediaFile),
-		filepath.Join(p.cfg.PublicDir, episode.MediaFile),
-	); err != nil {
+	if err = p.publishFile(mediaDir, episode.MediaFile); err != nil {
 		return nil, fmt.Errorf("failed to move media file: %w", err)
 	}
 
+	// Compute media checksum of the published file. The files are already published,
+	// so the episode is kept without the checksum if it fails
+	episode.MediaHash, err = files.HashFile(postCtx, filepath.Join(p.cfg.PublicDir, episode.MediaFile))
+	if err = stepError(postCtx, "media checksum", err); err != nil {
+		p.log.Warn("[yt-dlp] failed to compute media checksum", "request", req.LogValue(), "error", err)
+	}
+
 	return episode, nil
 }
 
+// publishFile moves the file from dir to the public directory
+// and checks that the file exists at the target path,
+// so that the episode never points to a missing file.
+func (p YtDlp) publishFile(dir, name string) error {
+	target := filepath.Join(p.cfg.PublicDir, name)
+	if err := p.moveFile(filepath.Join(dir, name), target); err != nil {
+		return err
+	}
+	if _, err := os.Stat(target); err != nil {
+		return fmt.Errorf("file is missing after move: %w", err)
+	}
+	return nil
+}
+
+// stepContext returns a context for a download step limited by the step timeout.
+// If the step timeout is not set, DownloadTimeout is used, so every step
+// including the post-processing (normalization and checksum) has a bounded duration.
+func (p YtDlp) stepContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = p.cfg.DownloadTimeout
+	}
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// stepError replaces the error of a download or post-processing step with context.DeadlineExceeded
+// if the step context has timed out: a killed process reports only its exit status.
+func stepError(ctx context.Context, step string, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%s timed out: %w", step, context.DeadlineExceeded)
+	}
+	return err
+}
+
 func (p YtDlp) fetchMeta(ctx context.Context, req entities.Request, dir string) (*youtubeMeta, error) {
-	cmd := exec.CommandContext(ctx, p.cfg.YtDlpPath,
-		"--no-playlist", // Do not download playlists
-		"-j",            // Dump JSON metadata
+	cmd := exec.CommandContext(ctx, p.cfg.YtDlpPath, p.commandArgs(req.Url,
+		"--no-playlist",        // Do not download playlists
+		"-f", "bestaudio/best", // Same format selection as audio extraction
+		"-j", // Dump JSON metadata
 		"--no-warnings",
 		"--skip-download",
-		req.Url,
-	)
+	)...)
 	cmd.Dir = dir
 
 	var stdout, stderr strings.Builder
@@ -172,13 +426,33 @@ func (p YtDlp) fetchMeta(ctx context.Context, req entities.Request, dir string)
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("yt-dlp command failed: %w, stderr: %s", err, strings.TrimSpace(stderr.String()))
+		err = fmt.Errorf("yt-dlp command failed: %w, stderr: %s", err, strings.TrimSpace(stderr.String()))
+		return nil, classifyError(err, stderr.String())
 	}
 
+	return p.parseMeta([]byte(stdout.String()))
+}
+
+// parseMeta parses the yt-dlp JSON metadata applying the platform-specific mapping.
+// It returns services.ErrPlaylist if yt-dlp reports a playlist: either the playlist metadata
+// or the metadata of several entries, one JSON document per entry.
+func (p YtDlp) parseMeta(data []byte) (*youtubeMeta, error) {
 	meta := &youtubeMeta{}
-	if err := json.Unmarshal([]byte(stdout.String()), meta); err != nil {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := dec.Decode(meta); err != nil {
 		return nil, fmt.Errorf("failed to parse yt-dlp json: %w", err)
 	}
+	if meta.Type == "playlist" {
+		return nil, services.ErrPlaylist
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("%w: yt-dlp returned metadata of %d entries", services.ErrPlaylist, countEntries(dec)+1)
+	}
+	if p.mapMeta != nil {
+		if err := p.mapMeta(data, meta); err != nil {
+			return nil, fmt.Errorf("failed to map yt-dlp json: %w", err)
+		}
+	}
 
 	if meta.Title == "" {
 		meta.Title = meta.Uploader
@@ -190,8 +464,71 @@ func (p YtDlp) fetchMeta(ctx context.Context, req entities.Request, dir string)
 	return meta, nil
 }
 
-func (p YtDlp) fetchThumbnail(ctx context.Context, req entities.Request, thumbUrl, dir string) (string, error) {
-	fileName := req.ID + ".jpg"
+// countEntries counts the remaining JSON documents of the decoder.
+// The first malformed document ends the counting.
+func countEntries(dec *json.Decoder) int {
+	var n int
+	for dec.More() {
+		if err := dec.Decode(&json.RawMessage{}); err != nil {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// playlistError returns services.ErrPlaylist for the playlist request, or services.PlaylistError
+// listing the playlist entries if playlists are allowed.
+func (p YtDlp) playlistError(ctx context.Context, req entities.Request, dir string) error {
+	if !p.cfg.AllowPlaylists {
+		return services.ErrPlaylist
+	}
+	p.log.Info("[yt-dlp] downloading playlist entries", "request", req.LogValue())
+	listCtx, cancel := p.stepContext(ctx, p.cfg.MetaTimeout)
+	defer cancel()
+	entries, err := p.fetchPlaylist(listCtx, req, dir)
+	if err = stepError(listCtx, "playlist download", err); err != nil {
+		return fmt.Errorf("failed to fetch playlist entries: %w", err)
+	}
+	return &services.PlaylistError{Entries: entries}
+}
+
+// fetchPlaylist returns the URLs of the playlist entries without fetching their metadata.
+func (p YtDlp) fetchPlaylist(ctx context.Context, req entities.Request, dir string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, p.cfg.YtDlpPath, p.commandArgs(req.Url,
+		"--flat-playlist", // List entries without resolving them
+		"-J",              // Dump JSON metadata of the playlist
+		"--no-warnings",
+	)...)
+	cmd.Dir = dir
+
+	var stdout, stderr strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		err = fmt.Errorf("yt-dlp command failed: %w, stderr: %s", err, strings.TrimSpace(stderr.String()))
+		return nil, classifyError(err, stderr.String())
+	}
+
+	var playlist youtubePlaylist
+	if err := json.Unmarshal([]byte(stdout.String()), &playlist); err != nil {
+		return nil, fmt.Errorf("failed to parse yt-dlp json: %w", err)
+	}
+	var entries []string
+	for _, entry := range playlist.Entries {
+		if u := cmp.Or(entry.WebpageURL, entry.URL); u != "" {
+			entries = append(entries, u)
+		}
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: playlist has no entries", services.ErrPermanent)
+	}
+	return entries, nil
+}
+
+func (p YtDlp) fetchThumbnail(ctx context.Context, name, thumbUrl, dir string) (string, error) {
+	fileName := name + ".jpg"
 	cmd := exec.CommandContext(ctx, p.cfg.FFMpegPath,
 		"-y",           // Overwrite output files without asking
 		"-i", thumbUrl, // Input file
@@ -206,29 +543,35 @@ func (p YtDlp) fetchThumbnail(ctx context.Context, req entities.Request, thumbUr
 	cmd.Dir = dir
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", fmt.Errorf("ffmpeg command failed: %w, output: %s", err, string(output))
+		err = fmt.Errorf("ffmpeg command failed: %w, output: %s", err, string(output))
+		return "", noSpaceError(err, string(output))
 	}
 	return fileName, nil
 }
 
-func (p YtDlp) fetchMedia(ctx context.Context, req entities.Request, dir string) (string, int64, error) {
-	fileName := req.ID + "." + string(req.DownloadFormat)
-	cmd := exec.CommandContext(ctx, p.cfg.YtDlpPath,
-		"--no-playlist",                              // Do not download playlists
+// fetchMedia downloads the media to dir and extracts the audio to the file named name
+// with the extension of the requested format. The downloaded source file is kept
+// in dir under the same name with the source extension for the size check, see sourceFile.
+func (p YtDlp) fetchMedia(ctx context.Context, req entities.Request, name, dir string) (string, int64, error) {
+	fileName := name + "." + string(req.DownloadFormat)
+	cmd := exec.CommandContext(ctx, p.cfg.YtDlpPath, p.commandArgs(req.Url,
+		"--no-playlist",        // Do not download playlists
+		"-f", "bestaudio/best", // Same format as reported in metadata
 		"-x",                                         // Extract audio
 		"--audio-format", string(req.DownloadFormat), // Audio format
 		"--audio-quality", req.DownloadQuality, // Audio quality
-		"--no-warnings",     // Suppress warnings
-		"--embed-thumbnail", // Embed thumbnail in the media file
-		"--add-metadata",    // Add metadata to the media file
-		"-o", fileName,      // Output file name
+		"--no-warnings",       // Suppress warnings
+		"--embed-thumbnail",   // Embed thumbnail in the media file
+		"--add-metadata",      // Add metadata to the media file
+		"-o", name+".%(ext)s", // Output file name, the extension is set by the audio format
+		"-k",                // Keep the source file for the size check
 		"--force-overwrite", // Overwrite output files
-		req.Url,
-	)
+	)...)
 	cmd.Dir = dir
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", 0, fmt.Errorf("yt-dlp command failed: %w, output: %s", err, string(output))
+		err = fmt.Errorf("yt-dlp command failed: %w, output: %s", err, string(output))
+		return "", 0, classifyError(err, string(output))
 	}
 
 	// Get file size
@@ -241,14 +584,259 @@ func (p YtDlp) fetchMedia(ctx context.Context, req entities.Request, dir string)
 	return fileName, fileInfo.Size(), nil
 }
 
+// sourceFile returns the name of the source media file kept in dir next to
+// the extracted fileName. If the source is not kept, e.g. it already has the requested
+// format and is not converted, fileName is returned.
+func sourceFile(dir, fileName string) (string, error) {
+	prefix := strings.TrimSuffix(fileName, filepath.Ext(fileName)) + "."
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if name != fileName && entry.Type().IsRegular() && strings.HasPrefix(name, prefix) {
+			return name, nil
+		}
+	}
+	return fileName, nil
+}
+
+// loudnormFilter is the ffmpeg loudness normalization filter
+// targeting -16 LUFS integrated loudness recommended for podcasts.
+const loudnormFilter = "loudnorm=I=-16:TP=-1.5:LRA=11"
+
+// loudnormSampleRate is the output sample rate of the normalized audio:
+// loudnorm upsamples its output to 192 kHz, which MP3 does not support.
+const loudnormSampleRate = "44100"
+
+// normalizeAudio runs ffmpeg loudness normalization pass over the media file in dir
+// and replaces the file with the normalized one. It returns the size of the normalized file.
+func (p YtDlp) normalizeAudio(ctx context.Context, req entities.Request, fileName, dir string) (int64, error) {
+	tmpName := "loudnorm-" + fileName
+	args := []string{
+		"-y",           // Overwrite output files without asking
+		"-i", fileName, // Input file
+		"-map", "0:a", // Audio stream
+		"-map", "0:v?", // Embedded thumbnail, if any
+		"-c:v", "copy", // Keep thumbnail as is
+		"-map_metadata", "0", // Keep metadata
+		"-af", loudnormFilter, // Loudness normalization
+		"-ar", loudnormSampleRate, // Resample from the loudnorm 192 kHz output
+	}
+	if isBitrate(req.DownloadQuality) {
+		args = append(args, "-b:a", req.DownloadQuality) // Keep requested bitrate
+	}
+	args = append(args, tmpName) // Output file
+
+	cmd := exec.CommandContext(ctx, p.cfg.FFMpegPath, args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		_ = os.Remove(filepath.Join(dir, tmpName))
+		err = fmt.Errorf("ffmpeg command failed: %w, output: %s", err, string(output))
+		return 0, noSpaceError(err, string(output))
+	}
+
+	// Replace original file with normalized one
+	if err = os.Rename(filepath.Join(dir, tmpName), filepath.Join(dir, fileName)); err != nil {
+		return 0, fmt.Errorf("failed to replace media file: %w", err)
+	}
+
+	// Get normalized file size
+	fileInfo, err := os.Stat(filepath.Join(dir, fileName))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get file size: %w", err)
+	}
+
+	return fileInfo.Size(), nil
+}
+
+// normalizeURL returns the URL with lower-cased scheme and host and without fragment.
+// If the URL cannot be parsed, it is returned as is.
+func normalizeURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+// isPlaylistURL reports whether the URL refers to a playlist rather than a single video,
+// i.e. it has the list query parameter without a video ID in the v parameter
+// or in the path (e.g. YouTube playlist URLs).
+// Playlists with URLs of other forms are detected by the yt-dlp metadata.
+func isPlaylistURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	query := u.Query()
+	return query.Get("list") != "" && query.Get("v") == "" && !hasPathVideoID(u)
+}
+
+// videoPathPrefixes are the URL path prefixes followed by the video ID.
+var videoPathPrefixes = []string{"/shorts/", "/live/", "/embed/"}
+
+// hasPathVideoID reports whether the URL path has the video ID,
+// e.g. youtu.be/<id>, youtube.com/shorts/<id> or youtube.com/live/<id>.
+func hasPathVideoID(u *url.URL) bool {
+	if strings.EqualFold(strings.TrimPrefix(u.Hostname(), "www."), "youtu.be") {
+		return strings.Trim(u.Path, "/") != ""
+	}
+	for _, prefix := range videoPathPrefixes {
+		if id, ok := strings.CutPrefix(u.Path, prefix); ok && strings.Trim(id, "/") != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// isAudio reports whether the media type is an audio format.
+func isAudio(mediaType entities.MediaType) bool {
+	return strings.HasPrefix(string(mediaType), "audio/")
+}
+
+// isBitrate reports whether the download quality is a bitrate, e.g. 192k.
+func isBitrate(quality string) bool {
+	quality = strings.ToLower(quality)
+	if !strings.HasSuffix(quality, "k") {
+		return false
+	}
+	_, err := strconv.Atoi(strings.TrimSuffix(quality, "k"))
+	return err == nil
+}
+
+// mediaSizeTolerance is the minimum allowed ratio of the source media file size
+// to the size reported by yt-dlp. The reported size may be an estimate,
+// so the check only catches clearly truncated downloads.
+const mediaSizeTolerance = 0.5
+
+var errMediaTruncated = errors.New("media file is truncated")
+
+// verifyMediaSize compares the downloaded source file size with the size reported by yt-dlp
+// for the same format. The extracted audio size is not comparable, as it is transcoded.
+// If yt-dlp does not report the size, the check is skipped.
+func (p YtDlp) verifyMediaSize(meta *youtubeMeta, actual int64) error {
+	expected := expectedSize(meta)
+	if expected <= 0 {
+		return nil
+	}
+	if float64(actual) < float64(expected)*mediaSizeTolerance {
+		return fmt.Errorf("%w: expected about %d bytes, got %d bytes", errMediaTruncated, expected, actual)
+	}
+	return nil
+}
+
+// checkMediaSize returns services.ErrMediaTooLarge if the size exceeds MaxMediaSize.
+// The size is not limited if MaxMediaSize is not set.
+func (p YtDlp) checkMediaSize(size int64) error {
+	if p.cfg.MaxMediaSize <= 0 || size <= p.cfg.MaxMediaSize {
+		return nil
+	}
+	return fmt.Errorf("%w: %d bytes, maximum is %d bytes", services.ErrMediaTooLarge, size, p.cfg.MaxMediaSize)
+}
+
+// checkDuration returns services.ErrMediaTooShort if the duration in seconds is less than MinDuration.
+// The duration is not limited if MinDuration is not set or the duration is unknown.
+func (p YtDlp) checkDuration(seconds float64) error {
+	if p.cfg.MinDuration <= 0 || seconds <= 0 || seconds >= p.cfg.MinDuration.Seconds() {
+		return nil
+	}
+	return fmt.Errorf("%w: %gs, minimum is %s", services.ErrMediaTooShort, seconds, p.cfg.MinDuration)
+}
+
+// expectedSize returns the media size reported by yt-dlp, exact if known and estimated otherwise.
+// It returns 0 if yt-dlp does not report the size.
+func expectedSize(meta *youtubeMeta) int64 {
+	if meta.Filesize > 0 {
+		return meta.Filesize
+	}
+	return max(meta.FilesizeApprox, 0)
+}
+
 // youtubeMeta represents metadata fetched from yt-dlp.
 type youtubeMeta struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Thumbnail   string `json:"thumbnail"`
-	Duration    int64  `json:"duration"`
-	Uploader    string `json:"uploader"`
-	WebpageURL  string `json:"webpage_url"`
+	Type           string  `json:"_type"` // "playlist" for playlists, "video" or empty otherwise
+	ID             string  `json:"id"`    // Media ID on the platform
+	Title          string  `json:"title"`
+	Description    string  `json:"description"`
+	Thumbnail      string  `json:"thumbnail"`
+	Duration       float64 `json:"duration"` // Seconds, fractional for some extractors (e.g. SoundCloud)
+	Uploader       string  `json:"uploader"`
+	UploadDate     string  `json:"upload_date"` // YYYYMMDD, if known
+	Timestamp      float64 `json:"timestamp"`   // Unix time of the upload, if known
+	WebpageURL     string  `json:"webpage_url"`
+	Filesize       int64   `json:"filesize"`        // Exact file size of the selected format, if known
+	FilesizeApprox int64   `json:"filesize_approx"` // Estimated file size of the selected format, if known
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The numeric fields are decoded
+// with metaNumber, so the numbers of unexpected types do not fail the metadata.
+func (m *youtubeMeta) UnmarshalJSON(data []byte) error {
+	type plain youtubeMeta // Without the UnmarshalJSON method
+	aux := struct {
+		*plain
+		Duration       metaNumber `json:"duration"`
+		Filesize       metaNumber `json:"filesize"`
+		FilesizeApprox metaNumber `json:"filesize_approx"`
+		Timestamp      metaNumber `json:"timestamp"`
+	}{plain: (*plain)(m)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	m.Duration = float64(aux.Duration)
+	m.Filesize = int64(math.Round(float64(aux.Filesize)))
+	m.FilesizeApprox = int64(math.Round(float64(aux.FilesizeApprox)))
+	m.Timestamp = float64(aux.Timestamp)
+	return nil
+}
+
+// publishedAt returns the original publish time of the media: the upload timestamp if reported,
+// the upload date at midnight UTC otherwise, or the zero time if neither is reported.
+func (m *youtubeMeta) publishedAt() time.Time {
+	if m.Timestamp > 0 {
+		return time.Unix(int64(m.Timestamp), 0).UTC()
+	}
+	if t, err := time.Parse("20060102", m.UploadDate); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// metaNumber is a number of yt-dlp metadata. Extractors report some numbers
+// as integers, floats or numeric strings, and unknown ones as null.
+// Null, empty and non-numeric values are decoded as 0.
+type metaNumber float64
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *metaNumber) UnmarshalJSON(data []byte) error {
+	*n = 0
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case float64:
+		*n = metaNumber(v)
+	case string:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			*n = metaNumber(f)
+		}
+	}
+	return nil
+}
+
+// youtubePlaylist represents flat playlist metadata fetched from yt-dlp.
+type youtubePlaylist struct {
+	Entries []struct {
+		URL        string `json:"url"`
+		WebpageURL string `json:"webpage_url"`
+	} `json:"entries"`
 }
 
 var mediaTypes = map[entities.DownloadFormat]entities.MediaType{
@@ -0,0 +1,1202 @@
+package platforms
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ofstudio/voxify/internal/config"
+	"github.com/ofstudio/voxify/internal/entities"
+	"github.com/ofstudio/voxify/internal/services"
+)
+
+// mockYtDlpScript is a fake yt-dlp executable.
+// It prints metadata reporting a media size of $MOCK_FILESIZE bytes,
+// a duration of $MOCK_DURATION seconds (60 if not set)
+// and a thumbnail URL of $MOCK_THUMBNAIL when called with -j
+// and creates an output file of $MOCK_ACTUAL_SIZE bytes otherwise,
+// keeping the source file of $MOCK_SOURCE_SIZE bytes ($MOCK_ACTUAL_SIZE if not set)
+// filled with "s" and the webm extension if called with -k.
+// The webpage_url field is omitted from metadata if $MOCK_NO_WEBPAGE_URL is set.
+// Metadata is replaced with $MOCK_META_JSON if set.
+// Metadata download fails with $MOCK_META_STDERR printed to stderr if set.
+// Media download fails with $MOCK_MEDIA_STDERR printed to stderr if set.
+// It prints $MOCK_PLAYLIST_JSON when called with -J (flat playlist metadata).
+// It sleeps for $MOCK_SLEEP_META or $MOCK_SLEEP_MEDIA seconds before the respective step if set.
+// Its arguments are appended as a line to $MOCK_YTDLP_ARGS if set.
+const mockYtDlpScript = `#!/bin/sh
+[ -n "$MOCK_YTDLP_ARGS" ] && echo "$@" >> "$MOCK_YTDLP_ARGS"
+out=""
+format=""
+keep=""
+while [ $# -gt 0 ]; do
+  case "$1" in
+    -j)
+      [ -n "$MOCK_SLEEP_META" ] && exec sleep "$MOCK_SLEEP_META"
+      [ -n "$MOCK_META_STDERR" ] && { echo "$MOCK_META_STDERR" >&2; exit 1; }
+      [ -n "$MOCK_META_JSON" ] && { printf '%s' "$MOCK_META_JSON"; exit 0; }
+      webpage=',"webpage_url":"https://www.youtube.com/watch?v=test"'
+      [ -n "$MOCK_NO_WEBPAGE_URL" ] && webpage=''
+      printf '{"title":"Test","description":"Desc","duration":%s,"uploader":"Author"%s,"thumbnail":"%s","filesize":%s}' "${MOCK_DURATION:-60}" "$webpage" "$MOCK_THUMBNAIL" "$MOCK_FILESIZE"
+      exit 0
+      ;;
+    -J)
+      printf '%s' "$MOCK_PLAYLIST_JSON"
+      exit 0
+      ;;
+    -o)
+      shift
+      out="$1"
+      ;;
+    --audio-format)
+      shift
+      format="$1"
+      ;;
+    -k)
+      keep=1
+      ;;
+  esac
+  shift
+done
+[ -n "$MOCK_SLEEP_MEDIA" ] && exec sleep "$MOCK_SLEEP_MEDIA"
+[ -n "$MOCK_MEDIA_STDERR" ] && { echo "$MOCK_MEDIA_STDERR" >&2; exit 1; }
+base="${out%.%(ext)s}"
+[ -n "$keep" ] && head -c "${MOCK_SOURCE_SIZE:-$MOCK_ACTUAL_SIZE}" /dev/zero | tr '\0' s > "$base.webm"
+head -c "$MOCK_ACTUAL_SIZE" /dev/zero > "$base.$format"
+`
+
+// mockFFMpegScript is a fake ffmpeg executable.
+// It writes its arguments to $MOCK_FFMPEG_ARGS and creates the output file
+// (the last argument) of $MOCK_NORMALIZED_SIZE bytes.
+// It sleeps for $MOCK_SLEEP_FFMPEG seconds first if set.
+const mockFFMpegScript = `#!/bin/sh
+[ -n "$MOCK_SLEEP_FFMPEG" ] && exec sleep "$MOCK_SLEEP_FFMPEG"
+echo "$@" > "$MOCK_FFMPEG_ARGS"
+for out; do :; done
+head -c "$MOCK_NORMALIZED_SIZE" /dev/zero > "$out"
+`
+
+// TestYtDlpSuite is a test suite for YtDlp platform
+type TestYtDlpSuite struct {
+	suite.Suite
+	ctx      context.Context
+	cfg      config.Settings
+	platform *YtDlp
+}
+
+// SetupTest is called before each test method
+func (suite *TestYtDlpSuite) SetupTest() {
+	suite.ctx = context.Background()
+	binDir := suite.T().TempDir()
+	ytDlpPath := filepath.Join(binDir, "yt-dlp")
+	suite.Require().NoError(os.WriteFile(ytDlpPath, []byte(mockYtDlpScript), 0755))
+
+	suite.cfg = config.Settings{
+		PublicDir:   suite.T().TempDir(),
+		DownloadDir: suite.T().TempDir(),
+		YtDlpPath:   ytDlpPath,
+		FFMpegPath:  "ffmpeg",
+	}
+	suite.platform = NewYtDlpPlatform(suite.cfg, slog.Default())
+}
+
+// TestDownload tests the Download method
+func (suite *TestYtDlpSuite) TestDownload() {
+	req := entities.Request{
+		ID:              "test123",
+		Url:             "https://www.youtube.com/watch?v=test",
+		DownloadFormat:  entities.DownloadMp3,
+		DownloadQuality: "192k",
+	}
+
+	suite.Run("Success", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "1000")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "900")
+
+		// Act
+		episode, err := suite.platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("test123.mp3", episode.MediaFile)
+		suite.Equal(int64(900), episode.MediaSize)
+		suite.FileExists(filepath.Join(suite.cfg.PublicDir, episode.MediaFile))
+		suite.Equal("https://www.youtube.com/watch?v=test", episode.CanonicalURL)
+		published, err := os.ReadFile(filepath.Join(suite.cfg.PublicDir, episode.MediaFile))
+		suite.Require().NoError(err)
+		sum := sha256.Sum256(published)
+		suite.Equal(hex.EncodeToString(sum[:]), episode.MediaHash, "checksum must be of the published file")
+		suite.NoFileExists(filepath.Join(suite.cfg.PublicDir, "test123.webm"), "source file must not be published")
+	})
+
+	suite.Run("FloatDuration", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "1000.0")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "1000")
+		suite.T().Setenv("MOCK_DURATION", "61.48")
+
+		// Act
+		episode, err := suite.platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(int64(61), episode.MediaDuration)
+	})
+
+	suite.Run("FilenameTemplate", func() {
+		// Arrange
+		cfg := suite.cfg
+		cfg.MediaFilenameTemplate = "{date}-{videoid}"
+		suite.T().Setenv("MOCK_META_JSON", `{"id":"vid/42","upload_date":"20240131","title":"Test","filesize":1000}`)
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "1000")
+
+		// Act
+		episode, err := NewYtDlpPlatform(cfg, slog.Default()).Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("2024-01-31-vid_42.mp3", episode.MediaFile)
+		suite.FileExists(filepath.Join(suite.cfg.PublicDir, episode.MediaFile))
+		suite.Equal(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), episode.PublishedAt)
+	})
+
+	suite.Run("WebpageURLMissing", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "1000")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "1000")
+		suite.T().Setenv("MOCK_NO_WEBPAGE_URL", "1")
+		req := req
+		req.ID = "nowebpage"
+		req.Url = "HTTPS://Example.COM/Video?id=42#t=10"
+
+		// Act
+		episode, err := suite.platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("https://example.com/Video?id=42", episode.CanonicalURL)
+		suite.Equal(req.Url, episode.OriginalURL)
+	})
+
+	suite.Run("TranscodedSmallerThanSource", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "1000")
+		suite.T().Setenv("MOCK_SOURCE_SIZE", "1000")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "100")
+		req := req
+		req.ID = "transcoded"
+
+		// Act
+		episode, err := suite.platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err, "the size must be checked against the source file")
+		suite.Equal(int64(100), episode.MediaSize)
+	})
+
+	suite.Run("SizeNotReported", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "null")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "10")
+
+		// Act
+		episode, err := suite.platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(int64(10), episode.MediaSize)
+	})
+
+	suite.Run("TruncatedMedia", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "1000000")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "1000")
+		req := req
+		req.ID = "truncated"
+
+		// Act
+		episode, err := suite.platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.Require().Error(err)
+		suite.True(errors.Is(err, errMediaTruncated))
+		suite.NoFileExists(filepath.Join(suite.cfg.PublicDir, "truncated.mp3"))
+	})
+
+	suite.Run("MissingAfterMove", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "1000")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "1000")
+		req := req
+		req.ID = "notmoved"
+		platform := NewYtDlpPlatform(suite.cfg, slog.Default())
+		platform.moveFile = func(src, dst string) error { return nil } // Silently no-op
+
+		// Act
+		episode, err := platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.Require().ErrorIs(err, os.ErrNotExist)
+		suite.Contains(err.Error(), "failed to move media file")
+		suite.NoFileExists(filepath.Join(suite.cfg.PublicDir, "notmoved.mp3"))
+	})
+}
+
+// TestDownload_NormalizeAudio tests the Download method with audio normalization enabled
+func (suite *TestYtDlpSuite) TestDownload_NormalizeAudio() {
+	binDir := suite.T().TempDir()
+	ffmpegPath := filepath.Join(binDir, "ffmpeg")
+	suite.Require().NoError(os.WriteFile(ffmpegPath, []byte(mockFFMpegScript), 0755))
+	argsFile := filepath.Join(binDir, "ffmpeg-args")
+
+	req := entities.Request{
+		ID:              "normalized",
+		Url:             "https://www.youtube.com/watch?v=test",
+		DownloadFormat:  entities.DownloadMp3,
+		DownloadQuality: "192k",
+	}
+
+	suite.Run("Enabled", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "1000")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "1000")
+		suite.T().Setenv("MOCK_NORMALIZED_SIZE", "1200")
+		suite.T().Setenv("MOCK_FFMPEG_ARGS", argsFile)
+		cfg := suite.cfg
+		cfg.FFMpegPath = ffmpegPath
+		cfg.NormalizeAudio = true
+		platform := NewYtDlpPlatform(cfg, slog.Default())
+
+		// Act
+		episode, err := platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(int64(1200), episode.MediaSize, "media size must be re-stat'd after normalization")
+		info, err := os.Stat(filepath.Join(cfg.PublicDir, episode.MediaFile))
+		suite.Require().NoError(err)
+		suite.Equal(int64(1200), info.Size())
+		sum := sha256.Sum256(make([]byte, 1200))
+		suite.Equal(hex.EncodeToString(sum[:]), episode.MediaHash, "checksum must be of the normalized file")
+
+		args, err := os.ReadFile(argsFile)
+		suite.Require().NoError(err)
+		suite.Contains(string(args), "-af "+loudnormFilter)
+		suite.Contains(string(args), "-ar "+loudnormSampleRate)
+		suite.Contains(string(args), "-i normalized.mp3")
+		suite.Contains(string(args), "-b:a 192k")
+	})
+
+	suite.Run("Disabled", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "1000")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "1000")
+		suite.T().Setenv("MOCK_NORMALIZED_SIZE", "1200")
+		suite.T().Setenv("MOCK_FFMPEG_ARGS", argsFile)
+		_ = os.Remove(argsFile)
+		cfg := suite.cfg
+		cfg.FFMpegPath = ffmpegPath
+		platform := NewYtDlpPlatform(cfg, slog.Default())
+
+		// Act
+		episode, err := platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(int64(1000), episode.MediaSize)
+		suite.NoFileExists(argsFile, "ffmpeg must not be invoked")
+	})
+}
+
+// TestDownload_Timeouts tests per-step download timeouts
+func (suite *TestYtDlpSuite) TestDownload_Timeouts() {
+	binDir := suite.T().TempDir()
+	ffmpegPath := filepath.Join(binDir, "ffmpeg")
+	suite.Require().NoError(os.WriteFile(ffmpegPath, []byte(mockFFMpegScript), 0755))
+
+	req := entities.Request{
+		ID:              "timeout",
+		Url:             "https://www.youtube.com/watch?v=test",
+		DownloadFormat:  entities.DownloadMp3,
+		DownloadQuality: "192k",
+	}
+
+	// newPlatform creates a platform with long default timeout and the given step timeouts
+	newPlatform := func(meta, thumb, media time.Duration) *YtDlp {
+		cfg := suite.cfg
+		cfg.FFMpegPath = ffmpegPath
+		cfg.DownloadTimeout = time.Minute
+		cfg.MetaTimeout = meta
+		cfg.ThumbnailTimeout = thumb
+		cfg.MediaTimeout = media
+		return NewYtDlpPlatform(cfg, slog.Default())
+	}
+
+	// arrange sets the mock environment with a thumbnail, so that all three steps are run
+	arrange := func() {
+		suite.T().Setenv("MOCK_FILESIZE", "100")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "100")
+		suite.T().Setenv("MOCK_NORMALIZED_SIZE", "100")
+		suite.T().Setenv("MOCK_FFMPEG_ARGS", filepath.Join(binDir, "ffmpeg-args"))
+		suite.T().Setenv("MOCK_THUMBNAIL", "https://example.com/thumb.jpg")
+	}
+
+	suite.Run("MetaTimeout", func() {
+		// Arrange
+		arrange()
+		suite.T().Setenv("MOCK_SLEEP_META", "5")
+		platform := newPlatform(100*time.Millisecond, 0, 0)
+
+		// Act
+		episode, err := platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, context.DeadlineExceeded)
+		suite.Contains(err.Error(), "metadata download timed out")
+	})
+
+	suite.Run("ThumbnailTimeout", func() {
+		// Arrange
+		arrange()
+		suite.T().Setenv("MOCK_SLEEP_FFMPEG", "5")
+		platform := newPlatform(0, 100*time.Millisecond, 0)
+
+		// Act
+		episode, err := platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, context.DeadlineExceeded)
+		suite.Contains(err.Error(), "thumbnail download timed out")
+	})
+
+	suite.Run("MediaTimeout", func() {
+		// Arrange
+		arrange()
+		suite.T().Setenv("MOCK_SLEEP_MEDIA", "5")
+		platform := newPlatform(0, 0, 100*time.Millisecond)
+
+		// Act
+		episode, err := platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, context.DeadlineExceeded)
+		suite.Contains(err.Error(), "media download timed out")
+	})
+
+	suite.Run("DefaultTimeout", func() {
+		// Arrange
+		arrange()
+		suite.T().Setenv("MOCK_SLEEP_MEDIA", "5")
+		platform := newPlatform(0, 0, 0)
+		platform.cfg.DownloadTimeout = 100 * time.Millisecond
+
+		// Act
+		_, err := platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.ErrorIs(err, context.DeadlineExceeded)
+		suite.Contains(err.Error(), "media download timed out")
+	})
+
+	suite.Run("NormalizationTimeout", func() {
+		// Arrange
+		arrange()
+		suite.T().Setenv("MOCK_THUMBNAIL", "")
+		suite.T().Setenv("MOCK_SLEEP_FFMPEG", "5")
+		platform := newPlatform(0, 0, 0)
+		platform.cfg.PostProcessTimeout = 100 * time.Millisecond
+		platform.cfg.NormalizeAudio = true
+
+		// Act
+		episode, err := platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, context.DeadlineExceeded)
+		suite.Contains(err.Error(), "audio normalization timed out")
+		suite.NoFileExists(filepath.Join(suite.cfg.PublicDir, "timeout.mp3"))
+	})
+
+	suite.Run("NormalizationDefaultTimeout", func() {
+		// Arrange
+		arrange()
+		suite.T().Setenv("MOCK_THUMBNAIL", "")
+		suite.T().Setenv("MOCK_SLEEP_FFMPEG", "5")
+		platform := newPlatform(0, 0, 0)
+		platform.cfg.DownloadTimeout = 100 * time.Millisecond
+		platform.cfg.NormalizeAudio = true
+
+		// Act
+		episode, err := platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, context.DeadlineExceeded)
+		suite.Contains(err.Error(), "audio normalization timed out")
+		suite.NoFileExists(filepath.Join(suite.cfg.PublicDir, "timeout.mp3"))
+	})
+
+	suite.Run("OtherFailureIsNotTimeout", func() {
+		// Arrange
+		arrange()
+		suite.T().Setenv("MOCK_FILESIZE", "not-a-number")
+		platform := newPlatform(0, 0, 0)
+
+		// Act
+		_, err := platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Error(err)
+		suite.NotErrorIs(err, context.DeadlineExceeded)
+	})
+}
+
+// TestDownload_MaxMediaSize tests the maximum media size guard
+func (suite *TestYtDlpSuite) TestDownload_MaxMediaSize() {
+	req := entities.Request{
+		ID:              "large",
+		Url:             "https://www.youtube.com/watch?v=test",
+		DownloadFormat:  entities.DownloadMp3,
+		DownloadQuality: "192k",
+	}
+
+	// newPlatform creates a platform limiting the media size to 1000 bytes
+	newPlatform := func() *YtDlp {
+		cfg := suite.cfg
+		cfg.MaxMediaSize = 1000
+		return NewYtDlpPlatform(cfg, slog.Default())
+	}
+
+	suite.Run("EstimatedTooLarge", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "5000")
+		suite.T().Setenv("MOCK_SLEEP_MEDIA", "5") // Media step must not run
+
+		// Act
+		start := time.Now()
+		episode, err := newPlatform().Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, services.ErrMediaTooLarge)
+		suite.ErrorIs(err, services.ErrPermanent)
+		suite.Less(time.Since(start), 2*time.Second, "download must stop before the media step")
+		suite.NoFileExists(filepath.Join(suite.cfg.PublicDir, "large.mp3"))
+	})
+
+	suite.Run("DownloadedTooLarge", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "null")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "1001")
+
+		// Act
+		episode, err := newPlatform().Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, services.ErrMediaTooLarge)
+		suite.NoFileExists(filepath.Join(suite.cfg.PublicDir, "large.mp3"))
+		entries, err := os.ReadDir(suite.cfg.DownloadDir)
+		suite.Require().NoError(err)
+		suite.Empty(entries, "downloaded media must be deleted")
+	})
+
+	suite.Run("WithinLimit", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "1000")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "1000")
+
+		// Act
+		episode, err := newPlatform().Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(int64(1000), episode.MediaSize)
+		suite.FileExists(filepath.Join(suite.cfg.PublicDir, "large.mp3"))
+	})
+
+	suite.Run("NotLimited", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "5000")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "5000")
+
+		// Act
+		episode, err := suite.platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(int64(5000), episode.MediaSize)
+	})
+}
+
+// TestDownload_MinDuration tests the minimum media duration guard
+func (suite *TestYtDlpSuite) TestDownload_MinDuration() {
+	req := entities.Request{
+		ID:              "short",
+		Url:             "https://www.youtube.com/watch?v=test",
+		DownloadFormat:  entities.DownloadMp3,
+		DownloadQuality: "192k",
+	}
+
+	// newPlatform creates a platform requiring the media to be at least 30 seconds long
+	newPlatform := func() *YtDlp {
+		cfg := suite.cfg
+		cfg.MinDuration = 30 * time.Second
+		return NewYtDlpPlatform(cfg, slog.Default())
+	}
+
+	suite.Run("TooShort", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "1000")
+		suite.T().Setenv("MOCK_DURATION", "2")
+		suite.T().Setenv("MOCK_SLEEP_MEDIA", "5") // Media step must not run
+
+		// Act
+		start := time.Now()
+		episode, err := newPlatform().Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, services.ErrMediaTooShort)
+		suite.ErrorIs(err, services.ErrPermanent)
+		suite.Less(time.Since(start), 2*time.Second, "download must stop before the media step")
+		suite.NoFileExists(filepath.Join(suite.cfg.PublicDir, "short.mp3"))
+	})
+
+	suite.Run("LongEnough", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "1000")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "1000")
+		suite.T().Setenv("MOCK_DURATION", "30")
+
+		// Act
+		episode, err := newPlatform().Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(int64(30), episode.MediaDuration)
+	})
+
+	suite.Run("UnknownDuration", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "1000")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "1000")
+		suite.T().Setenv("MOCK_DURATION", "0")
+
+		// Act
+		episode, err := newPlatform().Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.NotNil(episode)
+	})
+
+	suite.Run("NotLimited", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "1000")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "1000")
+		suite.T().Setenv("MOCK_DURATION", "2")
+
+		// Act
+		episode, err := suite.platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(int64(2), episode.MediaDuration)
+	})
+}
+
+// TestDownload_CommandArgs tests that the configured yt-dlp arguments are forwarded
+func (suite *TestYtDlpSuite) TestDownload_CommandArgs() {
+	req := entities.Request{
+		ID:              "args",
+		Url:             "https://www.youtube.com/watch?v=test",
+		DownloadFormat:  entities.DownloadMp3,
+		DownloadQuality: "192k",
+	}
+
+	suite.Run("Configured", func() {
+		// Arrange
+		argsFile := filepath.Join(suite.T().TempDir(), "args")
+		suite.T().Setenv("MOCK_YTDLP_ARGS", argsFile)
+		suite.T().Setenv("MOCK_FILESIZE", "1000")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "1000")
+		cfg := suite.cfg
+		cfg.YtDlpUserAgent = "Mozilla/5.0 (Test)"
+		cfg.YtDlpExtractorArgs = []string{"youtube:player_client=web,android", "", "vimeo:original_format_policy=never"}
+		cfg.YtDlpExtraArgs = []string{"--sleep-requests", "1", "", "--force-ipv4"}
+
+		// Act
+		_, err := NewYtDlpPlatform(cfg, slog.Default()).Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err)
+		data, err := os.ReadFile(argsFile)
+		suite.Require().NoError(err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		suite.Require().Len(lines, 2, "metadata and media commands")
+		for _, line := range lines {
+			suite.Contains(line, "--user-agent Mozilla/5.0 (Test)")
+			suite.Contains(line, "--extractor-args youtube:player_client=web,android --extractor-args vimeo:original_format_policy=never")
+			suite.Contains(line, "--sleep-requests 1 --force-ipv4 https://www.youtube.com/watch?v=test")
+			suite.Contains(line, "-f bestaudio/best", "metadata must report the size of the downloaded format")
+		}
+	})
+
+	suite.Run("NotConfigured", func() {
+		// Arrange
+		argsFile := filepath.Join(suite.T().TempDir(), "args")
+		suite.T().Setenv("MOCK_YTDLP_ARGS", argsFile)
+		suite.T().Setenv("MOCK_FILESIZE", "1000")
+		suite.T().Setenv("MOCK_ACTUAL_SIZE", "1000")
+
+		// Act
+		_, err := suite.platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Require().NoError(err)
+		data, err := os.ReadFile(argsFile)
+		suite.Require().NoError(err)
+		suite.NotContains(string(data), "--user-agent")
+		suite.NotContains(string(data), "--extractor-args")
+	})
+}
+
+// TestValidateArgs tests validation of the configured yt-dlp arguments
+func (suite *TestYtDlpSuite) TestValidateArgs() {
+	tests := []struct {
+		name      string
+		userAgent string
+		extractor []string
+		extra     []string
+		wantErr   bool
+	}{
+		{"Empty", "", nil, nil, false},
+		{"Valid", "Mozilla/5.0 (Test)", []string{"youtube:player_client=web"}, []string{"--sleep-requests", "1"}, false},
+		{"UserAgentNewline", "Mozilla\r\nX-Injected: 1", nil, nil, true},
+		{"ExtractorWithoutKey", "", []string{"player_client=web"}, nil, true},
+		{"ExtractorEmptyKey", "", []string{":player_client=web"}, nil, true},
+		{"ExtractorControl", "", []string{"youtube:a\x00b"}, nil, true},
+		{"ExtraControl", "", nil, []string{"--cookies\n/etc/passwd"}, true},
+		{"ExtraExec", "", nil, []string{"--exec", "rm -rf /"}, true},
+		{"ExtraExecAssigned", "", nil, []string{"--exec=rm -rf /"}, true},
+		{"ExtraOutput", "", nil, []string{"-o", "/tmp/out"}, true},
+		{"ExtraOutputAttached", "", nil, []string{"-o/tmp/out"}, true},
+		{"ExtraOutputAssigned", "", nil, []string{"--output=/tmp/out"}, true},
+		{"ExtraPathsAttached", "", nil, []string{"-P/tmp"}, true},
+		{"ExtraPathsAssigned", "", nil, []string{"--paths=/tmp"}, true},
+		{"ExtraBatchFileAttached", "", nil, []string{"-a/tmp/urls.txt"}, true},
+		{"ExtraBatchFileAssigned", "", nil, []string{"--batch-file=/tmp/urls.txt"}, true},
+		{"ExtraConfigLocation", "", nil, []string{"--config-location", "/tmp/yt-dlp.conf"}, true},
+		{"ExtraConfigLocationAssigned", "", nil, []string{"--config-location=/tmp/yt-dlp.conf"}, true},
+		{"ExtraConfigLocations", "", nil, []string{"--config-locations", "/tmp/yt-dlp.conf"}, true},
+		{"ExtraShortAttached", "", nil, []string{"-N4"}, false},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Arrange
+			cfg := suite.cfg
+			cfg.YtDlpUserAgent = tt.userAgent
+			cfg.YtDlpExtractorArgs = tt.extractor
+			cfg.YtDlpExtraArgs = tt.extra
+
+			// Act
+			err := NewYtDlpPlatform(cfg, slog.Default()).validateArgs()
+
+			// Assert
+			if tt.wantErr {
+				suite.Error(err)
+			} else {
+				suite.NoError(err)
+			}
+		})
+	}
+}
+
+// TestRemoveStaleDirs tests that only stale temporary directories are removed from the download directory
+func (suite *TestYtDlpSuite) TestRemoveStaleDirs() {
+	// Arrange
+	now := time.Now()
+	mkdir := func(name string, modified time.Time) string {
+		dir := filepath.Join(suite.cfg.DownloadDir, name)
+		suite.Require().NoError(os.Mkdir(dir, 0755))
+		suite.Require().NoError(os.WriteFile(filepath.Join(dir, "media.part"), []byte("partial"), 0644))
+		suite.Require().NoError(os.Chtimes(dir, modified, modified))
+		return dir
+	}
+	stale1 := mkdir("yt-dlp-111", now.Add(-2*time.Hour))
+	stale2 := mkdir("yt-dlp-222", now.Add(-25*time.Hour))
+	fresh := mkdir("yt-dlp-333", now.Add(-time.Minute))
+	other := mkdir("other-444", now.Add(-25*time.Hour))
+
+	// Act
+	err := suite.platform.removeStaleDirs(now.Add(-time.Hour))
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.NoDirExists(stale1)
+	suite.NoDirExists(stale2)
+	suite.DirExists(fresh)
+	suite.DirExists(other)
+}
+
+// TestDownload_ErrorClass tests that failed downloads are classified as retryable or permanent
+func (suite *TestYtDlpSuite) TestDownload_ErrorClass() {
+	req := entities.Request{
+		ID:              "test123",
+		Url:             "https://www.youtube.com/watch?v=test",
+		DownloadFormat:  entities.DownloadMp3,
+		DownloadQuality: "192k",
+	}
+
+	suite.Run("Retryable", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_META_STDERR", "ERROR: [youtube] test: Unable to download API page: HTTP Error 503: Service Unavailable")
+
+		// Act
+		episode, err := suite.platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, services.ErrRetryable)
+		suite.NotErrorIs(err, services.ErrPermanent)
+	})
+
+	suite.Run("Permanent", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_META_STDERR", "ERROR: [youtube] test: Private video. Sign in if you've been granted access to this video")
+
+		// Act
+		episode, err := suite.platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, services.ErrPermanent)
+		suite.NotErrorIs(err, services.ErrRetryable)
+	})
+
+	suite.Run("NoSpace", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_FILESIZE", "1000")
+		suite.T().Setenv("MOCK_MEDIA_STDERR", "ERROR: unable to write data: [Errno 28] No space left on device")
+
+		// Act
+		episode, err := suite.platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, ErrNoSpace)
+		suite.ErrorIs(err, syscall.ENOSPC)
+		suite.NotErrorIs(err, services.ErrRetryable)
+	})
+}
+
+// TestDownload_Playlist tests the detection of playlist URLs
+func (suite *TestYtDlpSuite) TestDownload_Playlist() {
+	req := entities.Request{
+		ID:              "test123",
+		Url:             "https://www.youtube.com/playlist?list=PLtest",
+		DownloadFormat:  entities.DownloadMp3,
+		DownloadQuality: "192k",
+	}
+	videoReq := req
+	videoReq.Url = "https://www.youtube.com/watch?v=test"
+	playlistJSON := `{"_type":"playlist","id":"PLtest","entries":[` +
+		`{"_type":"url","url":"https://www.youtube.com/watch?v=one","id":"one"},` +
+		`{"_type":"url","url":"https://www.youtube.com/watch?v=two","id":"two"},` +
+		`{"_type":"url","url":"","id":"deleted"}]}`
+
+	suite.Run("PlaylistURLRejected", func() {
+		// Arrange
+		argsFile := filepath.Join(suite.T().TempDir(), "args")
+		suite.T().Setenv("MOCK_YTDLP_ARGS", argsFile)
+
+		// Act
+		episode, err := suite.platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, services.ErrPlaylist)
+		suite.ErrorIs(err, services.ErrPermanent)
+		var playlist *services.PlaylistError
+		suite.False(errors.As(err, &playlist))
+		suite.NoFileExists(argsFile, "yt-dlp must not be called for a playlist URL")
+	})
+
+	suite.Run("MetaReportsPlaylist", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_META_JSON", `{"_type":"playlist","title":"Test playlist"}`)
+
+		// Act
+		episode, err := suite.platform.Download(suite.ctx, videoReq)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, services.ErrPlaylist)
+	})
+
+	suite.Run("MetaReportsEntries", func() {
+		// Arrange
+		suite.T().Setenv("MOCK_META_JSON", `{"title":"One"}`+"\n"+`{"title":"Two"}`)
+
+		// Act
+		episode, err := suite.platform.Download(suite.ctx, videoReq)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, services.ErrPlaylist)
+		suite.ErrorContains(err, "yt-dlp returned metadata of 2 entries")
+	})
+
+	suite.Run("EntriesListed", func() {
+		// Arrange
+		cfg := suite.cfg
+		cfg.AllowPlaylists = true
+		platform := NewYtDlpPlatform(cfg, slog.Default())
+		suite.T().Setenv("MOCK_PLAYLIST_JSON", playlistJSON)
+
+		// Act
+		episode, err := platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.Nil(episode)
+		suite.ErrorIs(err, services.ErrPlaylist)
+		var playlist *services.PlaylistError
+		suite.Require().ErrorAs(err, &playlist)
+		suite.Equal([]string{"https://www.youtube.com/watch?v=one", "https://www.youtube.com/watch?v=two"}, playlist.Entries)
+	})
+
+	suite.Run("EntriesListedForMetaPlaylist", func() {
+		// Arrange
+		cfg := suite.cfg
+		cfg.AllowPlaylists = true
+		platform := NewYtDlpPlatform(cfg, slog.Default())
+		suite.T().Setenv("MOCK_META_JSON", `{"_type":"playlist","title":"Test playlist"}`)
+		suite.T().Setenv("MOCK_PLAYLIST_JSON", playlistJSON)
+
+		// Act
+		_, err := platform.Download(suite.ctx, videoReq)
+
+		// Assert
+		var playlist *services.PlaylistError
+		suite.Require().ErrorAs(err, &playlist)
+		suite.Len(playlist.Entries, 2)
+	})
+
+	suite.Run("EmptyPlaylist", func() {
+		// Arrange
+		cfg := suite.cfg
+		cfg.AllowPlaylists = true
+		platform := NewYtDlpPlatform(cfg, slog.Default())
+		suite.T().Setenv("MOCK_PLAYLIST_JSON", `{"_type":"playlist","entries":[]}`)
+
+		// Act
+		_, err := platform.Download(suite.ctx, req)
+
+		// Assert
+		suite.ErrorIs(err, services.ErrPermanent)
+		suite.NotErrorIs(err, services.ErrPlaylist)
+	})
+}
+
+// TestParseMeta tests the tolerant parsing of yt-dlp metadata
+func (suite *TestYtDlpSuite) TestParseMeta() {
+	suite.Run("Numbers", func() {
+		tests := []struct {
+			name         string
+			json         string
+			wantDuration float64
+			wantFilesize int64
+		}{
+			{name: "Int", json: `{"duration":120,"filesize":1000}`, wantDuration: 120, wantFilesize: 1000},
+			{name: "Float", json: `{"duration":120.5,"filesize":1000.6}`, wantDuration: 120.5, wantFilesize: 1001},
+			{name: "String", json: `{"duration":"120.5","filesize":"1000"}`, wantDuration: 120.5, wantFilesize: 1000},
+			{name: "Null", json: `{"duration":null,"filesize":null}`},
+			{name: "NotNumber", json: `{"duration":"live","filesize":true}`},
+			{name: "Missing", json: `{}`},
+		}
+		for _, tt := range tests {
+			suite.Run(tt.name, func() {
+				// Act
+				meta, err := suite.platform.parseMeta([]byte(tt.json))
+
+				// Assert
+				suite.Require().NoError(err)
+				suite.Equal(tt.wantDuration, meta.Duration)
+				suite.Equal(tt.wantFilesize, meta.Filesize)
+			})
+		}
+	})
+
+	suite.Run("FilesizeApprox", func() {
+		// Act
+		meta, err := suite.platform.parseMeta([]byte(`{"filesize_approx":2048.4}`))
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(int64(2048), meta.FilesizeApprox)
+	})
+
+	suite.Run("OtherFieldsKept", func() {
+		// Act
+		meta, err := suite.platform.parseMeta([]byte(`{"title":"Test","webpage_url":"https://example.com/v","duration":1.5}`))
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("Test", meta.Title)
+		suite.Equal("https://example.com/v", meta.WebpageURL)
+		suite.Equal(1.5, meta.Duration)
+	})
+
+	suite.Run("MultipleObjects", func() {
+		// Arrange
+		data := `{"title":"One","duration":1.5}` + "\n" + `{"title":"Two"}` + "\n" + `{"title":"Three"}` + "\n"
+
+		// Act
+		meta, err := suite.platform.parseMeta([]byte(data))
+
+		// Assert
+		suite.Nil(meta)
+		suite.ErrorIs(err, services.ErrPlaylist)
+		suite.ErrorContains(err, "yt-dlp returned metadata of 3 entries")
+	})
+
+	suite.Run("PublishedAt", func() {
+		tests := []struct {
+			name string
+			json string
+			want time.Time
+		}{
+			{name: "UploadDate", json: `{"upload_date":"20240131"}`, want: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+			{name: "Timestamp", json: `{"upload_date":"20240131","timestamp":1706700600.5}`, want: time.Date(2024, 1, 31, 11, 30, 0, 0, time.UTC)},
+			{name: "InvalidUploadDate", json: `{"upload_date":"2024-01-31"}`},
+			{name: "NotReported", json: `{}`},
+		}
+		for _, tt := range tests {
+			suite.Run(tt.name, func() {
+				// Act
+				meta, err := suite.platform.parseMeta([]byte(tt.json))
+
+				// Assert
+				suite.Require().NoError(err)
+				suite.Equal(tt.want, meta.publishedAt())
+			})
+		}
+	})
+
+	suite.Run("TrailingWhitespace", func() {
+		// Act
+		meta, err := suite.platform.parseMeta([]byte(`{"title":"One"}` + "\n\n"))
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("One", meta.Title)
+	})
+}
+
+// TestIsPlaylistURL tests the isPlaylistURL helper
+func (suite *TestYtDlpSuite) TestIsPlaylistURL() {
+	suite.True(isPlaylistURL("https://www.youtube.com/playlist?list=PLtest"))
+	suite.True(isPlaylistURL("https://www.youtube.com/watch?list=PLtest&index=2"))
+	suite.False(isPlaylistURL("https://www.youtube.com/watch?v=test&list=PLtest"))
+	suite.False(isPlaylistURL("https://www.youtube.com/watch?v=test"))
+	suite.False(isPlaylistURL("https://youtu.be/test"))
+	suite.False(isPlaylistURL("https://youtu.be/test?list=PLtest"))
+	suite.False(isPlaylistURL("https://www.youtube.com/shorts/test?list=PLtest"))
+	suite.False(isPlaylistURL("https://www.youtube.com/live/test?list=PLtest"))
+	suite.True(isPlaylistURL("https://youtu.be/?list=PLtest"))
+	suite.True(isPlaylistURL("https://www.youtube.com/shorts/?list=PLtest"))
+	suite.False(isPlaylistURL("https://www.youtube.com/playlist?list="))
+	suite.False(isPlaylistURL("not a url"))
+}
+
+// TestNormalizeURL tests the normalizeURL helper
+func (suite *TestYtDlpSuite) TestNormalizeURL() {
+	suite.Equal("https://example.com/Video?id=42", normalizeURL("HTTPS://Example.COM/Video?id=42#t=10"))
+	suite.Equal("https://example.com/path", normalizeURL("https://example.com/path"))
+	suite.Equal("https://example.com/path", normalizeURL("https://example.com/path#"))
+	suite.Equal("://bad", normalizeURL("://bad"), "unparsable URL must be returned as is")
+}
+
+// TestClassifyError tests the classifyError helper
+func (suite *TestYtDlpSuite) TestClassifyError() {
+	cmdErr := errors.New("exit status 1")
+	tests := []struct {
+		name   string
+		output string
+		want   error
+	}{
+		{"HTTP 500", "ERROR: unable to download video data: HTTP Error 500: Internal Server Error", services.ErrRetryable},
+		{"HTTP 503", "ERROR: [youtube] abc: Unable to download webpage: HTTP Error 503: Service Unavailable", services.ErrRetryable},
+		{"HTTP 429", "ERROR: [youtube] abc: HTTP Error 429: Too Many Requests", services.ErrRetryable},
+		{"DNS failure", "ERROR: [youtube] abc: Unable to download webpage: <urlopen error [Errno -3] Temporary failure in name resolution>", services.ErrRetryable},
+		{"Connection reset", "ERROR: [Errno 104] Connection reset by peer", services.ErrRetryable},
+		{"Read timeout", "ERROR: The read operation timed out", services.ErrRetryable},
+		{"Private video", "ERROR: [youtube] abc: Private video. Sign in if you've been granted access to this video", services.ErrPermanent},
+		{"Removed video", "ERROR: [youtube] abc: This video has been removed by the uploader", services.ErrPermanent},
+		{"Unavailable video", "ERROR: [youtube] abc: Video unavailable", services.ErrPermanent},
+		{"Unsupported URL", "ERROR: Unsupported URL: https://example.com/", services.ErrPermanent},
+		{"HTTP 404", "ERROR: [generic] Unable to download webpage: HTTP Error 404: Not Found", services.ErrPermanent},
+		{"HTTP 403", "ERROR: [youtube] abc: Unable to download webpage: HTTP Error 403: Forbidden", services.ErrPermanent},
+		{"Unavailable in country", "ERROR: [vimeo] 123: This video is not available in your country", services.ErrPermanent},
+		{"Format not available", "ERROR: [youtube] abc: Requested format is not available. Use --list-formats for a list of available formats", services.ErrPermanent},
+		{"Format not available with server error", "ERROR: [youtube] abc: Requested format is not available: HTTP Error 503: Service Unavailable", services.ErrRetryable},
+		{"Removed with network error", "ERROR: [youtube] abc: Video unavailable. Connection reset by peer", services.ErrPermanent},
+		{"No space", "ERROR: unable to write data: [Errno 28] No space left on device", ErrNoSpace},
+		{"No space with network error", "ERROR: Unable to download video data: [Errno 28] No space left on device", ErrNoSpace},
+		{"Unknown", "ERROR: something unexpected happened", services.ErrPermanent},
+		{"Empty output", "", services.ErrPermanent},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Act
+			err := classifyError(cmdErr, tt.output)
+
+			// Assert
+			suite.ErrorIs(err, tt.want)
+			suite.ErrorIs(err, cmdErr)
+		})
+	}
+
+	suite.Run("NilError", func() {
+		suite.NoError(classifyError(nil, "HTTP Error 503"))
+	})
+}
+
+// TestMatch tests URL matching against the host patterns
+func (suite *TestYtDlpSuite) TestMatch() {
+	suite.Run("DefaultHosts", func() {
+		platform := NewYtDlpPlatform(suite.cfg, slog.Default())
+		suite.Equal("youtube", platform.ID())
+		suite.True(platform.Match("https://www.youtube.com/watch?v=abc"))
+		suite.True(platform.Match("https://youtube.com/watch?v=abc"))
+		suite.True(platform.Match("https://m.youtube.com/watch?v=abc"))
+		suite.True(platform.Match("https://youtu.be/abc"))
+		suite.True(platform.Match("https://WWW.YouTube.com/watch?v=abc"))
+		suite.False(platform.Match("http://www.youtube.com/watch?v=abc"))
+		suite.False(platform.Match("https://music.youtube.com/watch?v=abc"))
+		suite.False(platform.Match("https://youtube.com.example.com/watch?v=abc"))
+		suite.False(platform.Match("https://soundcloud.com/artist/track"))
+		suite.False(platform.Match("not a url"))
+	})
+
+	suite.Run("WildcardHosts", func() {
+		platform := NewYtDlpPlatform(suite.cfg, slog.Default(), SoundCloudHosts...)
+		suite.True(platform.Match("https://soundcloud.com/artist/track"))
+		suite.True(platform.Match("https://m.soundcloud.com/artist/track"))
+		suite.False(platform.Match("https://notsoundcloud.com/artist/track"))
+		suite.False(platform.Match("https://www.youtube.com/watch?v=abc"))
+	})
+
+	suite.Run("Vimeo", func() {
+		platform := NewVimeoPlatform(suite.cfg, slog.Default())
+		suite.Equal("vimeo", platform.ID())
+		suite.True(platform.Match("https://vimeo.com/123"))
+		suite.True(platform.Match("https://player.vimeo.com/video/123"))
+		suite.False(platform.Match("https://www.youtube.com/watch?v=abc"))
+	})
+}
+
+// TestSupportsFormat tests the SupportsFormat method
+func (suite *TestYtDlpSuite) TestSupportsFormat() {
+	suite.True(suite.platform.SupportsFormat(entities.DownloadMp3))
+	suite.True(suite.platform.SupportsFormat(entities.DownloadM4a))
+	suite.False(suite.platform.SupportsFormat("flac"))
+	suite.False(suite.platform.SupportsFormat(""))
+}
+
+// TestIsAudio tests the isAudio helper
+func (suite *TestYtDlpSuite) TestIsAudio() {
+	suite.True(isAudio(entities.MediaMp3))
+	suite.True(isAudio(entities.MediaM4a))
+	suite.False(isAudio("video/mp4"))
+}
+
+// TestIsBitrate tests the isBitrate helper
+func (suite *TestYtDlpSuite) TestIsBitrate() {
+	suite.True(isBitrate("192k"))
+	suite.True(isBitrate("128K"))
+	suite.False(isBitrate("0"))
+	suite.False(isBitrate("best"))
+	suite.False(isBitrate("k"))
+}
+
+// TestSourceFile tests the sourceFile helper
+func (suite *TestYtDlpSuite) TestSourceFile() {
+	tests := []struct {
+		name     string
+		files    []string
+		expected string
+	}{
+		{name: "SourceKept", files: []string{"test.mp3", "test.webm", "other.webm"}, expected: "test.webm"},
+		{name: "SourceNotKept", files: []string{"test.mp3", "other.webm"}, expected: "test.mp3"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Arrange
+			dir := suite.T().TempDir()
+			for _, name := range tt.files {
+				suite.Require().NoError(os.WriteFile(filepath.Join(dir, name), nil, 0644))
+			}
+
+			// Act
+			source, err := sourceFile(dir, "test.mp3")
+
+			// Assert
+			suite.Require().NoError(err)
+			suite.Equal(tt.expected, source)
+		})
+	}
+}
+
+// TestVerifyMediaSize tests the verifyMediaSize method
+func (suite *TestYtDlpSuite) TestVerifyMediaSize() {
+	tests := []struct {
+		name    string
+		meta    youtubeMeta
+		actual  int64
+		wantErr bool
+	}{
+		{name: "NoSizeReported", meta: youtubeMeta{}, actual: 1},
+		{name: "ExactSize", meta: youtubeMeta{Filesize: 1000}, actual: 1000},
+		{name: "LargerThanReported", meta: youtubeMeta{Filesize: 1000}, actual: 1500},
+		{name: "WithinTolerance", meta: youtubeMeta{Filesize: 1000}, actual: 500},
+		{name: "Truncated", meta: youtubeMeta{Filesize: 1000}, actual: 499, wantErr: true},
+		{name: "ApproxFallback", meta: youtubeMeta{FilesizeApprox: 1000}, actual: 100, wantErr: true},
+		{name: "ExactPreferredOverApprox", meta: youtubeMeta{Filesize: 100, FilesizeApprox: 1000}, actual: 100},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			err := suite.platform.verifyMediaSize(&tt.meta, tt.actual)
+			if tt.wantErr {
+				suite.ErrorIs(err, errMediaTruncated)
+			} else {
+				suite.NoError(err)
+			}
+		})
+	}
+}
+
+func TestYtDlp(t *testing.T) {
+	suite.Run(t, new(TestYtDlpSuite))
+}
@@ -6,10 +6,16 @@ import (
 	"fmt"
 	"log/slog"
 	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/ofstudio/voxify/internal/config"
 	"github.com/ofstudio/voxify/internal/entities"
+	"github.com/ofstudio/voxify/internal/store"
 	"github.com/ofstudio/voxify/pkg/files"
 )
 
@@ -17,15 +23,17 @@ type EpisodeService struct {
 	cfg       *config.Settings
 	log       *slog.Logger
 	store     Store
+	feeder    Feeder
 	platforms []Platform
 }
 
 // NewEpisodeService creates a new EpisodeService instance.
-func NewEpisodeService(cfg *config.Settings, log *slog.Logger, s Store, p ...Platform) *EpisodeService {
+func NewEpisodeService(cfg *config.Settings, log *slog.Logger, s Store, f Feeder, p ...Platform) *EpisodeService {
 	return &EpisodeService{
 		cfg:       cfg,
 		log:       log,
 		store:     s,
+		feeder:    f,
 		platforms: p,
 	}
 }
@@ -50,16 +58,30 @@ func (s *EpisodeService) Init(ctx context.Context) error {
 		return fmt.Errorf("download directory check failed: %w", err)
 	}
 
-	// cleanup download directory on startup
-	if err := files.CleanDir(s.cfg.DownloadDir); err != nil {
-		return fmt.Errorf("failed to clean download directory: %w", err)
+	// cleanup download directory on startup,
+	// unless platforms remove only their stale temporary files
+	if s.cfg.CleanupGracePeriod == 0 {
+		if err := files.CleanDir(s.cfg.DownloadDir); err != nil {
+			return fmt.Errorf("failed to clean download directory: %w", err)
+		}
+	}
+
+	// purge orphaned files from public directory on startup
+	if s.cfg.PurgeOrphans {
+		if err := s.purgeOrphans(ctx); err != nil {
+			return fmt.Errorf("failed to purge orphaned files: %w", err)
+		}
 	}
 
 	return nil
 }
 
-// Download downloads an episode from the given URL using the appropriate platform.
-func (s *EpisodeService) Download(ctx context.Context, req entities.Request) (*entities.Episode, error) {
+// Download downloads an episode from the given URL using the appropriate platform
+// and creates it in the store. If save is not nil, it is called within the same
+// store transaction right after the episode is created. If the episode cannot be stored,
+// the transaction is rolled back and the downloaded files are removed.
+func (s *EpisodeService) Download(ctx context.Context, req entities.Request,
+	save func(ctx context.Context, tx Store, episode *entities.Episode) error) (*entities.Episode, error) {
 	if req.DownloadFormat == "" {
 		req.DownloadFormat = s.cfg.DownloadFormat
 	}
@@ -74,20 +96,45 @@ func (s *EpisodeService) Download(ctx context.Context, req entities.Request) (*e
 	if platform == nil {
 		return nil, ErrNoMatchingPlatform
 	}
+	if !platform.SupportsFormat(req.DownloadFormat) {
+		return nil, fmt.Errorf("%w: %s is not available on %s",
+			ErrUnsupportedFormatForPlatform, req.DownloadFormat, platform.ID())
+	}
 
 	s.log.Info("[episode service] downloading episode",
 		"platform", platform.ID(), "request", req.LogValue())
-	ctxTimeout, cancel := context.WithTimeout(ctx, s.cfg.DownloadTimeout)
-	defer cancel()
-
-	episode, err := platform.Download(ctxTimeout, req)
+	// Platform applies per-step timeouts itself
+	episode, err := platform.Download(ctx, req)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("%w: %w", ErrDownloadTimeout, err)
+	}
+	if errors.Is(err, ErrMediaTooShort) {
+		return nil, fmt.Errorf("%w: %w", ErrTooShort, err)
+	}
+	if errors.Is(err, ErrPlaylist) {
+		return nil, fmt.Errorf("%w: %w", ErrPlaylistNotSupported, err)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrDownloadFailed, err)
+		return nil, ioError(ErrDownloadFailed, err)
 	}
 
+	// Apply the user overrides of detected metadata
+	if req.TitleOverride != "" {
+		episode.Title = req.TitleOverride
+	}
+	episode.EpisodeNumber = req.EpisodeNumber
+	episode.SeasonNumber = req.SeasonNumber
+
+	// Warn about the same media published before, e.g. a re-upload under another URL
+	s.warnDuplicateMedia(ctx, episode)
+
 	// Save episode to store
-	if err = s.store.EpisodeCreate(ctx, episode); err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrEpisodeCreate, err)
+	if err = s.create(ctx, episode, save); err != nil {
+		if rmErr := s.removeFiles(episode); rmErr != nil {
+			s.log.Error("[episode service] failed to remove files of unsaved episode",
+				"error", rmErr, "request", req.LogValue(), "episode", episode.LogValue())
+		}
+		return nil, err
 	}
 
 	s.log.Info("[episode service] episode downloaded",
@@ -95,6 +142,150 @@ func (s *EpisodeService) Download(ctx context.Context, req entities.Request) (*e
 	return episode, nil
 }
 
+// Delete deletes the episode with the given ID: removes it from the store,
+// deletes its media and thumbnail files from the public directory
+// and rebuilds the podcast feed so that it no longer references the episode.
+// Once the episode is removed from the store, the feed is rebuilt even if the files
+// cannot be removed. Deleting the last episode removes the feed file.
+func (s *EpisodeService) Delete(ctx context.Context, id int64) error {
+	episode, err := s.store.EpisodeGetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrEpisodeNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEpisodeGetByID, err)
+	}
+
+	// Delete episode from store
+	if err = s.store.EpisodeDelete(ctx, id); err != nil {
+		return fmt.Errorf("%w: %w", ErrEpisodeDelete, err)
+	}
+	s.log.Info("[episode service] episode deleted", "episode", episode.LogValue())
+
+	// Remove episode files from public directory
+	if err = s.removeFiles(episode); err != nil {
+		s.log.Error("[episode service] failed to remove files of deleted episode",
+			"error", err, "episode", episode.LogValue())
+	}
+
+	// Rebuild podcast feed, the feed file is removed if no episodes are left
+	if err = s.feeder.Build(ctx); err != nil && !errors.Is(err, ErrEmptyFeed) {
+		s.log.Error("[episode service] failed to rebuild feed after episode deletion",
+			"error", err, "episode", episode.LogValue())
+		return fmt.Errorf("episode deleted but feed rebuild failed: %w", err)
+	}
+
+	return nil
+}
+
+// create creates the episode in the store and calls save within a single transaction.
+// On failure the transaction is rolled back.
+func (s *EpisodeService) create(ctx context.Context, episode *entities.Episode,
+	save func(ctx context.Context, tx Store, episode *entities.Episode) error) (err error) {
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrStoreBegin, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = tx.EpisodeCreate(ctx, episode); err != nil {
+		return fmt.Errorf("%w: %w", ErrEpisodeCreate, err)
+	}
+	if save != nil {
+		if err = save(ctx, tx, episode); err != nil {
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%w: %w", ErrStoreCommit, err)
+	}
+	return nil
+}
+
+// removeFiles removes the media and thumbnail files of the episode from the public directory.
+// Missing files are ignored.
+func (s *EpisodeService) removeFiles(episode *entities.Episode) error {
+	for _, name := range []string{episode.MediaFile, episode.ThumbnailFile} {
+		if name == "" {
+			continue
+		}
+		err := os.Remove(filepath.Join(s.cfg.PublicDir, name))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: %w", ErrFileRemove, err)
+		}
+	}
+	return nil
+}
+
+// orphanExts are the extensions of the media and thumbnail files the downloads produce.
+// Only such files are removed as orphans.
+var orphanExts = []string{"." + string(entities.DownloadMp3), "." + string(entities.DownloadM4a), ".jpg"}
+
+// purgeOrphans removes the media and thumbnail files of the public directory (see orphanExts)
+// that are referenced neither by the episodes nor as the feed file and were last modified
+// more than cfg.PurgeGracePeriod ago. Other files, subdirectories and hidden files are kept.
+func (s *EpisodeService) purgeOrphans(ctx context.Context) error {
+	episodes, err := s.store.EpisodeListAll(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEpisodeListAll, err)
+	}
+	referenced := map[string]bool{s.cfg.FeedFileName: true}
+	for _, episode := range episodes {
+		referenced[episode.MediaFile] = true
+		referenced[episode.ThumbnailFile] = true
+	}
+
+	entries, err := os.ReadDir(s.cfg.PublicDir)
+	if err != nil {
+		return fmt.Errorf("failed to read public directory: %w", err)
+	}
+	threshold := time.Now().Add(-s.cfg.PurgeGracePeriod)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || referenced[name] ||
+			!slices.Contains(orphanExts, filepath.Ext(name)) {
+			continue
+		}
+		info, err := entry.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to stat '%s': %w", name, err)
+		}
+		if info.ModTime().After(threshold) {
+			continue
+		}
+		if err = os.Remove(filepath.Join(s.cfg.PublicDir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: %w", ErrFileRemove, err)
+		}
+		s.log.Info("[episode service] orphaned file removed", "file", name, "modified", info.ModTime())
+	}
+
+	return nil
+}
+
+// warnDuplicateMedia logs a warning if episodes with the same media checksum already exist.
+// The episode is still created, as the duplicate may be intended.
+func (s *EpisodeService) warnDuplicateMedia(ctx context.Context, episode *entities.Episode) {
+	if episode.MediaHash == "" {
+		return
+	}
+	duplicates, err := s.store.EpisodeGetByHash(ctx, episode.MediaHash)
+	if err != nil {
+		s.log.Error("[episode service] failed to check duplicate media", "error", err, "episode", episode.LogValue())
+		return
+	}
+	for _, duplicate := range duplicates {
+		s.log.Warn("[episode service] episode media matches existing episode",
+			"episode", episode.LogValue(), "duplicate", duplicate.LogValue())
+	}
+}
+
 func (s *EpisodeService) findPlatform(url string) Platform {
 	for _, p := range s.platforms {
 		if p.Match(url) {
@@ -115,6 +306,12 @@ func (s *EpisodeService) validateRequest(req *entities.Request) error {
 	if err := s.validateDownloadQuality(req.DownloadQuality); err != nil {
 		return fmt.Errorf("download quality validation failed: %w", err)
 	}
+	if req.EpisodeNumber < 0 {
+		return fmt.Errorf("episode number must not be negative: %d", req.EpisodeNumber)
+	}
+	if req.SeasonNumber < 0 {
+		return fmt.Errorf("season number must not be negative: %d", req.SeasonNumber)
+	}
 	return nil
 }
 
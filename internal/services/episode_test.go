@@ -3,8 +3,11 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"syscall"
 	"testing"
 	"time"
 
@@ -14,6 +17,7 @@ import (
 	"github.com/ofstudio/voxify/internal/config"
 	"github.com/ofstudio/voxify/internal/entities"
 	"github.com/ofstudio/voxify/internal/mocks"
+	"github.com/ofstudio/voxify/internal/store"
 )
 
 // TestEpisodeServiceSuite is a test suite for EpisodeService
@@ -67,20 +71,22 @@ func (suite *TestEpisodeServiceSuite) TearDownSuite() {
 func (suite *TestEpisodeServiceSuite) SetupSubTest() {
 	suite.mockStore = mocks.NewMockStore(suite.T())
 	suite.mockPlatform = mocks.NewMockPlatform(suite.T())
+	suite.mockPlatform.EXPECT().SupportsFormat(mock.Anything).Return(true).Maybe()
 	suite.mockFeeder = mocks.NewMockFeeder(suite.T())
 
-	suite.service = NewEpisodeService(suite.cfg, suite.log, suite.mockStore, suite.mockPlatform)
+	suite.service = NewEpisodeService(suite.cfg, suite.log, suite.mockStore, suite.mockFeeder, suite.mockPlatform)
 }
 
 // TestNewEpisodeService tests the constructor
 func (suite *TestEpisodeServiceSuite) TestNewEpisodeService() {
 	// Act
-	service := NewEpisodeService(suite.cfg, suite.log, suite.mockStore, suite.mockPlatform)
+	service := NewEpisodeService(suite.cfg, suite.log, suite.mockStore, suite.mockFeeder, suite.mockPlatform)
 
 	// Assert
 	suite.NotNil(service)
 	suite.Equal(suite.cfg, service.cfg)
 	suite.Equal(suite.mockStore, service.store)
+	suite.Equal(suite.mockFeeder, service.feeder)
 	suite.Len(service.platforms, 1)
 	suite.Equal(suite.mockPlatform, service.platforms[0])
 	suite.NotNil(service.log)
@@ -144,6 +150,118 @@ func (suite *TestEpisodeServiceSuite) TestInit() {
 		suite.Error(err)
 		suite.Contains(err.Error(), "download directory check failed")
 	})
+
+	suite.Run("DownloadDirCleaned", func() {
+		// Arrange
+		leftover := filepath.Join(suite.cfg.DownloadDir, "yt-dlp-123")
+		suite.Require().NoError(os.Mkdir(leftover, 0755))
+		suite.mockPlatform.On("Init", suite.ctx).Return(nil)
+
+		// Act
+		err := suite.service.Init(suite.ctx)
+
+		// Assert
+		suite.NoError(err)
+		suite.NoDirExists(leftover)
+	})
+
+	suite.Run("DownloadDirKeptWithGracePeriod", func() {
+		// Arrange
+		suite.cfg.CleanupGracePeriod = time.Hour
+		defer func() { suite.cfg.CleanupGracePeriod = 0 }()
+		leftover := filepath.Join(suite.cfg.DownloadDir, "yt-dlp-456")
+		suite.Require().NoError(os.Mkdir(leftover, 0755))
+		defer os.RemoveAll(leftover)
+		suite.mockPlatform.On("Init", suite.ctx).Return(nil)
+
+		// Act
+		err := suite.service.Init(suite.ctx)
+
+		// Assert
+		suite.NoError(err)
+		suite.DirExists(leftover, "stale directories are removed by the platform")
+	})
+
+	// createFiles creates the files in the directory modified at the given time
+	createFiles := func(dir string, modTime time.Time, names ...string) {
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			suite.Require().NoError(os.WriteFile(path, []byte("content"), 0644))
+			suite.Require().NoError(os.Chtimes(path, modTime, modTime))
+		}
+	}
+
+	suite.Run("PurgeOrphans", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		cfg.FeedFileName = "rss.xml"
+		cfg.PurgeOrphans = true
+		cfg.PurgeGracePeriod = time.Hour
+		service := NewEpisodeService(&cfg, suite.log, suite.mockStore, suite.mockFeeder, suite.mockPlatform)
+
+		old := time.Now().Add(-2 * time.Hour)
+		createFiles(cfg.PublicDir, old, "rss.xml", "episode.mp3", "episode.jpg", "orphan.mp3", "orphan.m4a", "orphan.jpg", ".hidden",
+			"robots.txt", "index.html", "orphan.MP3")
+		createFiles(cfg.PublicDir, time.Now(), "fresh.mp3")
+		suite.Require().NoError(os.Mkdir(filepath.Join(cfg.PublicDir, "subdir"), 0755))
+
+		suite.mockPlatform.On("Init", suite.ctx).Return(nil)
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return([]*entities.Episode{
+			{ID: 1, MediaFile: "episode.mp3", ThumbnailFile: "episode.jpg"},
+		}, nil)
+
+		// Act
+		err := service.Init(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		entries, err := os.ReadDir(cfg.PublicDir)
+		suite.Require().NoError(err)
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		suite.ElementsMatch([]string{"rss.xml", "episode.mp3", "episode.jpg", "fresh.mp3", ".hidden", "subdir",
+			"robots.txt", "index.html", "orphan.MP3"}, names, "only media and thumbnail files must be removed")
+	})
+
+	suite.Run("PurgeDisabled", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		service := NewEpisodeService(&cfg, suite.log, suite.mockStore, suite.mockFeeder, suite.mockPlatform)
+		createFiles(cfg.PublicDir, time.Now().Add(-48*time.Hour), "orphan.mp3")
+
+		suite.mockPlatform.On("Init", suite.ctx).Return(nil)
+
+		// Act
+		err := service.Init(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.FileExists(filepath.Join(cfg.PublicDir, "orphan.mp3"))
+		suite.mockStore.AssertNotCalled(suite.T(), "EpisodeListAll", mock.Anything)
+	})
+
+	suite.Run("PurgeStoreError", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		cfg.PurgeOrphans = true
+		service := NewEpisodeService(&cfg, suite.log, suite.mockStore, suite.mockFeeder, suite.mockPlatform)
+		createFiles(cfg.PublicDir, time.Now().Add(-48*time.Hour), "orphan.mp3")
+
+		suite.mockPlatform.On("Init", suite.ctx).Return(nil)
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(nil, errors.New("db error"))
+
+		// Act
+		err := service.Init(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrEpisodeListAll)
+		suite.FileExists(filepath.Join(cfg.PublicDir, "orphan.mp3"))
+	})
 }
 
 // TestDownload tests the Download method
@@ -163,7 +281,7 @@ func (suite *TestEpisodeServiceSuite) TestDownload() {
 		// Arrange
 		suite.mockPlatform.On("ID").Return("test-platform")
 		suite.mockPlatform.On("Match", req.Url).Return(true)
-		suite.mockPlatform.On("Download", mock.AnythingOfType("*context.timerCtx"), req).Return(&entities.Episode{
+		suite.mockPlatform.On("Download", suite.ctx, req).Return(&entities.Episode{
 			Title:         "Test Episode",
 			Description:   "Test Description",
 			MediaFile:     "audio_test.mp3",
@@ -173,6 +291,7 @@ func (suite *TestEpisodeServiceSuite) TestDownload() {
 			MediaDuration: 3600,
 			OriginalURL:   req.Url,
 		}, nil)
+		suite.mockStore.On("Begin", suite.ctx).Return(suite.mockStore, nil)
 		suite.mockStore.On("EpisodeCreate", suite.ctx, mock.MatchedBy(func(episode *entities.Episode) bool {
 			return episode.OriginalURL == req.Url && episode.Title == "Test Episode"
 		})).Return(nil).Run(func(args mock.Arguments) {
@@ -180,9 +299,10 @@ func (suite *TestEpisodeServiceSuite) TestDownload() {
 			episode := args.Get(1).(*entities.Episode)
 			episode.ID = 1
 		})
+		suite.mockStore.On("Commit").Return(nil)
 
 		// Act
-		result, err := suite.service.Download(suite.ctx, req)
+		result, err := suite.service.Download(suite.ctx, req, nil)
 
 		// Assert
 		suite.NoError(err)
@@ -198,12 +318,72 @@ func (suite *TestEpisodeServiceSuite) TestDownload() {
 		suite.Equal(int64(1), result.ID)
 	})
 
+	suite.Run("DuplicateMedia", func() {
+		// Arrange
+		memStore := store.NewMemoryStore()
+		service := NewEpisodeService(suite.cfg, suite.log, memStore, suite.mockFeeder, suite.mockPlatform)
+		existing := &entities.Episode{Title: "Original Upload", MediaFile: "original.mp3", MediaHash: "abc123", OriginalURL: "https://youtube.com/watch?v=original"}
+		suite.Require().NoError(memStore.EpisodeCreate(suite.ctx, existing))
+		suite.mockPlatform.On("ID").Return("test-platform")
+		suite.mockPlatform.On("Match", req.Url).Return(true)
+		suite.mockPlatform.On("Download", suite.ctx, req).Return(&entities.Episode{
+			Title:       "Re-upload",
+			MediaFile:   "reupload.mp3",
+			MediaType:   entities.MediaMp3,
+			MediaSize:   1024000,
+			MediaHash:   "abc123",
+			OriginalURL: req.Url,
+		}, nil)
+
+		// Act
+		result, err := service.Download(suite.ctx, req, nil)
+
+		// Assert
+		suite.Require().NoError(err)
+		duplicates, err := memStore.EpisodeGetByHash(suite.ctx, "abc123")
+		suite.Require().NoError(err)
+		suite.Len(duplicates, 2, "duplicate media is still created")
+		suite.NotEqual(existing.ID, result.ID)
+	})
+
+	suite.Run("Overrides", func() {
+		// Arrange
+		req := req
+		req.TitleOverride = "Custom Title"
+		req.EpisodeNumber = 12
+		req.SeasonNumber = 2
+		memStore := store.NewMemoryStore()
+		service := NewEpisodeService(suite.cfg, suite.log, memStore, suite.mockFeeder, suite.mockPlatform)
+		suite.mockPlatform.On("ID").Return("test-platform")
+		suite.mockPlatform.On("Match", req.Url).Return(true)
+		suite.mockPlatform.On("Download", suite.ctx, req).Return(&entities.Episode{
+			Title:       "Detected Title",
+			MediaFile:   "audio_override.mp3",
+			MediaType:   entities.MediaMp3,
+			OriginalURL: req.Url,
+		}, nil)
+
+		// Act
+		result, err := service.Download(suite.ctx, req, nil)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("Custom Title", result.Title)
+		suite.Equal(12, result.EpisodeNumber)
+		suite.Equal(2, result.SeasonNumber)
+		stored, err := memStore.EpisodeGetByID(suite.ctx, result.ID)
+		suite.Require().NoError(err)
+		suite.Equal("Custom Title", stored.Title)
+		suite.Equal(12, stored.EpisodeNumber)
+		suite.Equal(2, stored.SeasonNumber)
+	})
+
 	suite.Run("NoMatchingPlatform", func() {
 		// Arrange
 		suite.mockPlatform.On("Match", req.Url).Return(false)
 
 		// Act
-		result, err := suite.service.Download(suite.ctx, req)
+		result, err := suite.service.Download(suite.ctx, req, nil)
 
 		// Assert
 		suite.Error(err)
@@ -216,11 +396,11 @@ func (suite *TestEpisodeServiceSuite) TestDownload() {
 		platformErr := errors.New("platform download error")
 		suite.mockPlatform.On("ID").Return("test-platform")
 		suite.mockPlatform.On("Match", req.Url).Return(true)
-		suite.mockPlatform.On("Download", mock.AnythingOfType("*context.timerCtx"), req).
+		suite.mockPlatform.On("Download", suite.ctx, req).
 			Return(nil, platformErr)
 
 		// Act
-		result, err := suite.service.Download(suite.ctx, req)
+		result, err := suite.service.Download(suite.ctx, req, nil)
 
 		// Assert
 		suite.Error(err)
@@ -229,22 +409,95 @@ func (suite *TestEpisodeServiceSuite) TestDownload() {
 		suite.Contains(err.Error(), platformErr.Error())
 	})
 
+	suite.Run("TooShort", func() {
+		// Arrange
+		suite.mockPlatform.On("ID").Return("test-platform")
+		suite.mockPlatform.On("Match", req.Url).Return(true)
+		suite.mockPlatform.On("Download", suite.ctx, req).
+			Return(nil, fmt.Errorf("media duration check failed: %w: 2s, minimum is 30s", ErrMediaTooShort))
+
+		// Act
+		result, err := suite.service.Download(suite.ctx, req, nil)
+
+		// Assert
+		suite.Nil(result)
+		suite.ErrorIs(err, ErrTooShort)
+		suite.NotErrorIs(err, ErrDownloadFailed)
+	})
+
+	suite.Run("Playlist", func() {
+		// Arrange
+		playlistErr := &PlaylistError{Entries: []string{"https://example.com/one"}}
+		suite.mockPlatform.On("ID").Return("test-platform")
+		suite.mockPlatform.On("Match", req.Url).Return(true)
+		suite.mockPlatform.On("Download", suite.ctx, req).Return(nil, playlistErr)
+
+		// Act
+		result, err := suite.service.Download(suite.ctx, req, nil)
+
+		// Assert
+		suite.Nil(result)
+		suite.ErrorIs(err, ErrPlaylistNotSupported)
+		suite.NotErrorIs(err, ErrDownloadFailed)
+		var playlist *PlaylistError
+		suite.Require().ErrorAs(err, &playlist, "playlist entries must be kept for the process service")
+		suite.Equal(playlistErr.Entries, playlist.Entries)
+	})
+
+	suite.Run("UnsupportedFormatForPlatform", func() {
+		// Arrange
+		platform := mocks.NewMockPlatform(suite.T())
+		platform.EXPECT().ID().Return("test-platform")
+		platform.EXPECT().Match(req.Url).Return(true)
+		platform.EXPECT().SupportsFormat(req.DownloadFormat).Return(false)
+		service := NewEpisodeService(suite.cfg, suite.log, suite.mockStore, suite.mockFeeder, platform)
+
+		// Act
+		result, err := service.Download(suite.ctx, req, nil)
+
+		// Assert
+		suite.Nil(result)
+		suite.ErrorIs(err, ErrUnsupportedFormatForPlatform)
+		suite.NotErrorIs(err, ErrInvalidRequest)
+		platform.AssertNotCalled(suite.T(), "Download", mock.Anything, mock.Anything)
+	})
+
+	suite.Run("DiskFull", func() {
+		// Arrange
+		moveErr := &os.PathError{Op: "write", Path: "/public/test.mp3", Err: syscall.ENOSPC}
+		suite.mockPlatform.On("ID").Return("test-platform")
+		suite.mockPlatform.On("Match", req.Url).Return(true)
+		suite.mockPlatform.On("Download", suite.ctx, req).
+			Return(nil, fmt.Errorf("failed to move media file: %w", moveErr))
+
+		// Act
+		result, err := suite.service.Download(suite.ctx, req, nil)
+
+		// Assert
+		suite.Nil(result)
+		suite.ErrorIs(err, ErrDiskFull)
+		suite.ErrorIs(err, syscall.ENOSPC)
+		suite.NotErrorIs(err, ErrDownloadFailed)
+	})
+
 	suite.Run("StoreCreateFails", func() {
 		// Arrange
 		storeErr := errors.New("store create error")
 		suite.mockPlatform.On("ID").Return("test-platform")
 		suite.mockPlatform.On("Match", req.Url).Return(true)
-		suite.mockPlatform.On("Download", mock.AnythingOfType("*context.timerCtx"), req).
+		suite.mockPlatform.On("Download", suite.ctx, req).
 			Return(&entities.Episode{
 				Title:       "Test Episode",
 				MediaFile:   "audio_test.mp3",
 				OriginalURL: req.Url,
 			}, nil)
+		suite.mockStore.On("Begin", suite.ctx).Return(suite.mockStore, nil)
 		suite.mockStore.On("EpisodeCreate", suite.ctx, mock.AnythingOfType("*entities.Episode")).
 			Return(storeErr)
+		suite.mockStore.On("Rollback").Return(nil)
 
 		// Act
-		result, err := suite.service.Download(suite.ctx, req)
+		result, err := suite.service.Download(suite.ctx, req, nil)
 
 		// Assert
 		suite.Error(err)
@@ -253,6 +506,110 @@ func (suite *TestEpisodeServiceSuite) TestDownload() {
 		suite.Contains(err.Error(), storeErr.Error())
 	})
 
+	suite.Run("SaveWithinTransaction", func() {
+		// Arrange
+		tx := mocks.NewMockStore(suite.T())
+		suite.mockPlatform.On("ID").Return("test-platform")
+		suite.mockPlatform.On("Match", req.Url).Return(true)
+		suite.mockPlatform.On("Download", suite.ctx, req).
+			Return(&entities.Episode{Title: "Test Episode", MediaFile: "audio_test.mp3"}, nil)
+		suite.mockStore.On("Begin", suite.ctx).Return(tx, nil)
+		tx.On("EpisodeCreate", suite.ctx, mock.AnythingOfType("*entities.Episode")).
+			Return(nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*entities.Episode).ID = 1
+		})
+		tx.On("Commit").Return(nil)
+
+		var saved *entities.Episode
+		save := func(ctx context.Context, s Store, episode *entities.Episode) error {
+			suite.Same(tx, s, "save must be called within the transaction")
+			saved = episode
+			return nil
+		}
+
+		// Act
+		result, err := suite.service.Download(suite.ctx, req, save)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Same(result, saved)
+		suite.Equal(int64(1), saved.ID)
+	})
+
+	suite.Run("SaveFails", func() {
+		// Arrange
+		tx := mocks.NewMockStore(suite.T())
+		mediaPath := filepath.Join(suite.publicDir, "audio_save_fails.mp3")
+		thumbPath := filepath.Join(suite.publicDir, "thumb_save_fails.jpg")
+		suite.Require().NoError(os.WriteFile(mediaPath, []byte("media"), 0644))
+		suite.Require().NoError(os.WriteFile(thumbPath, []byte("thumb"), 0644))
+
+		suite.mockPlatform.On("ID").Return("test-platform")
+		suite.mockPlatform.On("Match", req.Url).Return(true)
+		suite.mockPlatform.On("Download", suite.ctx, req).
+			Return(&entities.Episode{
+				Title:         "Test Episode",
+				MediaFile:     "audio_save_fails.mp3",
+				ThumbnailFile: "thumb_save_fails.jpg",
+			}, nil)
+		suite.mockStore.On("Begin", suite.ctx).Return(tx, nil)
+		tx.On("EpisodeCreate", suite.ctx, mock.AnythingOfType("*entities.Episode")).Return(nil)
+		tx.On("Rollback").Return(nil).Once()
+		save := func(context.Context, Store, *entities.Episode) error {
+			return fmt.Errorf("%w: %w", ErrProcessUpsert, errors.New("upsert error"))
+		}
+
+		// Act
+		result, err := suite.service.Download(suite.ctx, req, save)
+
+		// Assert
+		suite.Nil(result)
+		suite.ErrorIs(err, ErrProcessUpsert)
+		tx.AssertNotCalled(suite.T(), "Commit")
+		suite.NoFileExists(mediaPath)
+		suite.NoFileExists(thumbPath)
+	})
+
+	suite.Run("BeginFails", func() {
+		// Arrange
+		suite.mockPlatform.On("ID").Return("test-platform")
+		suite.mockPlatform.On("Match", req.Url).Return(true)
+		suite.mockPlatform.On("Download", suite.ctx, req).
+			Return(&entities.Episode{Title: "Test Episode", MediaFile: "audio_test.mp3"}, nil)
+		suite.mockStore.On("Begin", suite.ctx).Return(nil, errors.New("database is locked"))
+
+		// Act
+		result, err := suite.service.Download(suite.ctx, req, nil)
+
+		// Assert
+		suite.Nil(result)
+		suite.ErrorIs(err, ErrStoreBegin)
+	})
+
+	suite.Run("CommitFails", func() {
+		// Arrange
+		tx := mocks.NewMockStore(suite.T())
+		mediaPath := filepath.Join(suite.publicDir, "audio_commit_fails.mp3")
+		suite.Require().NoError(os.WriteFile(mediaPath, []byte("media"), 0644))
+
+		suite.mockPlatform.On("ID").Return("test-platform")
+		suite.mockPlatform.On("Match", req.Url).Return(true)
+		suite.mockPlatform.On("Download", suite.ctx, req).
+			Return(&entities.Episode{Title: "Test Episode", MediaFile: "audio_commit_fails.mp3"}, nil)
+		suite.mockStore.On("Begin", suite.ctx).Return(tx, nil)
+		tx.On("EpisodeCreate", suite.ctx, mock.AnythingOfType("*entities.Episode")).Return(nil)
+		tx.On("Commit").Return(errors.New("disk I/O error"))
+		tx.On("Rollback").Return(nil)
+
+		// Act
+		result, err := suite.service.Download(suite.ctx, req, nil)
+
+		// Assert
+		suite.Nil(result)
+		suite.ErrorIs(err, ErrStoreCommit)
+		suite.NoFileExists(mediaPath)
+	})
+
 	suite.Run("ContextTimeout", func() {
 		// Arrange
 		shortCtx, cancel := context.WithTimeout(suite.ctx, 10*time.Millisecond)
@@ -260,16 +617,17 @@ func (suite *TestEpisodeServiceSuite) TestDownload() {
 
 		suite.mockPlatform.On("ID").Return("test-platform")
 		suite.mockPlatform.On("Match", req.Url).Return(true)
-		suite.mockPlatform.On("Download", mock.AnythingOfType("*context.cancelCtx"), req).
-			Return(nil, context.DeadlineExceeded)
+		suite.mockPlatform.On("Download", shortCtx, req).
+			Return(nil, fmt.Errorf("media download timed out: %w", context.DeadlineExceeded))
 
 		// Act
-		result, err := suite.service.Download(shortCtx, req)
+		result, err := suite.service.Download(shortCtx, req, nil)
 
 		// Assert
 		suite.Error(err)
 		suite.Nil(result)
-		suite.True(errors.Is(err, ErrDownloadFailed))
+		suite.True(errors.Is(err, ErrDownloadTimeout))
+		suite.False(errors.Is(err, ErrDownloadFailed))
 		suite.Contains(err.Error(), context.DeadlineExceeded.Error())
 	})
 
@@ -286,7 +644,7 @@ func (suite *TestEpisodeServiceSuite) TestDownload() {
 
 		suite.mockPlatform.On("ID").Return("test-platform")
 		suite.mockPlatform.On("Match", reqNoDefaults.Url).Return(true)
-		suite.mockPlatform.On("Download", mock.AnythingOfType("*context.timerCtx"), mock.MatchedBy(func(req entities.Request) bool {
+		suite.mockPlatform.On("Download", suite.ctx, mock.MatchedBy(func(req entities.Request) bool {
 			// Verify that default values from config are applied
 			return req.DownloadFormat == suite.cfg.DownloadFormat && req.DownloadQuality == suite.cfg.DownloadQuality
 		})).Return(&entities.Episode{
@@ -294,14 +652,16 @@ func (suite *TestEpisodeServiceSuite) TestDownload() {
 			MediaFile:   "audio_test.mp3",
 			OriginalURL: reqNoDefaults.Url,
 		}, nil)
+		suite.mockStore.On("Begin", suite.ctx).Return(suite.mockStore, nil)
 		suite.mockStore.On("EpisodeCreate", suite.ctx, mock.AnythingOfType("*entities.Episode")).
 			Return(nil).Run(func(args mock.Arguments) {
 			episode := args.Get(1).(*entities.Episode)
 			episode.ID = 1
 		})
+		suite.mockStore.On("Commit").Return(nil)
 
 		// Act
-		result, err := suite.service.Download(suite.ctx, reqNoDefaults)
+		result, err := suite.service.Download(suite.ctx, reqNoDefaults, nil)
 
 		// Assert
 		suite.NoError(err)
@@ -314,7 +674,8 @@ func (suite *TestEpisodeServiceSuite) TestFindPlatform() {
 	suite.Run("MultiplePlatforms", func() {
 		// Arrange
 		mockPlatform2 := mocks.NewMockPlatform(suite.T())
-		service := NewEpisodeService(suite.cfg, suite.log, suite.mockStore, suite.mockPlatform, mockPlatform2)
+		mockPlatform2.EXPECT().SupportsFormat(entities.DownloadMp3).Return(true)
+		service := NewEpisodeService(suite.cfg, suite.log, suite.mockStore, suite.mockFeeder, suite.mockPlatform, mockPlatform2)
 
 		req := entities.Request{
 			ID:              "test-req-multi",
@@ -331,20 +692,22 @@ func (suite *TestEpisodeServiceSuite) TestFindPlatform() {
 		suite.mockPlatform.On("Match", req.Url).Return(false)
 		mockPlatform2.On("ID").Return("test-platform-2")
 		mockPlatform2.On("Match", req.Url).Return(true)
-		mockPlatform2.On("Download", mock.AnythingOfType("*context.timerCtx"), req).
+		mockPlatform2.On("Download", suite.ctx, req).
 			Return(&entities.Episode{
 				Title:       "Test Episode",
 				MediaFile:   "test.mp3",
 				OriginalURL: req.Url,
 			}, nil)
+		suite.mockStore.On("Begin", suite.ctx).Return(suite.mockStore, nil)
 		suite.mockStore.On("EpisodeCreate", suite.ctx, mock.AnythingOfType("*entities.Episode")).
 			Return(nil).Run(func(args mock.Arguments) {
 			episode := args.Get(1).(*entities.Episode)
 			episode.ID = 1
 		})
+		suite.mockStore.On("Commit").Return(nil)
 
 		// Act
-		result, err := service.Download(suite.ctx, req)
+		result, err := service.Download(suite.ctx, req, nil)
 
 		// Assert
 		suite.NoError(err)
@@ -358,7 +721,7 @@ func (suite *TestEpisodeServiceSuite) TestFindPlatform() {
 
 	suite.Run("NoPlatforms", func() {
 		// Arrange
-		service := NewEpisodeService(suite.cfg, suite.log, suite.mockStore) // No platforms
+		service := NewEpisodeService(suite.cfg, suite.log, suite.mockStore, suite.mockFeeder) // No platforms
 		req := entities.Request{
 			ID:              "test-req-no-platforms",
 			UserID:          123,
@@ -371,7 +734,7 @@ func (suite *TestEpisodeServiceSuite) TestFindPlatform() {
 		}
 
 		// Act
-		result, err := service.Download(suite.ctx, req)
+		result, err := service.Download(suite.ctx, req, nil)
 
 		// Assert
 		suite.Error(err)
@@ -380,10 +743,140 @@ func (suite *TestEpisodeServiceSuite) TestFindPlatform() {
 	})
 }
 
+// TestDelete tests the Delete method
+func (suite *TestEpisodeServiceSuite) TestDelete() {
+	episode := &entities.Episode{
+		ID:            42,
+		Title:         "Test Episode",
+		MediaFile:     "delete.mp3",
+		ThumbnailFile: "delete.jpg",
+	}
+	createFiles := func() {
+		for _, name := range []string{episode.MediaFile, episode.ThumbnailFile} {
+			suite.Require().NoError(os.WriteFile(filepath.Join(suite.publicDir, name), []byte("data"), 0644))
+		}
+	}
+
+	suite.Run("Success", func() {
+		// Arrange
+		createFiles()
+		var filesExistOnBuild bool
+		mock.InOrder(
+			suite.mockStore.On("EpisodeGetByID", suite.ctx, int64(42)).Return(episode, nil).Once(),
+			suite.mockStore.On("EpisodeDelete", suite.ctx, int64(42)).Return(nil).Once(),
+			suite.mockFeeder.On("Build", suite.ctx).Run(func(_ mock.Arguments) {
+				_, err := os.Stat(filepath.Join(suite.publicDir, episode.MediaFile))
+				filesExistOnBuild = err == nil
+			}).Return(nil).Once(),
+		)
+
+		// Act
+		err := suite.service.Delete(suite.ctx, 42)
+
+		// Assert
+		suite.NoError(err)
+		suite.False(filesExistOnBuild, "files must be removed before feed rebuild")
+		suite.NoFileExists(filepath.Join(suite.publicDir, episode.MediaFile))
+		suite.NoFileExists(filepath.Join(suite.publicDir, episode.ThumbnailFile))
+	})
+
+	suite.Run("FilesAlreadyMissing", func() {
+		// Arrange
+		suite.mockStore.On("EpisodeGetByID", suite.ctx, int64(42)).Return(episode, nil).Once()
+		suite.mockStore.On("EpisodeDelete", suite.ctx, int64(42)).Return(nil).Once()
+		suite.mockFeeder.On("Build", suite.ctx).Return(nil).Once()
+
+		// Act
+		err := suite.service.Delete(suite.ctx, 42)
+
+		// Assert
+		suite.NoError(err)
+	})
+
+	suite.Run("OnlyEpisode", func() {
+		// Arrange
+		createFiles()
+		suite.mockStore.On("EpisodeGetByID", suite.ctx, int64(42)).Return(episode, nil).Once()
+		suite.mockStore.On("EpisodeDelete", suite.ctx, int64(42)).Return(nil).Once()
+		suite.mockFeeder.On("Build", suite.ctx).Return(ErrEmptyFeed).Once()
+
+		// Act
+		err := suite.service.Delete(suite.ctx, 42)
+
+		// Assert
+		suite.NoError(err, "the empty feed is removed by the feeder")
+		suite.NoFileExists(filepath.Join(suite.publicDir, episode.MediaFile))
+	})
+
+	suite.Run("RemoveFilesFails", func() {
+		// Arrange: a non-empty directory in place of the media file can not be removed
+		mediaDir := filepath.Join(suite.publicDir, episode.MediaFile)
+		suite.Require().NoError(os.MkdirAll(filepath.Join(mediaDir, "nested"), 0755))
+		defer func() { _ = os.RemoveAll(mediaDir) }()
+		suite.mockStore.On("EpisodeGetByID", suite.ctx, int64(42)).Return(episode, nil).Once()
+		suite.mockStore.On("EpisodeDelete", suite.ctx, int64(42)).Return(nil).Once()
+		suite.mockFeeder.On("Build", suite.ctx).Return(nil).Once()
+
+		// Act
+		err := suite.service.Delete(suite.ctx, 42)
+
+		// Assert
+		suite.NoError(err)
+		suite.mockFeeder.AssertCalled(suite.T(), "Build", suite.ctx)
+	})
+
+	suite.Run("NotFound", func() {
+		// Arrange
+		suite.mockStore.On("EpisodeGetByID", suite.ctx, int64(42)).Return(nil, store.ErrNotFound).Once()
+
+		// Act
+		err := suite.service.Delete(suite.ctx, 42)
+
+		// Assert
+		suite.ErrorIs(err, ErrEpisodeNotFound)
+		suite.mockStore.AssertNotCalled(suite.T(), "EpisodeDelete", mock.Anything, mock.Anything)
+		suite.mockFeeder.AssertNotCalled(suite.T(), "Build", mock.Anything)
+	})
+
+	suite.Run("StoreDeleteFails", func() {
+		// Arrange
+		createFiles()
+		storeErr := errors.New("db error")
+		suite.mockStore.On("EpisodeGetByID", suite.ctx, int64(42)).Return(episode, nil).Once()
+		suite.mockStore.On("EpisodeDelete", suite.ctx, int64(42)).Return(storeErr).Once()
+
+		// Act
+		err := suite.service.Delete(suite.ctx, 42)
+
+		// Assert
+		suite.ErrorIs(err, ErrEpisodeDelete)
+		suite.ErrorIs(err, storeErr)
+		suite.FileExists(filepath.Join(suite.publicDir, episode.MediaFile), "files must be kept")
+		suite.mockFeeder.AssertNotCalled(suite.T(), "Build", mock.Anything)
+	})
+
+	suite.Run("BuildFails", func() {
+		// Arrange
+		createFiles()
+		buildErr := errors.New("build error")
+		suite.mockStore.On("EpisodeGetByID", suite.ctx, int64(42)).Return(episode, nil).Once()
+		suite.mockStore.On("EpisodeDelete", suite.ctx, int64(42)).Return(nil).Once()
+		suite.mockFeeder.On("Build", suite.ctx).Return(buildErr).Once()
+
+		// Act
+		err := suite.service.Delete(suite.ctx, 42)
+
+		// Assert
+		suite.ErrorIs(err, buildErr)
+		suite.Contains(err.Error(), "feed rebuild failed")
+		suite.NoFileExists(filepath.Join(suite.publicDir, episode.MediaFile))
+	})
+}
+
 // Add tests for validateRequest
 func (suite *TestEpisodeServiceSuite) TestValidateRequest() {
 	// create a service instance explicitly to ensure cfg is applied
-	service := NewEpisodeService(suite.cfg, suite.log, suite.mockStore, suite.mockPlatform)
+	service := NewEpisodeService(suite.cfg, suite.log, suite.mockStore, suite.mockFeeder, suite.mockPlatform)
 
 	suite.Run("Success", func() {
 		req := entities.Request{
@@ -431,6 +924,32 @@ func (suite *TestEpisodeServiceSuite) TestValidateRequest() {
 		suite.Error(err)
 		suite.Contains(err.Error(), "download quality validation failed")
 	})
+
+	suite.Run("NegativeEpisodeNumber", func() {
+		req := entities.Request{
+			ID:              "req-bad-episode",
+			Url:             "https://example.com/video",
+			DownloadFormat:  entities.DownloadMp3,
+			DownloadQuality: "128k",
+			EpisodeNumber:   -1,
+		}
+		err := service.validateRequest(&req)
+		suite.Error(err)
+		suite.Contains(err.Error(), "episode number must not be negative")
+	})
+
+	suite.Run("NegativeSeasonNumber", func() {
+		req := entities.Request{
+			ID:              "req-bad-season",
+			Url:             "https://example.com/video",
+			DownloadFormat:  entities.DownloadMp3,
+			DownloadQuality: "128k",
+			SeasonNumber:    -1,
+		}
+		err := service.validateRequest(&req)
+		suite.Error(err)
+		suite.Contains(err.Error(), "season number must not be negative")
+	})
 }
 
 // TestEpisodeService runs the test suite
@@ -2,6 +2,8 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"syscall"
 )
 
 var (
@@ -14,6 +16,15 @@ var (
 	ErrProcessInterrupted = NewError(105, "process was interrupted")
 	ErrEmptyFeed          = NewError(106, "feed has no items")
 	ErrInvalidRequest     = NewError(107, "invalid download request")
+	ErrEpisodeNotFound    = NewError(108, "episode not found")
+	ErrFeedInvalid        = NewError(109, "feed validation failed")
+	ErrDownloadTimeout    = NewError(110, "download timed out")
+	ErrDiskFull           = NewError(111, "server storage is full")
+	ErrTooShort           = NewError(112, "media is too short")
+
+	ErrPlaylistNotSupported = NewError(113, "playlists are not supported")
+
+	ErrUnsupportedFormatForPlatform = NewError(115, "download format is not supported by the platform")
 
 	// Store errors
 
@@ -25,12 +36,55 @@ var (
 	ErrEpisodeListAll             = NewError(206, "failed to list all episodes")
 	ErrEpisodeCountAll            = NewError(207, "failed to count all episodes")
 	ErrEpisodeGetLastTime         = NewError(208, "failed to get last episode time")
+	ErrEpisodeGetByID             = NewError(209, "failed to get episode by ID")
+	ErrEpisodeDelete              = NewError(210, "failed to delete episode")
+	ErrEpisodeExistsByOriginalURL = NewError(211, "failed to check episode existence by original URL")
+	ErrEpisodeList                = NewError(212, "failed to list episodes")
+	ErrStoreBegin                 = NewError(213, "failed to begin store transaction")
+	ErrStoreCommit                = NewError(214, "failed to commit store transaction")
 
 	// I/O errors
 
-	ErrFeedSave = NewError(301, "failed to save feed to file")
+	ErrFeedSave   = NewError(301, "failed to save feed to file")
+	ErrFileRemove = NewError(302, "failed to remove file")
+	ErrFeedEncode = NewError(303, "failed to encode feed")
+)
+
+// Download failure classes. Platforms wrap command failures with one of them
+// so that the services can decide whether repeating the download makes sense.
+var (
+	ErrRetryable = errors.New("transient download failure")
+	ErrPermanent = errors.New("permanent download failure")
 )
 
+// ErrMediaTooLarge is returned by platforms when the media exceeds the configured maximum size.
+// It is a permanent failure: the same media will not get smaller on retry.
+var ErrMediaTooLarge = fmt.Errorf("%w: media file is too large", ErrPermanent)
+
+// ErrMediaTooShort is returned by platforms when the media is shorter than the configured minimum duration.
+// It is a permanent failure: the same media will not get longer on retry.
+var ErrMediaTooShort = fmt.Errorf("%w: media is too short", ErrPermanent)
+
+// ErrPlaylist is returned by platforms when the URL refers to a playlist rather than a single media.
+// It is a permanent failure: the playlist is downloaded only as separate requests of its entries.
+var ErrPlaylist = fmt.Errorf("%w: url is a playlist", ErrPermanent)
+
+// PlaylistError is returned by platforms instead of ErrPlaylist if playlists are allowed.
+// It lists the URLs of the playlist entries to download them as separate requests.
+type PlaylistError struct {
+	Entries []string
+}
+
+// Error implements the error interface.
+func (e *PlaylistError) Error() string {
+	return fmt.Sprintf("%s with %d entries", ErrPlaylist, len(e.Entries))
+}
+
+// Unwrap returns ErrPlaylist, so PlaylistError is detected with errors.Is(err, ErrPlaylist).
+func (e *PlaylistError) Unwrap() error {
+	return ErrPlaylist
+}
+
 type Error = struct {
 	Code int
 	error
@@ -39,3 +93,11 @@ type Error = struct {
 func NewError(code int, msg string) Error {
 	return Error{Code: code, error: errors.New(msg)}
 }
+
+// ioError wraps the I/O error with ErrDiskFull if the disk is full, otherwise with the given error.
+func ioError(e Error, err error) error {
+	if errors.Is(err, syscall.ENOSPC) {
+		return fmt.Errorf("%w: %w", ErrDiskFull, err)
+	}
+	return fmt.Errorf("%w: %w", e, err)
+}
@@ -1,11 +1,22 @@
 package services
 
 import (
+	"bytes"
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log/slog"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/ofstudio/voxify/internal/config"
@@ -13,126 +24,453 @@ import (
 	"github.com/ofstudio/voxify/pkg/feedcast"
 )
 
+const feedFileMode = 0644 // Feed file permissions
+
 // FeedService builds RSS podcast feed from episodes.
 type FeedService struct {
-	cfg   *config.Settings
-	log   *slog.Logger
-	store Store
+	cfg    *config.Settings
+	log    *slog.Logger
+	store  Store
+	client *http.Client
 }
 
 // NewFeedService creates a new FeedService instance.
 func NewFeedService(cfg *config.Settings, log *slog.Logger, s Store) *FeedService {
 	return &FeedService{
-		cfg:   cfg,
-		log:   log,
-		store: s,
+		cfg:    cfg,
+		log:    log,
+		store:  s,
+		client: newHttpClient(cfg),
 	}
 }
 
 // Init checks the service dependencies and prepares the environment.
 func (s *FeedService) Init(_ context.Context) error {
+	if s.cfg.DefaultEpisodeImage != "" {
+		u, err := url.Parse(s.cfg.DefaultEpisodeImage)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("default episode image must be an absolute http(s) URL, got %q", s.cfg.DefaultEpisodeImage)
+		}
+	}
 	return nil
 }
 
 // Build implements Feeder interface to generate RSS feed from all episodes.
+// If there are no episodes, the published feed file is removed and ErrEmptyFeed is returned.
 func (s *FeedService) Build(ctx context.Context) error {
+	_, err := s.BuildReport(ctx)
+	return err
+}
+
+// BuildReport implements Feeder interface to generate RSS feed from all episodes
+// as Build does and return the summary of the build.
+func (s *FeedService) BuildReport(ctx context.Context) (*entities.FeedBuildReport, error) {
 	s.log.Info("[feed service] building podcast feed")
+	started := time.Now()
+
+	feed, _, err := s.buildFeed(ctx)
+	if errors.Is(err, ErrEmptyFeed) {
+		// Do not leave the feed listing the deleted episodes
+		if rmErr := s.removeFeed(); rmErr != nil {
+			s.log.Error("[feed service] failed to remove empty feed", "error", rmErr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.dropInvalidItems(feed)
+	if err = feed.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFeedInvalid, err)
+	}
+
+	// Write feed to file
+	size, err := s.saveFeed(feed)
+	if err != nil {
+		return nil, ioError(ErrFeedSave, err)
+	}
+
+	report := &entities.FeedBuildReport{
+		EpisodeCount: len(feed.Channel.Items),
+		FeedSize:     size,
+		Duration:     time.Since(started),
+	}
+	s.log.Info("[feed service] podcast feed built", "episodes_count", report.EpisodeCount,
+		"feed_size", report.FeedSize, "duration", report.Duration)
+
+	// Notify WebSub hub about feed update
+	if s.cfg.HubURL != "" {
+		s.pingHub(ctx)
+	}
+
+	return report, nil
+}
+
+// Validate implements Feeder interface to check that the feed built
+// from all episodes is valid without writing it to disk.
+// If cfg.FeedSkipInvalid is set, invalid episodes are skipped as in Build.
+// If cfg.FeedCheckArtwork is set, it also checks that the feed artwork is reachable.
+func (s *FeedService) Validate(ctx context.Context) error {
+	feed, _, err := s.buildFeed(ctx)
+	if err != nil {
+		return err
+	}
+	// Validate the same items that would be published
+	s.dropInvalidItems(feed)
+	if s.cfg.FeedCheckArtwork {
+		err = feed.ValidateStrict(ctx, s.client)
+	} else {
+		err = feed.Validate()
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFeedInvalid, err)
+	}
+
+	s.log.Info("[feed service] podcast feed validated", "episodes_count", len(feed.Channel.Items))
+
+	return nil
+}
+
+// FeedBytes implements Feeder interface to build the feed from all episodes
+// in memory and return the encoded RSS XML without writing it to disk,
+// along with the values for conditional HTTP requests. It allows serving
+// the feed directly from an HTTP handler. The lastMod is the last time
+// an episode was added or updated, it is also the build date of the returned feed,
+// so the feed only changes with the episodes and the settings.
+// The etag is a strong entity tag of the returned data.
+func (s *FeedService) FeedBytes(ctx context.Context) (data []byte, etag string, lastMod time.Time, err error) {
+	feed, lastMod, err := s.buildFeed(ctx)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+	s.dropInvalidItems(feed)
+	if err = feed.Validate(); err != nil {
+		return nil, "", time.Time{}, fmt.Errorf("%w: %w", ErrFeedInvalid, err)
+	}
+
+	if data, err = encodeFeed(feed.WithLastBuildDate(lastMod)); err != nil {
+		return nil, "", time.Time{}, err
+	}
+	sum := sha256.Sum256(data)
+
+	return data, `"` + hex.EncodeToString(sum[:16]) + `"`, lastMod, nil
+}
+
+// encodeFeed returns the encoded RSS XML of the feed.
+func encodeFeed(feed *feedcast.Feed) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := feed.Encode(&buf); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFeedEncode, err)
+	}
+	return buf.Bytes(), nil
+}
 
+// buildFeed creates podcast feed in memory from all episodes.
+// It returns the feed and the last time an episode was added or updated.
+func (s *FeedService) buildFeed(ctx context.Context) (*feedcast.Feed, time.Time, error) {
 	// Get all episodes from store
 	episodes, err := s.store.EpisodeListAll(ctx)
 	if err != nil {
-		return fmt.Errorf("%w: %w", ErrEpisodeListAll, err)
+		return nil, time.Time{}, fmt.Errorf("%w: %w", ErrEpisodeListAll, err)
 	}
 	if len(episodes) == 0 {
-		return ErrEmptyFeed
+		return nil, time.Time{}, ErrEmptyFeed
 	}
 
 	// Create podcast feed
 	feed := s.createFeed().WithPubDate(episodes[0].CreatedAt)
 
+	// Episodes are listed by their original publish date, newest first.
+	// Serial shows and feeds configured so are listed from the oldest one.
+	// Store returns the newest downloaded episodes first, so the ties keep the download order.
+	slices.SortStableFunc(episodes, func(a, b *entities.Episode) int {
+		return b.PubDate().Compare(a.PubDate())
+	})
+	if s.oldestFirst() {
+		slices.Reverse(episodes)
+	}
+
 	// Add episodes to feed
+	numbers := newEpisodeNumbers(episodes)
+	var lastMod time.Time
 	for _, episode := range episodes {
-		feed.AddItem(s.createItem(episode))
+		item, err := s.createItem(episode)
+		if err != nil {
+			s.log.Warn("[feed service] episode skipped", "error", err, "episode", episode.LogValue())
+			continue
+		}
+		if s.cfg.FeedType == entities.FeedTypeSerial && episode.EpisodeNumber == 0 {
+			// Serial shows require episode numbers, counted from the oldest one
+			item = item.WithItunesEpisode(numbers.next(episode.SeasonNumber))
+		}
+		feed.AddItem(item)
+		for _, t := range []time.Time{episode.CreatedAt, episode.UpdatedAt} {
+			if t.After(lastMod) {
+				lastMod = t
+			}
+		}
+	}
+	if len(feed.Channel.Items) == 0 {
+		return nil, time.Time{}, ErrEmptyFeed
 	}
 
-	// Write feed to file
-	if err = s.saveFeed(feed); err != nil {
-		return fmt.Errorf("%w: %w", ErrFeedSave, err)
+	return feed, lastMod, nil
+}
+
+// episodeNumbers numbers the episodes without an episode number within their seasons.
+type episodeNumbers struct {
+	used map[seasonEpisode]bool // Numbers of the numbered episodes
+	last map[int]int            // Last assigned number by season
+}
+
+// seasonEpisode is the episode number within the season.
+type seasonEpisode struct {
+	season, episode int
+}
+
+// newEpisodeNumbers returns the numbering skipping the numbers of the numbered episodes.
+func newEpisodeNumbers(episodes []*entities.Episode) *episodeNumbers {
+	used := make(map[seasonEpisode]bool)
+	for _, episode := range episodes {
+		if episode.EpisodeNumber > 0 {
+			used[seasonEpisode{episode.SeasonNumber, episode.EpisodeNumber}] = true
+		}
 	}
+	return &episodeNumbers{used: used, last: make(map[int]int)}
+}
 
-	s.log.Info("[feed service] podcast feed built", "episodes_count", len(episodes))
+// next returns the next number of the season not used by a numbered episode.
+func (n *episodeNumbers) next(season int) int {
+	number := n.last[season] + 1
+	for n.used[seasonEpisode{season, number}] {
+		number++
+	}
+	n.last[season] = number
+	return number
+}
 
-	return nil
+// dropInvalidItems removes the invalid items from the feed if cfg.FeedSkipInvalid is set,
+// so that a single broken episode does not prevent the others from being published.
+// Errors of the removed items are logged.
+func (s *FeedService) dropInvalidItems(feed *feedcast.Feed) {
+	if !s.cfg.FeedSkipInvalid {
+		return
+	}
+	if err := feed.DropInvalidItems(); err != nil {
+		s.log.Warn("[feed service] invalid episodes skipped", "error", err)
+	}
+}
+
+// oldestFirst reports whether feed items should be listed in chronological order.
+func (s *FeedService) oldestFirst() bool {
+	return s.cfg.FeedType == entities.FeedTypeSerial || s.cfg.FeedSortOrder == entities.FeedSortOldest
+}
+
+// pingHub notifies the WebSub hub that the feed has been updated.
+// The notification is best-effort: failures are logged and otherwise ignored.
+func (s *FeedService) pingHub(ctx context.Context) {
+	form := url.Values{
+		"hub.mode": {"publish"},
+		"hub.url":  {s.feedURL()},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.HubURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		s.log.Error("[feed service] failed to create hub notification", "error", err, "hub_url", s.cfg.HubURL)
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.log.Error("[feed service] failed to notify hub", "error", err, "hub_url", s.cfg.HubURL)
+		return
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		s.log.Error("[feed service] hub rejected notification", "status", resp.Status, "hub_url", s.cfg.HubURL)
+		return
+	}
+	s.log.Info("[feed service] hub notified", "hub_url", s.cfg.HubURL)
+}
+
+// feedURL returns the public URL of the feed file.
+func (s *FeedService) feedURL() string {
+	return s.cfg.PublicUrl.JoinPath(s.cfg.FeedFileName).String()
 }
 
 // createFeed creates and configures the main podcast feed.
+// The self link is always the URL the feed is published at, independent of the website link cfg.FeedLink.
 func (s *FeedService) createFeed() *feedcast.Feed {
 	now := time.Now()
-	explicit := feedcast.ExplicitFalse
-	if s.cfg.FeedIsExplicit {
-		explicit = feedcast.ExplicitTrue
-	}
-
-	return feedcast.NewFeed(feedcast.FeedData{
+	data := feedcast.FeedData{
 		Title:       s.cfg.FeedTitle,
 		Description: s.cfg.FeedDescription,
 		Image:       s.cfg.FeedImage,
 		Language:    s.cfg.FeedLanguage,
-		Explicit:    explicit,
 		Categories:  s.getCategories(),
-	}).
+	}
+	data.SetExplicit(s.cfg.FeedIsExplicit)
+	feed := feedcast.NewFeed(data).
 		WithLink(s.cfg.FeedLink).
 		WithItunesTitle(s.cfg.FeedTitle).
 		WithItunesSummary(s.cfg.FeedDescription).
 		WithItunesKeywords(s.cfg.FeedKeywords).
 		WithAuthor(s.cfg.FeedAuthor).
 		WithLastBuildDate(now).
-		WithGenerator(s.getGenerator())
+		WithGenerator(s.getGenerator()).
+		WithSelfLink(s.feedURL())
+
+	if s.cfg.FeedType != entities.FeedTypeNotSet {
+		feed = feed.WithItunesType(s.cfg.FeedType)
+	}
+
+	if s.cfg.FeedCopyright != "" {
+		feed = feed.WithCopyright(s.cfg.FeedCopyright)
+	}
+
+	if owner := s.getOwner(); owner != nil {
+		feed = feed.WithItunesOwner(owner.Name, owner.Email)
+	}
+
+	if s.cfg.FeedBlocked {
+		feed = feed.WithItunesBlock(feedcast.BlockYes)
+	}
+
+	if s.cfg.FeedComplete {
+		feed = feed.WithItunesComplete(feedcast.CompleteYes)
+	}
+
+	if s.cfg.HubURL != "" {
+		feed = feed.WithHub(s.cfg.HubURL)
+	}
+
+	if s.cfg.FeedRssImage {
+		feed = feed.WithImage(s.cfg.FeedImage, s.cfg.FeedTitle, s.cfg.FeedLink)
+	}
+
+	return feed
+}
+
+// episodeExplicit returns the parental advisory of the episode: its own value if set.
+// Otherwise, episodes inherit the channel parental advisory unless cfg.EpisodeDefaultExplicit is set.
+func (s *FeedService) episodeExplicit(episode *entities.Episode) feedcast.Explicit {
+	if episode.Explicit != nil {
+		return feedcast.ExplicitOf(*episode.Explicit)
+	}
+	if s.cfg.EpisodeDefaultExplicit != nil {
+		return feedcast.ExplicitOf(*s.cfg.EpisodeDefaultExplicit)
+	}
+	return feedcast.ExplicitOf(s.cfg.FeedIsExplicit)
 }
 
 // createItem creates a feed item from an episode entity.
-func (s *FeedService) createItem(episode *entities.Episode) *feedcast.Item {
+// The item author falls back to the feed author if the episode has no author.
+// A missing media size is backfilled from the media file in PublicDir.
+// It returns an error if the size is missing and the media file can not be found.
+func (s *FeedService) createItem(episode *entities.Episode) (*feedcast.Item, error) {
+
+	// Backfill media size
+	size := episode.MediaSize
+	if size <= 0 {
+		info, err := os.Stat(filepath.Join(s.cfg.PublicDir, episode.MediaFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get media file size: %w", err)
+		}
+		size = info.Size()
+	}
 
 	// Create item
-	mediaUrl := s.cfg.PublicUrl.JoinPath(episode.MediaFile).String()
+	mediaUrl := s.cfg.MediaUrl(episode.MediaFile)
+	title := feedcast.TruncateTitle(episode.Title, s.cfg.MaxTitleLength)
 	item := feedcast.NewItem(feedcast.ItemData{
-		Title:     episode.Title,
-		Enclosure: feedcast.NewEnclosure(mediaUrl, episode.MediaSize, episode.MediaType),
-		Guid:      mediaUrl,
+		Title:     title,
+		Enclosure: feedcast.NewEnclosure(mediaUrl, size, episode.MediaType),
+		Guid:      s.itemGuid(episode),
 	}).
-		WithItunesDuration(episode.MediaDuration).
-		WithPubDate(episode.CreatedAt).
-		WithDescription(episode.Description).
-		WithItunesTitle(episode.Title).
-		WithItunesSummary(episode.Description).
+		WithPubDate(episode.PubDate()).
+		WithDescription(feedcast.Truncate(episode.Description, feedcast.MaxItemDescriptionLen)).
+		WithItunesTitle(title).
+		WithItunesSummary(feedcast.TruncateSummary(episode.Description, feedcast.MaxItemSummaryLen)).
 		WithLink(episode.CanonicalURL).
-		WithItunesAuthor(episode.Author)
+		WithItunesAuthor(cmp.Or(episode.Author, s.cfg.FeedAuthor)).
+		WithItunesExplicit(s.episodeExplicit(episode))
 
+	if episode.MediaDuration > 0 {
+		item = item.WithItunesDuration(episode.MediaDuration)
+	}
 	if episode.ThumbnailFile != "" {
-		thumbUrl := s.cfg.PublicUrl.JoinPath(episode.ThumbnailFile).String()
+		thumbUrl := s.cfg.MediaUrl(episode.ThumbnailFile)
 		item = item.WithItunesImage(thumbUrl)
+	} else if s.cfg.DefaultEpisodeImage != "" {
+		item = item.WithItunesImage(s.cfg.DefaultEpisodeImage)
+	}
+	if episode.EpisodeNumber > 0 {
+		item = item.WithItunesEpisode(episode.EpisodeNumber)
 	}
+	if episode.SeasonNumber > 0 {
+		item = item.WithItunesSeason(episode.SeasonNumber)
+	}
+
+	return item, nil
+}
+
+// itemGuid returns the GUID of the episode item: the media file URL on PublicUrl.
+// The GUID does not follow MediaBaseUrl, so changing the media host
+// does not make podcast clients download all episodes again.
+func (s *FeedService) itemGuid(episode *entities.Episode) string {
+	return s.cfg.PublicUrl.JoinPath(episode.MediaFile).String()
+}
 
-	return item
+// removeFeed removes the published feed file. A missing file is not an error.
+func (s *FeedService) removeFeed() error {
+	if s.cfg.FeedFileName == "" {
+		return nil
+	}
+	err := os.Remove(filepath.Join(s.cfg.PublicDir, s.cfg.FeedFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
 }
 
-// saveFeed writes the RSS feed to the configured file path.
-func (s *FeedService) saveFeed(feed *feedcast.Feed) error {
+// saveFeed writes the RSS feed to the configured file path and returns the size of the written feed.
+// The feed is written to a temporary file in the same directory first
+// and then renamed over the final path, so readers never see a half-written feed.
+func (s *FeedService) saveFeed(feed *feedcast.Feed) (size int64, err error) {
 
-	// Create or overwrite feed file
-	file, err := os.Create(filepath.Join(s.cfg.PublicDir, s.cfg.FeedFileName))
+	// Create temporary feed file next to the final one
+	file, err := os.CreateTemp(s.cfg.PublicDir, s.cfg.FeedFileName+".*.tmp")
 	if err != nil {
-		return fmt.Errorf("failed to create feed file: %w", err)
+		return 0, fmt.Errorf("failed to create feed file: %w", err)
 	}
-	//goland:noinspection GoUnhandledErrorResult
-	defer file.Close()
+	defer func() {
+		if err != nil {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+	}()
 
-	// Write RSS feed to file
+	// Write RSS feed to temporary file
 	if err = feed.Encode(file); err != nil {
-		return fmt.Errorf("failed to encode feed to file: %w", err)
+		return 0, fmt.Errorf("failed to encode feed to file: %w", err)
+	}
+	if size, err = file.Seek(0, io.SeekCurrent); err != nil {
+		return 0, fmt.Errorf("failed to get feed file size: %w", err)
+	}
+	if err = file.Chmod(feedFileMode); err != nil {
+		return 0, fmt.Errorf("failed to set feed file permissions: %w", err)
+	}
+	if err = file.Close(); err != nil {
+		return 0, fmt.Errorf("failed to close temporary feed file: %w", err)
 	}
 
-	return nil
+	// Replace feed file
+	if err = os.Rename(file.Name(), filepath.Join(s.cfg.PublicDir, s.cfg.FeedFileName)); err != nil {
+		return 0, fmt.Errorf("failed to replace feed file: %w", err)
+	}
+
+	return size, nil
 }
 
 // getCategories converts a list of config.Settings categories to entities.FeedCategory.
@@ -161,6 +499,14 @@ func (s *FeedService) getCategories() []entities.FeedCategory {
 	return categories
 }
 
+// getOwner returns the configured podcast owner or nil if neither name nor email is set.
+func (s *FeedService) getOwner() *entities.FeedOwner {
+	if s.cfg.FeedOwnerName == "" && s.cfg.FeedOwnerEmail == "" {
+		return nil
+	}
+	return &entities.FeedOwner{Name: s.cfg.FeedOwnerName, Email: s.cfg.FeedOwnerEmail}
+}
+
 // getGenerator returns the feed generator string.
 func (s *FeedService) getGenerator() string {
 	return "Voxify " + config.Version() + " (github.com/ofstudio/voxify)"
@@ -190,17 +536,27 @@ func (s *FeedService) Feed(ctx context.Context) (*entities.Feed, error) {
 		Categories:    s.getCategories(),
 		Keywords:      s.cfg.FeedKeywords,
 		Author:        s.cfg.FeedAuthor,
-		Owner:         nil, // Owner not implemented yet
-		Copyright:     "",  // Copyright not implemented yet
+		Owner:         s.getOwner(),
+		Copyright:     s.cfg.FeedCopyright,
 		Explicit:      s.cfg.FeedIsExplicit,
-		FeedType:      entities.FeedTypeNotSet, // Feed type not implemented yet
-		FeedCompleted: false,                   // Feed completed feature not implemented yet
-		FeedBlocked:   false,                   // Feed blocked feature not implemented yet
+		FeedType:      s.cfg.FeedType,
+		FeedCompleted: s.cfg.FeedComplete,
+		FeedBlocked:   s.cfg.FeedBlocked,
 		WebsiteLink:   s.cfg.FeedLink,
-		RSSLink:       s.cfg.PublicUrl.JoinPath(s.cfg.FeedFileName).String(),
+		RSSLink:       s.feedURL(),
 		ImageUrl:      s.cfg.FeedImage,
 		Generator:     s.getGenerator(),
 		PubDate:       pubDate, // Zero time if no episodes
 		EpisodeCount:  count,
 	}, nil
 }
+
+// RecentEpisodes implements Feeder interface to return up to limit episodes,
+// newest first, skipping the first offset ones.
+func (s *FeedService) RecentEpisodes(ctx context.Context, limit, offset int) ([]*entities.Episode, error) {
+	episodes, err := s.store.EpisodeList(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEpisodeList, err)
+	}
+	return episodes, nil
+}
@@ -1,13 +1,21 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -16,6 +24,7 @@ import (
 	"github.com/ofstudio/voxify/internal/config"
 	"github.com/ofstudio/voxify/internal/entities"
 	"github.com/ofstudio/voxify/internal/mocks"
+	"github.com/ofstudio/voxify/pkg/feedcast"
 )
 
 // TestFeedServiceSuite is a test suite for FeedService
@@ -50,7 +59,7 @@ func (suite *TestFeedServiceSuite) SetupSuite() {
 		FeedLink:        "https://test.example.com",
 		FeedImage:       "https://test.example.com/cover.jpg",
 		FeedLanguage:    "en",
-		FeedCategories:  []string{"Technology", "Podcasts"},
+		FeedCategories:  []string{"Science", "Physics"},
 		FeedAuthor:      "Test Author",
 		FeedIsExplicit:  false,
 	}
@@ -94,6 +103,22 @@ func (suite *TestFeedServiceSuite) TestBuild() {
 		// No feed file should be created when there are no episodes
 	})
 
+	suite.Run("NoEpisodesLeft", func() {
+		// Arrange: the feed published before the last episode was deleted
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		feedPath := filepath.Join(cfg.PublicDir, cfg.FeedFileName)
+		suite.Require().NoError(os.WriteFile(feedPath, []byte("<rss/>"), 0644))
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return([]*entities.Episode{}, nil)
+
+		// Act
+		err := NewFeedService(&cfg, suite.log, suite.mockStore).Build(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrEmptyFeed)
+		suite.NoFileExists(feedPath, "stale feed must be removed")
+	})
+
 	suite.Run("Success_WithEpisodes", func() {
 		// Arrange
 		now := time.Now()
@@ -289,6 +314,28 @@ func (suite *TestFeedServiceSuite) TestBuild_EdgeCases() {
 		suite.FileExists(feedPath)
 	})
 
+	suite.Run("LongDescriptionTruncated", func() {
+		// Arrange
+		episodes := []*entities.Episode{
+			{
+				ID:          1,
+				Title:       "Long Episode",
+				Description: strings.Repeat("я", 6000), // 12000 bytes
+				CreatedAt:   time.Now(),
+				MediaFile:   "episode1.mp3",
+				MediaSize:   1024000,
+				MediaType:   "audio/mpeg",
+			},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := suite.service.Build(suite.ctx)
+
+		// Assert
+		suite.NoError(err)
+	})
+
 	suite.Run("SupportedMediaFormats", func() {
 		// Arrange - test both supported formats
 		now := time.Now()
@@ -332,65 +379,1563 @@ func (suite *TestFeedServiceSuite) TestBuild_EdgeCases() {
 	})
 }
 
-// TestFeed method
-func (suite *TestFeedServiceSuite) TestFeed() {
-	suite.Run("WithEpisodes", func() {
+// TestBuildReport tests the summary returned by BuildReport
+func (suite *TestFeedServiceSuite) TestBuildReport() {
+	suite.Run("Success", func() {
 		// Arrange
-		now := time.Now().UTC()
-		suite.mockStore.On("EpisodeCountAll", suite.ctx).Return(2, nil)
-		suite.mockStore.On("EpisodeGetLastTime", suite.ctx).Return(now, nil)
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		episodes := []*entities.Episode{
+			{ID: 2, Title: "Episode 2", CreatedAt: time.Now(), MediaFile: "episode2.mp3", MediaSize: 2048, MediaType: entities.MediaMp3},
+			{ID: 1, Title: "Episode 1", CreatedAt: time.Now().Add(-time.Hour), MediaFile: "episode1.mp3", MediaSize: 1024, MediaType: entities.MediaMp3},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
 
 		// Act
-		feed, err := suite.service.Feed(suite.ctx)
+		report, err := service.BuildReport(suite.ctx)
 
 		// Assert
 		suite.Require().NoError(err)
-		suite.Equal(2, feed.EpisodeCount)
-		suite.True(feed.PubDate.Equal(now))
-		suite.Equal(suite.cfg.FeedTitle, feed.Title)
-		suite.Equal(suite.cfg.FeedDescription, feed.Description)
-		suite.Equal(suite.cfg.PublicUrl.JoinPath(suite.cfg.FeedFileName).String(), feed.RSSLink)
+		suite.Require().NotNil(report)
+		suite.Equal(2, report.EpisodeCount)
+		info, err := os.Stat(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		suite.Equal(info.Size(), report.FeedSize)
+		suite.Positive(report.Duration)
+	})
+
+	suite.Run("Failure", func() {
+		// Arrange
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(nil, errors.New("store error"))
+
+		// Act
+		report, err := suite.service.BuildReport(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrEpisodeListAll)
+		suite.Nil(report)
 	})
+}
+
+// TestBuild_HubPing tests WebSub hub notification after the feed is built
+func (suite *TestFeedServiceSuite) TestBuild_HubPing() {
+	episodes := []*entities.Episode{
+		{
+			ID:        1,
+			Title:     "Test Episode",
+			CreatedAt: time.Now(),
+			MediaFile: "episode1.mp3",
+			MediaSize: 1024000,
+			MediaType: entities.MediaMp3,
+		},
+	}
 
-	suite.Run("ZeroEpisodes", func() {
+	suite.Run("PingSent", func() {
 		// Arrange
-		suite.mockStore.On("EpisodeCountAll", suite.ctx).Return(0, nil)
+		pings := make(chan url.Values, 1)
+		hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			suite.Equal(http.MethodPost, r.Method)
+			suite.Equal("application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+			suite.Require().NoError(r.ParseForm())
+			pings <- r.PostForm
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer hub.Close()
+
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		cfg.HubURL = hub.URL
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
 
 		// Act
-		feed, err := suite.service.Feed(suite.ctx)
+		err := service.Build(suite.ctx)
 
 		// Assert
 		suite.Require().NoError(err)
-		suite.Equal(0, feed.EpisodeCount)
-		suite.True(feed.PubDate.IsZero())
+		select {
+		case form := <-pings:
+			suite.Equal("publish", form.Get("hub.mode"))
+			suite.Equal("https://test.example.com/public/feed.xml", form.Get("hub.url"))
+		default:
+			suite.Fail("hub was not notified")
+		}
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		suite.Contains(string(content), `<atom:link rel="hub" href="`+hub.URL+`">`)
 	})
 
-	suite.Run("CountError", func() {
+	suite.Run("PingFailureIgnored", func() {
 		// Arrange
-		expectedErr := errors.New("count failed")
-		suite.mockStore.On("EpisodeCountAll", suite.ctx).Return(0, expectedErr)
+		hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer hub.Close()
+
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		cfg.HubURL = hub.URL
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
 
 		// Act
-		_, err := suite.service.Feed(suite.ctx)
+		err := service.Build(suite.ctx)
 
 		// Assert
-		suite.Error(err)
-		suite.Contains(err.Error(), "failed to count all episodes")
-		suite.ErrorIs(err, expectedErr)
+		suite.NoError(err)
 	})
 
-	suite.Run("LastTimeError", func() {
+	suite.Run("NoHubConfigured", func() {
 		// Arrange
-		expectedErr := errors.New("last failed")
-		suite.mockStore.On("EpisodeCountAll", suite.ctx).Return(1, nil)
-		suite.mockStore.On("EpisodeGetLastTime", suite.ctx).Return(time.Time{}, expectedErr)
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
 
 		// Act
-		_, err := suite.service.Feed(suite.ctx)
+		err := service.Build(suite.ctx)
 
 		// Assert
-		suite.Error(err)
-		suite.Contains(err.Error(), "failed to get last episode time")
+		suite.Require().NoError(err)
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		suite.NotContains(string(content), `rel="hub"`)
+	})
+}
+
+// TestBuild_SortOrder tests the order of items in the feed
+func (suite *TestFeedServiceSuite) TestBuild_SortOrder() {
+	now := time.Now().UTC().Truncate(time.Second)
+	// Episodes as returned by the store: newest first
+	newEpisodes := func() []*entities.Episode {
+		return []*entities.Episode{
+			{ID: 3, Title: "Episode 3", CreatedAt: now, MediaFile: "episode3.mp3", MediaSize: 3000, MediaType: entities.MediaMp3},
+			{ID: 2, Title: "Episode 2", CreatedAt: now.Add(-time.Hour), MediaFile: "episode2.mp3", MediaSize: 2000, MediaType: entities.MediaMp3},
+			{ID: 1, Title: "Episode 1", CreatedAt: now.Add(-2 * time.Hour), MediaFile: "episode1.mp3", MediaSize: 1000, MediaType: entities.MediaMp3},
+		}
+	}
+
+	// build builds the feed with the given config and returns the item blocks in order
+	build := func(cfg config.Settings) []string {
+		cfg.PublicDir = suite.T().TempDir()
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(newEpisodes(), nil)
+
+		suite.Require().NoError(service.Build(suite.ctx))
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		items := strings.Split(string(content), "<item>")[1:]
+		suite.Require().Len(items, 3)
+		return items
+	}
+
+	// assertItem checks that the item block belongs to the episode with the given number
+	assertItem := func(item string, n int) {
+		suite.Contains(item, fmt.Sprintf("<title>Episode %d</title>", n))
+		suite.Contains(item, fmt.Sprintf("<guid>https://test.example.com/public/episode%d.mp3</guid>", n))
+		pubDate := now.Add(time.Duration(n-3) * time.Hour).Format(time.RFC1123Z)
+		suite.Contains(item, "<pubDate>"+pubDate+"</pubDate>")
+	}
+
+	suite.Run("NewestFirst", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.FeedSortOrder = entities.FeedSortNewest
+
+		// Act
+		items := build(cfg)
+
+		// Assert
+		assertItem(items[0], 3)
+		assertItem(items[1], 2)
+		assertItem(items[2], 1)
+	})
+
+	suite.Run("OldestFirst", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.FeedSortOrder = entities.FeedSortOldest
+
+		// Act
+		items := build(cfg)
+
+		// Assert
+		assertItem(items[0], 1)
+		assertItem(items[1], 2)
+		assertItem(items[2], 3)
+		suite.NotContains(items[0], "<itunes:episode>")
+	})
+
+	suite.Run("SerialOldestFirst", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.FeedType = entities.FeedTypeSerial
+		cfg.FeedSortOrder = entities.FeedSortNewest
+
+		// Act
+		items := build(cfg)
+
+		// Assert
+		for i, item := range items {
+			assertItem(item, i+1)
+			suite.Contains(item, fmt.Sprintf("<itunes:episode>%d</itunes:episode>", i+1))
+		}
+	})
+
+	suite.Run("SerialNumbering", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		cfg.FeedType = entities.FeedTypeSerial
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		episodes := []*entities.Episode{
+			{ID: 6, Title: "Episode 6", CreatedAt: now, MediaFile: "episode6.mp3", MediaSize: 1000, MediaType: entities.MediaMp3, SeasonNumber: 2},
+			{ID: 5, Title: "Episode 5", CreatedAt: now.Add(-time.Hour), MediaFile: "episode5.mp3", MediaSize: 1000, MediaType: entities.MediaMp3, SeasonNumber: 2, EpisodeNumber: 1},
+			{ID: 4, Title: "Episode 4", CreatedAt: now.Add(-2 * time.Hour), MediaFile: "episode4.mp3", MediaSize: 1000, MediaType: entities.MediaMp3},
+			{ID: 3, Title: "Episode 3", CreatedAt: now.Add(-3 * time.Hour), MediaFile: "missing.mp3", MediaType: entities.MediaMp3},
+			{ID: 2, Title: "Episode 2", CreatedAt: now.Add(-4 * time.Hour), MediaFile: "episode2.mp3", MediaSize: 1000, MediaType: entities.MediaMp3, EpisodeNumber: 1},
+			{ID: 1, Title: "Episode 1", CreatedAt: now.Add(-5 * time.Hour), MediaFile: "episode1.mp3", MediaSize: 1000, MediaType: entities.MediaMp3},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := service.Build(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		items := strings.Split(string(content), "<item>")[1:]
+		suite.Require().Len(items, 5, "episode without media must be skipped")
+		expected := []struct {
+			title           string
+			season, episode int
+		}{
+			{"Episode 1", 0, 2}, // Number 1 is used by episode 2
+			{"Episode 2", 0, 1},
+			{"Episode 4", 0, 3}, // Skipped episode 3 takes no number
+			{"Episode 5", 2, 1},
+			{"Episode 6", 2, 2}, // Numbered within the season
+		}
+		for i, e := range expected {
+			suite.Contains(items[i], "<title>"+e.title+"</title>")
+			suite.Contains(items[i], fmt.Sprintf("<itunes:episode>%d</itunes:episode>", e.episode))
+			if e.season > 0 {
+				suite.Contains(items[i], fmt.Sprintf("<itunes:season>%d</itunes:season>", e.season))
+			}
+		}
+	})
+
+	suite.Run("ExplicitEpisodeNumber", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		cfg.FeedType = entities.FeedTypeEpisodic
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		episodes := newEpisodes()
+		episodes[0].EpisodeNumber = 42
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := service.Build(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		items := strings.Split(string(content), "<item>")[1:]
+		suite.Require().Len(items, 3)
+		suite.Contains(items[0], "<itunes:episode>42</itunes:episode>")
+		suite.NotContains(items[1], "<itunes:episode>")
+		suite.NotContains(items[2], "<itunes:episode>")
+	})
+
+	suite.Run("SeasonNumber", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		episodes := newEpisodes()
+		episodes[0].EpisodeNumber, episodes[0].SeasonNumber = 2, 3
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := service.Build(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		items := strings.Split(string(content), "<item>")[1:]
+		suite.Require().Len(items, 3)
+		suite.Contains(items[0], "<itunes:season>3</itunes:season>")
+		suite.NotContains(items[1], "<itunes:season>")
+	})
+
+	suite.Run("OriginalPublishDate", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		episodes := newEpisodes() // A backlog downloaded in reverse order of publishing
+		published := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+		episodes[0].PublishedAt = published.AddDate(0, 0, -2)
+		episodes[2].PublishedAt = published
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := service.Build(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		items := strings.Split(string(content), "<item>")[1:]
+		suite.Require().Len(items, 3)
+		suite.Contains(items[0], "<title>Episode 2</title>", "episode without publish date is dated by download")
+		suite.Contains(items[0], "<pubDate>"+now.Add(-time.Hour).Format(time.RFC1123Z)+"</pubDate>")
+		suite.Contains(items[1], "<title>Episode 1</title>")
+		suite.Contains(items[1], "<pubDate>"+published.Format(time.RFC1123Z)+"</pubDate>")
+		suite.Contains(items[2], "<title>Episode 3</title>")
+		suite.Contains(items[2], "<pubDate>"+published.AddDate(0, 0, -2).Format(time.RFC1123Z)+"</pubDate>")
+	})
+}
+
+// TestBuild_Explicit tests that items inherit the explicit setting of the channel
+// unless the default explicit of the episodes is configured or the episode has its own value
+func (suite *TestFeedServiceSuite) TestBuild_Explicit() {
+	// build builds the feed of n episodes with the given config and returns the channel block and the item blocks.
+	// The first episode has the given explicit value
+	build := func(cfg config.Settings, n int, explicit *bool) (string, []string) {
+		cfg.PublicDir = suite.T().TempDir()
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		episodes := make([]*entities.Episode, n)
+		for i := range episodes {
+			episodes[i] = &entities.Episode{
+				ID:        int64(i + 1),
+				Title:     fmt.Sprintf("Episode %d", i+1),
+				CreatedAt: time.Now().Add(-time.Duration(i) * time.Hour),
+				MediaFile: fmt.Sprintf("episode%d.mp3", i+1),
+				MediaSize: 1000,
+				MediaType: entities.MediaMp3,
+			}
+		}
+		episodes[0].Explicit = explicit
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		suite.Require().NoError(service.Build(suite.ctx))
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		parts := strings.Split(string(content), "<item>")
+		suite.Require().Len(parts, n+1)
+		return parts[0], parts[1:]
+	}
+
+	suite.Run("ExplicitChannel", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.FeedIsExplicit = true
+
+		// Act
+		channel, items := build(cfg, 1, nil)
+
+		// Assert
+		suite.Contains(channel, "<itunes:explicit>true</itunes:explicit>")
+		suite.Contains(items[0], "<itunes:explicit>true</itunes:explicit>")
+	})
+
+	suite.Run("CleanChannel", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.FeedIsExplicit = false
+
+		// Act
+		channel, items := build(cfg, 1, nil)
+
+		// Assert
+		suite.Contains(channel, "<itunes:explicit>false</itunes:explicit>")
+		suite.Contains(items[0], "<itunes:explicit>false</itunes:explicit>")
+	})
+
+	suite.Run("EpisodeDefaultExplicit", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.FeedIsExplicit = false
+		explicit := true
+		cfg.EpisodeDefaultExplicit = &explicit
+
+		// Act
+		channel, items := build(cfg, 3, nil)
+
+		// Assert
+		suite.Contains(channel, "<itunes:explicit>false</itunes:explicit>")
+		for _, item := range items {
+			suite.Contains(item, "<itunes:explicit>true</itunes:explicit>")
+		}
+	})
+
+	suite.Run("EpisodeDefaultClean", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.FeedIsExplicit = true
+		explicit := false
+		cfg.EpisodeDefaultExplicit = &explicit
+
+		// Act
+		channel, items := build(cfg, 3, nil)
+
+		// Assert
+		suite.Contains(channel, "<itunes:explicit>true</itunes:explicit>")
+		for _, item := range items {
+			suite.Contains(item, "<itunes:explicit>false</itunes:explicit>")
+		}
+	})
+
+	suite.Run("EpisodeOverridesChannel", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.FeedIsExplicit = true
+		explicit := false
+
+		// Act
+		channel, items := build(cfg, 2, &explicit)
+
+		// Assert
+		suite.Contains(channel, "<itunes:explicit>true</itunes:explicit>")
+		suite.Contains(items[0], "<itunes:explicit>false</itunes:explicit>")
+		suite.Contains(items[1], "<itunes:explicit>true</itunes:explicit>", "episodes without a value must inherit the channel")
+	})
+
+	suite.Run("EpisodeOverridesDefault", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.FeedIsExplicit = false
+		defaultExplicit, explicit := true, false
+		cfg.EpisodeDefaultExplicit = &defaultExplicit
+
+		// Act
+		_, items := build(cfg, 2, &explicit)
+
+		// Assert
+		suite.Contains(items[0], "<itunes:explicit>false</itunes:explicit>", "episode value must win over the default")
+		suite.Contains(items[1], "<itunes:explicit>true</itunes:explicit>")
+	})
+}
+
+// TestBuild_MediaBaseUrl tests that media files are linked from the media base URL
+func (suite *TestFeedServiceSuite) TestBuild_MediaBaseUrl() {
+	episodes := []*entities.Episode{
+		{ID: 1, Title: "Episode 1", CreatedAt: time.Now(), MediaFile: "episode1.mp3", MediaSize: 1000, MediaType: entities.MediaMp3, ThumbnailFile: "thumb1.jpg"},
+	}
+
+	// build builds the feed with the given config and returns its content
+	build := func(cfg config.Settings) string {
+		cfg.PublicDir = suite.T().TempDir()
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		suite.Require().NoError(service.Build(suite.ctx))
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		return string(content)
+	}
+
+	suite.Run("DistinctHosts", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cdnUrl, err := url.Parse("https://cdn.example.net/media")
+		suite.Require().NoError(err)
+		cfg.MediaBaseUrl = *cdnUrl
+
+		// Act
+		content := build(cfg)
+
+		// Assert
+		suite.Contains(content, `<enclosure url="https://cdn.example.net/media/episode1.mp3"`)
+		suite.Contains(content, `<itunes:image href="https://cdn.example.net/media/thumb1.jpg">`)
+		suite.Contains(content, `<atom:link rel="self" href="https://test.example.com/public/feed.xml" type="application/rss+xml">`)
+		suite.NotContains(content, `<enclosure url="https://test.example.com/public/episode1.mp3"`)
+	})
+
+	suite.Run("StableGuid", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cdnUrl, err := url.Parse("https://cdn.example.net/media")
+		suite.Require().NoError(err)
+		cfg.MediaBaseUrl = *cdnUrl
+
+		// Act
+		before := build(*suite.cfg)
+		after := build(cfg)
+
+		// Assert
+		guid := `<guid>https://test.example.com/public/episode1.mp3</guid>`
+		suite.Contains(before, guid)
+		suite.Contains(after, guid, "GUID must not change with the media host")
+	})
+
+	suite.Run("FallbackToPublicUrl", func() {
+		// Act
+		content := build(*suite.cfg)
+
+		// Assert
+		suite.Contains(content, `<enclosure url="https://test.example.com/public/episode1.mp3"`)
+		suite.Contains(content, `<itunes:image href="https://test.example.com/public/thumb1.jpg">`)
+		suite.Contains(content, `<atom:link rel="self" href="https://test.example.com/public/feed.xml" type="application/rss+xml">`)
+	})
+}
+
+// TestBuild_RssImage tests the standard RSS image of the channel
+func (suite *TestFeedServiceSuite) TestBuild_RssImage() {
+	// build builds the feed with the given config and returns its content
+	build := func(cfg config.Settings) string {
+		cfg.PublicDir = suite.T().TempDir()
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		episodes := []*entities.Episode{
+			{ID: 1, Title: "Episode 1", CreatedAt: time.Now(), MediaFile: "episode1.mp3", MediaSize: 1000, MediaType: entities.MediaMp3},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		suite.Require().NoError(service.Build(suite.ctx))
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		return string(content)
+	}
+
+	suite.Run("Enabled", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.FeedRssImage = true
+
+		// Act
+		content := build(cfg)
+
+		// Assert
+		suite.Contains(content, `<itunes:image href="https://test.example.com/cover.jpg"></itunes:image>`)
+		suite.Contains(content, "<url>https://test.example.com/cover.jpg</url>")
+		suite.Contains(content, "<title>Test Podcast</title>\n      <link>https://test.example.com</link>\n    </image>")
+	})
+
+	suite.Run("Disabled", func() {
+		// Act
+		content := build(*suite.cfg)
+
+		// Assert
+		suite.Contains(content, `<itunes:image href="https://test.example.com/cover.jpg"></itunes:image>`)
+		suite.NotContains(content, "<image>")
+	})
+}
+
+// TestBuild_Links tests that the website link and the self link of the channel are set independently
+func (suite *TestFeedServiceSuite) TestBuild_Links() {
+	suite.Run("DistinctUrls", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		cfg.FeedLink = "https://www.example.org/show"
+		publicUrl, err := url.Parse("https://feeds.example.net/podcast")
+		suite.Require().NoError(err)
+		cfg.PublicUrl = *publicUrl
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		episodes := []*entities.Episode{
+			{ID: 1, Title: "Episode 1", CreatedAt: time.Now(), MediaFile: "episode1.mp3", MediaSize: 1000, MediaType: entities.MediaMp3},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		suite.Require().NoError(service.Build(suite.ctx))
+
+		// Assert
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		suite.Contains(string(content), "<link>https://www.example.org/show</link>")
+		suite.Contains(string(content),
+			`<atom:link rel="self" href="https://feeds.example.net/podcast/feed.xml" type="application/rss+xml">`)
+		suite.NotContains(string(content), `<atom:link rel="self" href="https://www.example.org`)
+	})
+}
+
+// TestBuild_CopyrightAndOwner tests the copyright and owner of the channel
+func (suite *TestFeedServiceSuite) TestBuild_CopyrightAndOwner() {
+	// build builds the feed with the given config and returns its content and the feed info
+	build := func(cfg config.Settings) (string, *entities.Feed) {
+		cfg.PublicDir = suite.T().TempDir()
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		episodes := []*entities.Episode{
+			{ID: 1, Title: "Episode 1", CreatedAt: time.Now(), MediaFile: "episode1.mp3", MediaSize: 1000, MediaType: entities.MediaMp3},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+		suite.mockStore.On("EpisodeCountAll", suite.ctx).Return(len(episodes), nil)
+		suite.mockStore.On("EpisodeGetLastTime", suite.ctx).Return(episodes[0].CreatedAt, nil)
+
+		suite.Require().NoError(service.Build(suite.ctx))
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		feed, err := service.Feed(suite.ctx)
+		suite.Require().NoError(err)
+		return string(content), feed
+	}
+
+	suite.Run("Configured", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.FeedCopyright = "© 2025 Test Author"
+		cfg.FeedOwnerName = "Test Owner"
+		cfg.FeedOwnerEmail = "owner@test.example.com"
+
+		// Act
+		content, feed := build(cfg)
+
+		// Assert
+		suite.Contains(content, "<copyright>© 2025 Test Author</copyright>")
+		suite.Contains(content, "<itunes:name>Test Owner</itunes:name>")
+		suite.Contains(content, "<itunes:email>owner@test.example.com</itunes:email>")
+		suite.Equal("© 2025 Test Author", feed.Copyright)
+		suite.Equal(&entities.FeedOwner{Name: "Test Owner", Email: "owner@test.example.com"}, feed.Owner)
+	})
+
+	suite.Run("NotConfigured", func() {
+		// Act
+		content, feed := build(*suite.cfg)
+
+		// Assert
+		suite.NotContains(content, "<copyright>")
+		suite.NotContains(content, "<itunes:owner>")
+		suite.Empty(feed.Copyright)
+		suite.Nil(feed.Owner)
+	})
+}
+
+// TestBuild_BlockAndComplete tests the block and complete status of the channel
+func (suite *TestFeedServiceSuite) TestBuild_BlockAndComplete() {
+	// build builds the feed with the given config and returns its content and the feed info
+	build := func(cfg config.Settings) (string, *entities.Feed) {
+		cfg.PublicDir = suite.T().TempDir()
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		episodes := []*entities.Episode{
+			{ID: 1, Title: "Episode 1", CreatedAt: time.Now(), MediaFile: "episode1.mp3", MediaSize: 1000, MediaType: entities.MediaMp3},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+		suite.mockStore.On("EpisodeCountAll", suite.ctx).Return(len(episodes), nil)
+		suite.mockStore.On("EpisodeGetLastTime", suite.ctx).Return(episodes[0].CreatedAt, nil)
+
+		suite.Require().NoError(service.Build(suite.ctx))
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		feed, err := service.Feed(suite.ctx)
+		suite.Require().NoError(err)
+		return string(content), feed
+	}
+
+	suite.Run("Enabled", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.FeedBlocked = true
+		cfg.FeedComplete = true
+
+		// Act
+		content, feed := build(cfg)
+
+		// Assert
+		suite.Contains(content, "<itunes:block>Yes</itunes:block>")
+		suite.Contains(content, "<itunes:complete>Yes</itunes:complete>")
+		suite.True(feed.FeedBlocked)
+		suite.True(feed.FeedCompleted)
+	})
+
+	suite.Run("Disabled", func() {
+		// Act
+		content, feed := build(*suite.cfg)
+
+		// Assert
+		suite.NotContains(content, "<itunes:block>")
+		suite.NotContains(content, "<itunes:complete>")
+		suite.False(feed.FeedBlocked)
+		suite.False(feed.FeedCompleted)
+	})
+}
+
+// TestBuild_MediaSizeBackfill tests that the missing media size is backfilled from the media file
+func (suite *TestFeedServiceSuite) TestBuild_MediaSizeBackfill() {
+	newService := func() (*FeedService, *config.Settings) {
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		return NewFeedService(&cfg, suite.log, suite.mockStore), &cfg
+	}
+
+	suite.Run("Backfilled", func() {
+		// Arrange
+		service, cfg := newService()
+		suite.Require().NoError(os.WriteFile(filepath.Join(cfg.PublicDir, "episode2.mp3"), make([]byte, 2048), 0644))
+		episodes := []*entities.Episode{
+			{ID: 2, Title: "Zero Size Episode", CreatedAt: time.Now(), MediaFile: "episode2.mp3", MediaSize: 0, MediaType: entities.MediaMp3},
+			{ID: 1, Title: "Good Episode", CreatedAt: time.Now().Add(-time.Hour), MediaFile: "episode1.mp3", MediaSize: 1000, MediaType: entities.MediaMp3},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := service.Build(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		suite.Contains(string(content), `<enclosure url="https://test.example.com/public/episode2.mp3" length="2048" type="audio/mpeg">`)
+		suite.Contains(string(content), `<enclosure url="https://test.example.com/public/episode1.mp3" length="1000" type="audio/mpeg">`)
+		suite.Zero(episodes[0].MediaSize, "episode entity must not be modified")
+	})
+
+	suite.Run("MissingFileSkipped", func() {
+		// Arrange
+		service, cfg := newService()
+		episodes := []*entities.Episode{
+			{ID: 2, Title: "Missing Episode", CreatedAt: time.Now(), MediaFile: "episode2.mp3", MediaSize: 0, MediaType: entities.MediaMp3},
+			{ID: 1, Title: "Good Episode", CreatedAt: time.Now().Add(-time.Hour), MediaFile: "episode1.mp3", MediaSize: 1000, MediaType: entities.MediaMp3},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := service.Build(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		suite.NotContains(string(content), "Missing Episode")
+		suite.Contains(string(content), "Good Episode")
+	})
+
+	suite.Run("AllSkipped", func() {
+		// Arrange
+		service, _ := newService()
+		episodes := []*entities.Episode{
+			{ID: 1, Title: "Missing Episode", CreatedAt: time.Now(), MediaFile: "episode1.mp3", MediaSize: 0, MediaType: entities.MediaMp3},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := service.Build(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrEmptyFeed)
+	})
+}
+
+// TestBuild_SkipInvalid tests that invalid episodes are skipped if configured
+func (suite *TestFeedServiceSuite) TestBuild_SkipInvalid() {
+	newService := func(skip bool) (*FeedService, *config.Settings) {
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		cfg.FeedSkipInvalid = skip
+		return NewFeedService(&cfg, suite.log, suite.mockStore), &cfg
+	}
+	episodes := []*entities.Episode{
+		{ID: 2, Title: "", CreatedAt: time.Now(), MediaFile: "episode2.mp3", MediaSize: 1000, MediaType: entities.MediaMp3},
+		{ID: 1, Title: "Good Episode", CreatedAt: time.Now().Add(-time.Hour), MediaFile: "episode1.mp3", MediaSize: 1000, MediaType: entities.MediaMp3},
+	}
+
+	suite.Run("Enabled", func() {
+		// Arrange
+		service, cfg := newService(true)
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := service.Build(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		suite.Contains(string(content), "Good Episode")
+		suite.NotContains(string(content), "episode2.mp3")
+	})
+
+	suite.Run("Disabled", func() {
+		// Arrange
+		service, cfg := newService(false)
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := service.Build(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrFeedInvalid)
+		suite.NoFileExists(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+	})
+}
+
+// TestCreateItem_Duration tests that the unknown duration of the episode is omitted
+func (suite *TestFeedServiceSuite) TestCreateItem_Duration() {
+	tests := []struct {
+		name     string
+		duration int64
+		want     string
+	}{
+		{"Positive", 3600, "<itunes:duration>3600</itunes:duration>"},
+		{"Zero", 0, ""},
+		{"Negative", -1, ""},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Arrange
+			service := NewFeedService(suite.cfg, suite.log, suite.mockStore)
+			feed := service.createFeed()
+
+			// Act
+			item, err := service.createItem(&entities.Episode{
+				Title:         "Episode",
+				MediaFile:     "episode.mp3",
+				MediaSize:     1024,
+				MediaType:     entities.MediaMp3,
+				MediaDuration: tt.duration,
+				CreatedAt:     time.Now(),
+			})
+			suite.Require().NoError(err)
+			feed.AddItem(item)
+
+			// Assert
+			var buf bytes.Buffer
+			suite.Require().NoError(feed.Encode(&buf))
+			if tt.want == "" {
+				suite.NotContains(buf.String(), "<itunes:duration>")
+			} else {
+				suite.Contains(buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+// TestCreateItem_Author tests that the item author falls back to the feed author
+func (suite *TestFeedServiceSuite) TestCreateItem_Author() {
+	tests := []struct {
+		name   string
+		author string
+		want   string
+	}{
+		{"EpisodeAuthor", "Episode Author", "Episode Author"},
+		{"NoEpisodeAuthor", "", "Test Author"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Arrange
+			service := NewFeedService(suite.cfg, suite.log, suite.mockStore)
+			feed := service.createFeed()
+
+			// Act
+			item, err := service.createItem(&entities.Episode{
+				Title:     "Episode",
+				Author:    tt.author,
+				MediaFile: "episode.mp3",
+				MediaSize: 1024,
+				MediaType: entities.MediaMp3,
+				CreatedAt: time.Now(),
+			})
+			suite.Require().NoError(err)
+			feed.AddItem(item)
+
+			// Assert
+			var buf bytes.Buffer
+			suite.Require().NoError(feed.Encode(&buf))
+			var rss struct {
+				Items []struct {
+					Author string `xml:"author"`
+				} `xml:"channel>item"`
+			}
+			suite.Require().NoError(xml.Unmarshal(buf.Bytes(), &rss))
+			suite.Require().Len(rss.Items, 1)
+			suite.Equal(tt.want, rss.Items[0].Author)
+		})
+	}
+}
+
+// TestCreateItem_Summary tests that a long summary is truncated on a word boundary
+func (suite *TestFeedServiceSuite) TestCreateItem_Summary() {
+	// Arrange
+	service := NewFeedService(suite.cfg, suite.log, suite.mockStore)
+	feed := service.createFeed()
+	description := strings.Repeat("word ", feedcast.MaxItemSummaryLen)
+
+	// Act
+	item, err := service.createItem(&entities.Episode{
+		Title:       "Episode",
+		Description: description,
+		MediaFile:   "episode.mp3",
+		MediaSize:   1024,
+		MediaType:   entities.MediaMp3,
+		CreatedAt:   time.Now(),
+	})
+	suite.Require().NoError(err)
+	feed.AddItem(item)
+
+	// Assert
+	var buf bytes.Buffer
+	suite.Require().NoError(feed.Encode(&buf))
+	var rss struct {
+		Items []struct {
+			Summary string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+		} `xml:"channel>item"`
+	}
+	suite.Require().NoError(xml.Unmarshal(buf.Bytes(), &rss))
+	suite.Require().Len(rss.Items, 1)
+	suite.LessOrEqual(len(rss.Items[0].Summary), feedcast.MaxItemSummaryLen)
+	suite.True(strings.HasSuffix(rss.Items[0].Summary, "word…"))
+}
+
+// TestCreateItem_UrlEncoding tests that the media and thumbnail file names are escaped in the item URLs
+func (suite *TestFeedServiceSuite) TestCreateItem_UrlEncoding() {
+	tests := []struct {
+		name      string
+		mediaFile string
+		wantUrl   string
+	}{
+		{"Plain", "episode.mp3", "https://test.example.com/public/episode.mp3"},
+		{"SpacesAndAmpersand", "my episode & more.mp3", "https://test.example.com/public/my%20episode%20&%20more.mp3"},
+		{"Percent", "100%25 real.mp3", "https://test.example.com/public/100%2525%20real.mp3"},
+		{"NonASCII", "выпуск.mp3", "https://test.example.com/public/%D0%B2%D1%8B%D0%BF%D1%83%D1%81%D0%BA.mp3"},
+		{"Reserved", "a/../b?c#d.mp3", "https://test.example.com/public/a%2F..%2Fb%3Fc%23d.mp3"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Arrange
+			service := NewFeedService(suite.cfg, suite.log, suite.mockStore)
+			feed := service.createFeed()
+			thumbFile := strings.TrimSuffix(tt.mediaFile, ".mp3") + ".jpg"
+
+			// Act
+			item, err := service.createItem(&entities.Episode{
+				Title:         "Episode",
+				MediaFile:     tt.mediaFile,
+				ThumbnailFile: thumbFile,
+				MediaSize:     1024,
+				MediaType:     entities.MediaMp3,
+				CreatedAt:     time.Now(),
+			})
+			suite.Require().NoError(err)
+			feed.AddItem(item)
+
+			// Assert
+			var buf bytes.Buffer
+			suite.Require().NoError(feed.Encode(&buf))
+			var rss struct {
+				Items []struct {
+					Enclosure struct {
+						Url string `xml:"url,attr"`
+					} `xml:"enclosure"`
+					Image struct {
+						Href string `xml:"href,attr"`
+					} `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+				} `xml:"channel>item"`
+			}
+			suite.Require().NoError(xml.Unmarshal(buf.Bytes(), &rss))
+			suite.Require().Len(rss.Items, 1)
+			suite.Equal(tt.wantUrl, rss.Items[0].Enclosure.Url)
+
+			enclosureUrl, err := url.Parse(rss.Items[0].Enclosure.Url)
+			suite.Require().NoError(err)
+			suite.Equal("/public/"+tt.mediaFile, enclosureUrl.Path)
+			imageUrl, err := url.Parse(rss.Items[0].Image.Href)
+			suite.Require().NoError(err)
+			suite.Equal("/public/"+thumbFile, imageUrl.Path)
+		})
+	}
+}
+
+// TestInit tests the Init method
+func (suite *TestFeedServiceSuite) TestInit() {
+	tests := []struct {
+		name    string
+		image   string
+		wantErr bool
+	}{
+		{"NotSet", "", false},
+		{"Absolute", "https://cdn.example.com/placeholder.jpg", false},
+		{"Relative", "/placeholder.jpg", true},
+		{"NoHost", "https:///placeholder.jpg", true},
+		{"UnsupportedScheme", "ftp://cdn.example.com/placeholder.jpg", true},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Arrange
+			cfg := *suite.cfg
+			cfg.DefaultEpisodeImage = tt.image
+			service := NewFeedService(&cfg, suite.log, suite.mockStore)
+
+			// Act
+			err := service.Init(suite.ctx)
+
+			// Assert
+			if tt.wantErr {
+				suite.ErrorContains(err, "default episode image must be an absolute http(s) URL")
+			} else {
+				suite.NoError(err)
+			}
+		})
+	}
+}
+
+// TestBuild_DefaultEpisodeImage tests that episodes without thumbnail get the default image
+func (suite *TestFeedServiceSuite) TestBuild_DefaultEpisodeImage() {
+	suite.Run("ThumbnailMissing", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		cfg.DefaultEpisodeImage = "https://cdn.example.com/placeholder.jpg"
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		episodes := []*entities.Episode{
+			{ID: 2, Title: "No Thumbnail", CreatedAt: time.Now(), MediaFile: "episode2.mp3", MediaSize: 1000, MediaType: entities.MediaMp3},
+			{ID: 1, Title: "With Thumbnail", CreatedAt: time.Now().Add(-time.Hour), MediaFile: "episode1.mp3", ThumbnailFile: "thumb1.jpg",
+				MediaSize: 1000, MediaType: entities.MediaMp3},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := service.Build(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		var rss struct {
+			Items []struct {
+				Title string `xml:"title"`
+				Image struct {
+					Href string `xml:"href,attr"`
+				} `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+			} `xml:"channel>item"`
+		}
+		suite.Require().NoError(xml.Unmarshal(content, &rss))
+		suite.Require().Len(rss.Items, 2)
+		suite.Equal("No Thumbnail", rss.Items[0].Title)
+		suite.Equal("https://cdn.example.com/placeholder.jpg", rss.Items[0].Image.Href)
+		suite.Equal("https://test.example.com/public/thumb1.jpg", rss.Items[1].Image.Href)
+	})
+}
+
+// TestBuild_MaxTitleLength tests that long episode titles are truncated in the feed only
+func (suite *TestFeedServiceSuite) TestBuild_MaxTitleLength() {
+	suite.Run("Truncated", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		cfg.MaxTitleLength = 30
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		title := "A very long video title that goes on " + strings.Repeat("and on ", 50) + "forever"
+		episode := &entities.Episode{ID: 1, Title: title, CreatedAt: time.Now(), MediaFile: "episode1.mp3",
+			MediaSize: 1000, MediaType: entities.MediaMp3}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return([]*entities.Episode{episode}, nil)
+
+		// Act
+		err := service.Build(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		content, err := os.ReadFile(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+		suite.Require().NoError(err)
+		suite.Contains(string(content), "<title>A very long video title that…</title>")
+		suite.Contains(string(content), "<itunes:title>A very long video title that…</itunes:title>")
+		suite.NotContains(string(content), "forever")
+		suite.Equal(title, episode.Title, "stored episode title must be kept intact")
+	})
+}
+
+// TestValidate tests the Validate method
+func (suite *TestFeedServiceSuite) TestValidate() {
+	newService := func() (*FeedService, string) {
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		return NewFeedService(&cfg, suite.log, suite.mockStore), filepath.Join(cfg.PublicDir, cfg.FeedFileName)
+	}
+	episodes := []*entities.Episode{
+		{ID: 1, Title: "Valid Episode", CreatedAt: time.Now(), MediaFile: "episode1.mp3", MediaSize: 1024000, MediaType: entities.MediaMp3},
+	}
+
+	suite.Run("Valid", func() {
+		// Arrange
+		service, feedPath := newService()
+		episodes := []*entities.Episode{
+			{
+				ID:        1,
+				Title:     "Valid Episode",
+				CreatedAt: time.Now(),
+				MediaFile: "episode1.mp3",
+				MediaSize: 1024000,
+				MediaType: entities.MediaMp3,
+			},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := service.Validate(suite.ctx)
+
+		// Assert
+		suite.NoError(err)
+		suite.NoFileExists(feedPath)
+	})
+
+	suite.Run("InvalidEpisode", func() {
+		// Arrange
+		service, feedPath := newService()
+		episodes := []*entities.Episode{
+			{
+				ID:        1,
+				Title:     "", // Invalid: empty title
+				CreatedAt: time.Now(),
+				MediaFile: "episode1.mp3",
+				MediaSize: 1024000,
+				MediaType: entities.MediaMp3,
+			},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := service.Validate(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrFeedInvalid)
+		suite.Contains(err.Error(), "item title is required")
+		var ve *feedcast.ValidationError
+		suite.Require().ErrorAs(err, &ve)
+		suite.Equal("item.title", ve.Field)
+		suite.NoFileExists(feedPath)
+	})
+
+	suite.Run("InvalidEpisodeSkipped", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		cfg.FeedSkipInvalid = true
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		episodes := []*entities.Episode{
+			{ID: 2, Title: "", CreatedAt: time.Now(), MediaFile: "episode2.mp3", MediaSize: 1024000, MediaType: entities.MediaMp3},
+			{ID: 1, Title: "Valid Episode", CreatedAt: time.Now().Add(-time.Hour), MediaFile: "episode1.mp3", MediaSize: 1024000, MediaType: entities.MediaMp3},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := service.Validate(suite.ctx)
+
+		// Assert
+		suite.NoError(err, "validation must agree with the published feed")
+		suite.NoFileExists(filepath.Join(cfg.PublicDir, cfg.FeedFileName))
+	})
+
+	suite.Run("NoEpisodes", func() {
+		// Arrange
+		service, feedPath := newService()
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return([]*entities.Episode{}, nil)
+
+		// Act
+		err := service.Validate(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrEmptyFeed)
+		suite.NoFileExists(feedPath)
+	})
+
+	suite.Run("StoreFailure", func() {
+		// Arrange
+		service, _ := newService()
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(nil, errors.New("store error"))
+
+		// Act
+		err := service.Validate(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrEpisodeListAll)
+	})
+
+	suite.Run("ArtworkChecked", func() {
+		// Arrange
+		artwork := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			suite.Equal(http.MethodHead, r.Method)
+			w.Header().Set("Content-Type", "image/jpeg")
+		}))
+		defer artwork.Close()
+		cfg := *suite.cfg
+		cfg.FeedImage = artwork.URL + "/cover.jpg"
+		cfg.FeedCheckArtwork = true
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		err := service.Validate(suite.ctx)
+
+		// Assert
+		suite.NoError(err)
+	})
+
+	suite.Run("ArtworkTimeout", func() {
+		// Arrange
+		release := make(chan struct{})
+		artwork := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer artwork.Close()
+		defer close(release)
+		cfg := *suite.cfg
+		cfg.FeedImage = artwork.URL + "/cover.jpg"
+		cfg.FeedCheckArtwork = true
+		cfg.HttpTimeout = 50 * time.Millisecond
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		started := time.Now()
+		err := service.Validate(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrFeedInvalid)
+		suite.Contains(err.Error(), "is not reachable")
+		suite.Less(time.Since(started), time.Second)
+	})
+}
+
+// TestFeedBytes tests the FeedBytes method
+func (suite *TestFeedServiceSuite) TestFeedBytes() {
+	newService := func() (*FeedService, string) {
+		cfg := *suite.cfg
+		cfg.PublicDir = suite.T().TempDir()
+		return NewFeedService(&cfg, suite.log, suite.mockStore), filepath.Join(cfg.PublicDir, cfg.FeedFileName)
+	}
+
+	suite.Run("Success", func() {
+		// Arrange
+		service, feedPath := newService()
+		episodes := []*entities.Episode{
+			{
+				ID:        1,
+				Title:     "Test Episode 1",
+				CreatedAt: time.Now(),
+				MediaFile: "episode1.mp3",
+				MediaSize: 1024000,
+				MediaType: entities.MediaMp3,
+			},
+			{
+				ID:        2,
+				Title:     "Test Episode 2",
+				CreatedAt: time.Now().Add(-time.Hour),
+				MediaFile: "episode2.mp3",
+				MediaSize: 512000,
+				MediaType: entities.MediaMp3,
+			},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		data, _, _, err := service.FeedBytes(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		var rss struct {
+			XMLName xml.Name `xml:"rss"`
+			Version string   `xml:"version,attr"`
+			Items   []struct {
+				Title string `xml:"title"`
+			} `xml:"channel>item"`
+		}
+		suite.Require().NoError(xml.Unmarshal(data, &rss), "feed must be valid XML")
+		suite.Equal("2.0", rss.Version)
+		suite.Require().Len(rss.Items, 2)
+		suite.Equal("Test Episode 1", rss.Items[0].Title)
+		suite.Equal("Test Episode 2", rss.Items[1].Title)
+		suite.Contains(string(data), "episode1.mp3")
+		suite.NoFileExists(feedPath)
+	})
+
+	suite.Run("InvalidEpisode", func() {
+		// Arrange
+		service, _ := newService()
+		episodes := []*entities.Episode{
+			{
+				ID:        1,
+				Title:     "", // Invalid: empty title
+				CreatedAt: time.Now(),
+				MediaFile: "episode1.mp3",
+				MediaSize: 1024000,
+				MediaType: entities.MediaMp3,
+			},
+		}
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+
+		// Act
+		data, _, _, err := service.FeedBytes(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrFeedInvalid)
+		suite.Nil(data)
+	})
+
+	suite.Run("NoEpisodes", func() {
+		// Arrange
+		service, _ := newService()
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return([]*entities.Episode{}, nil)
+
+		// Act
+		data, _, _, err := service.FeedBytes(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrEmptyFeed)
+		suite.Nil(data)
+	})
+
+	suite.Run("StoreFailure", func() {
+		// Arrange
+		service, _ := newService()
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(nil, errors.New("store error"))
+
+		// Act
+		data, _, _, err := service.FeedBytes(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrEpisodeListAll)
+		suite.Nil(data)
+	})
+}
+
+// TestFeedBytes_Conditional tests the values for conditional HTTP requests returned by FeedBytes
+func (suite *TestFeedServiceSuite) TestFeedBytes_Conditional() {
+	now := time.Now()
+	episode := func(id int64, createdAt time.Time) *entities.Episode {
+		return &entities.Episode{
+			ID:        id,
+			Title:     fmt.Sprintf("Episode %d", id),
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+			MediaFile: fmt.Sprintf("episode%d.mp3", id),
+			MediaSize: 1024,
+			MediaType: entities.MediaMp3,
+		}
+	}
+	// feedBytesOf returns the feed of the service for the given episode set
+	feedBytesOf := func(service *FeedService, episodes []*entities.Episode) ([]byte, string, time.Time, error) {
+		suite.mockStore.ExpectedCalls = nil
+		suite.mockStore.On("EpisodeListAll", suite.ctx).Return(episodes, nil)
+		return service.FeedBytes(suite.ctx)
+	}
+	// etagOf returns the etag for the given episode set
+	etagOf := func(episodes []*entities.Episode) (string, error) {
+		_, etag, _, err := feedBytesOf(suite.service, episodes)
+		return etag, err
+	}
+
+	suite.Run("UnchangedEpisodes", func() {
+		// Act
+		data1, etag1, lastMod1, err1 := feedBytesOf(suite.service, []*entities.Episode{episode(2, now), episode(1, now.Add(-time.Hour))})
+		data2, etag2, lastMod2, err2 := feedBytesOf(suite.service, []*entities.Episode{episode(2, now), episode(1, now.Add(-time.Hour))})
+
+		// Assert
+		suite.Require().NoError(err1)
+		suite.Require().NoError(err2)
+		suite.Equal(data1, data2, "feed bytes must not change between requests")
+		suite.Equal(etag1, etag2)
+		suite.Regexp(`^"[0-9a-f]{32}"$`, etag1)
+		sum := sha256.Sum256(data1)
+		suite.Equal(`"`+hex.EncodeToString(sum[:16])+`"`, etag1, "etag must be of the returned bytes")
+		suite.Equal(now, lastMod1)
+		suite.Equal(lastMod1, lastMod2)
+	})
+
+	suite.Run("UpdatedEpisode", func() {
+		// Arrange
+		updated := episode(1, now.Add(-time.Hour))
+		updated.UpdatedAt = now.Add(time.Minute)
+
+		// Act
+		data, _, lastMod, err := feedBytesOf(suite.service, []*entities.Episode{episode(2, now), updated})
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(now.Add(time.Minute), lastMod, "last modified must include the episode updates")
+		suite.Contains(string(data), "<lastBuildDate>"+now.Add(time.Minute).Format(time.RFC1123Z)+"</lastBuildDate>")
+	})
+
+	suite.Run("ChangedEpisodes", func() {
+		// Arrange
+		base := []*entities.Episode{episode(2, now), episode(1, now.Add(-time.Hour))}
+		changed := map[string][]*entities.Episode{
+			"added":     {episode(3, now.Add(time.Minute)), episode(2, now), episode(1, now.Add(-time.Hour))},
+			"removed":   {episode(2, now)},
+			"replaced":  {episode(3, now), episode(1, now.Add(-time.Hour))},
+			"recreated": {episode(2, now.Add(time.Second)), episode(1, now.Add(-time.Hour))},
+			"updated":   {episode(2, now), episode(1, now.Add(-time.Hour))},
+		}
+		changed["updated"][0].Title = "Updated title"
+		changed["updated"][0].UpdatedAt = now.Add(time.Minute)
+
+		// Act
+		etag, err := etagOf(base)
+
+		// Assert
+		suite.Require().NoError(err)
+		for name, episodes := range changed {
+			changedEtag, err := etagOf(episodes)
+			suite.Require().NoError(err, name)
+			suite.NotEqual(etag, changedEtag, name)
+		}
+	})
+
+	suite.Run("ChangedSettings", func() {
+		// Arrange
+		episodes := []*entities.Episode{episode(1, now)}
+		cfg := *suite.cfg
+		cfg.FeedTitle = "Renamed Podcast"
+
+		// Act
+		etag, err := etagOf(episodes)
+		_, changedEtag, _, changedErr := feedBytesOf(NewFeedService(&cfg, suite.log, suite.mockStore), episodes)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().NoError(changedErr)
+		suite.NotEqual(etag, changedEtag)
+	})
+}
+
+// TestSaveFeed tests the saveFeed method
+func (suite *TestFeedServiceSuite) TestSaveFeed() {
+	suite.Run("EncodeFailureKeepsOriginal", func() {
+		// Arrange
+		dir := suite.T().TempDir()
+		cfg := *suite.cfg
+		cfg.PublicDir = dir
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+
+		feedPath := filepath.Join(dir, cfg.FeedFileName)
+		original := []byte("<rss>original</rss>")
+		suite.Require().NoError(os.WriteFile(feedPath, original, 0644))
+
+		// Feed without items fails validation on encode
+		feed := service.createFeed()
+
+		// Act
+		_, err := service.saveFeed(feed)
+
+		// Assert
+		suite.Error(err)
+		suite.Contains(err.Error(), "failed to encode feed to file")
+		content, err := os.ReadFile(feedPath)
+		suite.Require().NoError(err)
+		suite.Equal(original, content)
+		entries, err := os.ReadDir(dir)
+		suite.Require().NoError(err)
+		suite.Len(entries, 1, "temporary feed file must be removed")
+	})
+
+	suite.Run("ReplacesExisting", func() {
+		// Arrange
+		dir := suite.T().TempDir()
+		cfg := *suite.cfg
+		cfg.PublicDir = dir
+		service := NewFeedService(&cfg, suite.log, suite.mockStore)
+
+		feedPath := filepath.Join(dir, cfg.FeedFileName)
+		suite.Require().NoError(os.WriteFile(feedPath, []byte("<rss>original</rss>"), 0644))
+
+		feed := service.createFeed()
+		item, err := service.createItem(&entities.Episode{
+			Title:     "New Episode",
+			MediaFile: "episode.mp3",
+			MediaSize: 1024,
+			MediaType: entities.MediaMp3,
+			CreatedAt: time.Now(),
+		})
+		suite.Require().NoError(err)
+		feed.AddItem(item)
+
+		// Act
+		size, err := service.saveFeed(feed)
+
+		// Assert
+		suite.Require().NoError(err)
+		content, err := os.ReadFile(feedPath)
+		suite.Require().NoError(err)
+		suite.Contains(string(content), "New Episode")
+		info, err := os.Stat(feedPath)
+		suite.Require().NoError(err)
+		suite.Equal(os.FileMode(feedFileMode), info.Mode().Perm())
+		suite.Equal(info.Size(), size)
+		entries, err := os.ReadDir(dir)
+		suite.Require().NoError(err)
+		suite.Len(entries, 1, "temporary feed file must be removed")
+	})
+}
+
+// noSpaceWriter is an io.Writer failing as if the disk is full
+type noSpaceWriter struct{}
+
+func (noSpaceWriter) Write([]byte) (int, error) {
+	return 0, &os.PathError{Op: "write", Path: "rss.xml", Err: syscall.ENOSPC}
+}
+
+// TestIOError tests that I/O errors of the full disk are surfaced as ErrDiskFull
+func (suite *TestFeedServiceSuite) TestIOError() {
+	suite.Run("DiskFull", func() {
+		// Arrange
+		service := NewFeedService(suite.cfg, suite.log, suite.mockStore)
+		feed := service.createFeed()
+		item, err := service.createItem(&entities.Episode{
+			Title:     "Episode",
+			MediaFile: "episode.mp3",
+			MediaSize: 1024,
+			MediaType: entities.MediaMp3,
+			CreatedAt: time.Now(),
+		})
+		suite.Require().NoError(err)
+		feed.AddItem(item)
+
+		// Act
+		err = ioError(ErrFeedSave, feed.Encode(noSpaceWriter{}))
+
+		// Assert
+		suite.ErrorIs(err, ErrDiskFull)
+		suite.ErrorIs(err, syscall.ENOSPC)
+		suite.NotErrorIs(err, ErrFeedSave)
+	})
+
+	suite.Run("OtherError", func() {
+		// Act
+		err := ioError(ErrFeedSave, os.ErrPermission)
+
+		// Assert
+		suite.ErrorIs(err, ErrFeedSave)
+		suite.ErrorIs(err, os.ErrPermission)
+		suite.NotErrorIs(err, ErrDiskFull)
+	})
+}
+
+// TestRecentEpisodes tests the RecentEpisodes method
+func (suite *TestFeedServiceSuite) TestRecentEpisodes() {
+	suite.Run("Success", func() {
+		// Arrange
+		episodes := []*entities.Episode{{ID: 2, Title: "Episode 2"}, {ID: 1, Title: "Episode 1"}}
+		suite.mockStore.On("EpisodeList", suite.ctx, 10, 20).Return(episodes, nil)
+
+		// Act
+		result, err := suite.service.RecentEpisodes(suite.ctx, 10, 20)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(episodes, result)
+	})
+
+	suite.Run("StoreError", func() {
+		// Arrange
+		expectedErr := errors.New("list failed")
+		suite.mockStore.On("EpisodeList", suite.ctx, 10, 0).Return(nil, expectedErr)
+
+		// Act
+		_, err := suite.service.RecentEpisodes(suite.ctx, 10, 0)
+
+		// Assert
+		suite.ErrorIs(err, ErrEpisodeList)
 		suite.ErrorIs(err, expectedErr)
 	})
 }
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ofstudio/voxify/internal/config"
+)
+
+const defaultHttpTimeout = 10 * time.Second // Timeout of outgoing HTTP requests if cfg.HttpTimeout is not set
+
+// newHttpClient creates the HTTP client for the outgoing requests of the services,
+// such as WebSub hub notifications and artwork checks.
+// Each request is bounded by cfg.HttpTimeout, retries included,
+// and is retried up to cfg.HttpRetries times on network errors and server errors.
+func newHttpClient(cfg *config.Settings) *http.Client {
+	timeout := cfg.HttpTimeout
+	if timeout <= 0 {
+		timeout = defaultHttpTimeout
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &retryTransport{
+			base:    http.DefaultTransport,
+			retries: cfg.HttpRetries,
+			delay:   cfg.HttpRetryDelay,
+		},
+	}
+}
+
+// retryTransport is a http.RoundTripper retrying the failed requests.
+// A request is retried if the transport fails or the response status is 429 or 5xx.
+// Requests with a body are retried only if the body can be obtained again (see http.Request.GetBody).
+type retryTransport struct {
+	base    http.RoundTripper
+	retries int
+	delay   time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.retries || !retryable(resp, err) || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(t.delay):
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryable reports whether the request should be retried after the response or the error.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
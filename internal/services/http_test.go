@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ofstudio/voxify/internal/config"
+)
+
+// TestHttpClientSuite is a test suite for the HTTP client of the services
+type TestHttpClientSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+// SetupSuite is called once before the entire test suite runs
+func (suite *TestHttpClientSuite) SetupSuite() {
+	suite.ctx = context.Background()
+}
+
+// TestTimeout tests that the request timeout is enforced
+func (suite *TestHttpClientSuite) TestTimeout() {
+	suite.Run("SlowResponse", func() {
+		// Arrange
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer server.Close()
+		defer close(release)
+		client := newHttpClient(&config.Settings{HttpTimeout: 50 * time.Millisecond})
+		req, err := http.NewRequestWithContext(suite.ctx, http.MethodGet, server.URL, nil)
+		suite.Require().NoError(err)
+
+		// Act
+		started := time.Now()
+		resp, err := client.Do(req)
+
+		// Assert
+		suite.Require().Error(err)
+		suite.Nil(resp)
+		suite.Less(time.Since(started), time.Second)
+	})
+
+	suite.Run("SlowResponseRetried", func() {
+		// Arrange
+		var attempts atomic.Int32
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer server.Close()
+		defer close(release)
+		client := newHttpClient(&config.Settings{
+			HttpTimeout:    50 * time.Millisecond,
+			HttpRetries:    5,
+			HttpRetryDelay: 10 * time.Millisecond,
+		})
+		req, err := http.NewRequestWithContext(suite.ctx, http.MethodGet, server.URL, nil)
+		suite.Require().NoError(err)
+
+		// Act
+		started := time.Now()
+		_, err = client.Do(req)
+
+		// Assert
+		suite.Require().Error(err)
+		suite.Less(time.Since(started), time.Second, "timeout must bound the retries")
+		suite.Equal(int32(1), attempts.Load())
+	})
+
+	suite.Run("DefaultTimeout", func() {
+		// Act
+		client := newHttpClient(&config.Settings{})
+
+		// Assert
+		suite.Equal(defaultHttpTimeout, client.Timeout)
+	})
+}
+
+// TestRetries tests that the failed requests are retried
+func (suite *TestHttpClientSuite) TestRetries() {
+	// newServer returns a server failing the first failures requests with the status
+	newServer := func(failures int32, status int) (*httptest.Server, *atomic.Int32, *[]string) {
+		var attempts atomic.Int32
+		var bodies []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			if attempts.Add(1) <= failures {
+				w.WriteHeader(status)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		return server, &attempts, &bodies
+	}
+	newClient := func(retries int) *http.Client {
+		return newHttpClient(&config.Settings{
+			HttpTimeout:    time.Second,
+			HttpRetries:    retries,
+			HttpRetryDelay: time.Millisecond,
+		})
+	}
+
+	suite.Run("ServerErrorRetried", func() {
+		// Arrange
+		server, attempts, bodies := newServer(2, http.StatusServiceUnavailable)
+		defer server.Close()
+		req, err := http.NewRequestWithContext(suite.ctx, http.MethodPost, server.URL, strings.NewReader("hub.mode=publish"))
+		suite.Require().NoError(err)
+
+		// Act
+		resp, err := newClient(2).Do(req)
+
+		// Assert
+		suite.Require().NoError(err)
+		_ = resp.Body.Close()
+		suite.Equal(http.StatusNoContent, resp.StatusCode)
+		suite.Equal(int32(3), attempts.Load())
+		suite.Equal([]string{"hub.mode=publish", "hub.mode=publish", "hub.mode=publish"}, *bodies,
+			"request body must be sent on every attempt")
+	})
+
+	suite.Run("RetriesExhausted", func() {
+		// Arrange
+		server, attempts, _ := newServer(5, http.StatusTooManyRequests)
+		defer server.Close()
+		req, err := http.NewRequestWithContext(suite.ctx, http.MethodGet, server.URL, nil)
+		suite.Require().NoError(err)
+
+		// Act
+		resp, err := newClient(1).Do(req)
+
+		// Assert
+		suite.Require().NoError(err)
+		_ = resp.Body.Close()
+		suite.Equal(http.StatusTooManyRequests, resp.StatusCode)
+		suite.Equal(int32(2), attempts.Load())
+	})
+
+	suite.Run("ClientErrorNotRetried", func() {
+		// Arrange
+		server, attempts, _ := newServer(5, http.StatusNotFound)
+		defer server.Close()
+		req, err := http.NewRequestWithContext(suite.ctx, http.MethodGet, server.URL, nil)
+		suite.Require().NoError(err)
+
+		// Act
+		resp, err := newClient(2).Do(req)
+
+		// Assert
+		suite.Require().NoError(err)
+		_ = resp.Body.Close()
+		suite.Equal(http.StatusNotFound, resp.StatusCode)
+		suite.Equal(int32(1), attempts.Load())
+	})
+}
+
+// Run the test suite
+func TestHttpClient(t *testing.T) {
+	suite.Run(t, new(TestHttpClientSuite))
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/ofstudio/voxify/internal/entities"
 	"github.com/ofstudio/voxify/internal/store"
@@ -15,16 +16,34 @@ type Platform interface {
 	ID() string
 	Init(ctx context.Context) error
 	Match(url string) bool
+	// SupportsFormat reports whether the platform can download media in the format.
+	SupportsFormat(f entities.DownloadFormat) bool
+	// Download downloads the episode applying the configured per-step timeouts.
+	// If a step times out, the returned error wraps context.DeadlineExceeded.
 	Download(ctx context.Context, req entities.Request) (*entities.Episode, error)
 }
 
 // Downloader is an interface for downloading episodes.
 type Downloader interface {
-	Download(ctx context.Context, req entities.Request) (*entities.Episode, error)
+	// Download downloads the episode and creates it in the store.
+	// If save is not nil, it is called within the same store transaction right after
+	// the episode is created: if save fails, neither the episode nor save changes are stored.
+	Download(ctx context.Context, req entities.Request,
+		save func(ctx context.Context, tx Store, episode *entities.Episode) error) (*entities.Episode, error)
 }
 
 // Feeder is an interface for building the podcast feed.
 type Feeder interface {
 	Build(ctx context.Context) error
+	BuildReport(ctx context.Context) (*entities.FeedBuildReport, error)
+	Validate(ctx context.Context) error
+	FeedBytes(ctx context.Context) (data []byte, etag string, lastMod time.Time, err error)
 	Feed(ctx context.Context) (*entities.Feed, error)
+	RecentEpisodes(ctx context.Context, limit, offset int) ([]*entities.Episode, error)
+}
+
+// Processor is an interface for reading the download processes.
+type Processor interface {
+	// Active returns the processes in progress in the order they were accepted.
+	Active(ctx context.Context) ([]*entities.Process, error)
 }
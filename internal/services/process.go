@@ -1,18 +1,26 @@
 package services
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/ofstudio/voxify/internal/config"
 	"github.com/ofstudio/voxify/internal/entities"
+	"github.com/ofstudio/voxify/internal/store"
 	"github.com/ofstudio/voxify/pkg/randtoken"
 )
 
 const (
-	notifyBuffer = 8 // Buffer size for the notify channel
+	notifyBuffer     = 8  // Buffer size for the notify channel
+	defaultWorkers   = 2  // Number of workers if cfg.DownloadWorkers is less than 1
+	defaultQueueSize = 20 // Number of requests waiting for a worker if cfg.DownloadQueueSize is less than 1
 )
 
 // ProcessService handles the processing of download requests.
@@ -23,7 +31,11 @@ type ProcessService struct {
 	downloader Downloader
 	feeder     Feeder
 	in         chan entities.Request
+	queue      chan *entities.Process   // Accepted processes taken by the workers
+	requeue    chan []*entities.Process // Processes of the playlist entries queued by the workers
 	out        chan entities.Process
+	resumed    []*entities.Process // Processes accepted before the restart, queued on Start
+	expanding  sync.Mutex          // Serializes the playlist expansion, so the playlists get distinct seasons
 }
 
 // NewProcessService creates a new ProcessService instance.
@@ -35,6 +47,8 @@ func NewProcessService(cfg *config.Settings, log *slog.Logger, s Store, d Downlo
 		downloader: d,
 		feeder:     f,
 		in:         make(chan entities.Request),
+		queue:      make(chan *entities.Process),
+		requeue:    make(chan []*entities.Process),
 		out:        make(chan entities.Process, notifyBuffer),
 	}
 }
@@ -50,13 +64,40 @@ func (s *ProcessService) Out() <-chan entities.Process {
 }
 
 // Init initializes the service before starting.
-// It fails all in-progress processes to ensure a clean state.
+// Processes accepted but not started before the restart are resumed on Start,
+// processes interrupted while publishing are published again with a single feed build,
+// other in-progress processes are failed to ensure a clean state.
 func (s *ProcessService) Init(ctx context.Context) error {
-	// Fail all in-progress processes
 	processes, err := s.store.ProcessGetByStatus(ctx, entities.StatusInProgress)
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrProcessGetByStatus, err)
 	}
+
+	// Resume queued processes in the order they were accepted
+	s.resumed = nil
+	processes = slices.DeleteFunc(processes, func(process *entities.Process) bool {
+		if process.Step != entities.StepCreating {
+			return false
+		}
+		s.resumed = append(s.resumed, process)
+		return true
+	})
+	slices.SortFunc(s.resumed, func(a, b *entities.Process) int { return cmp.Compare(a.ID, b.ID) })
+	if len(s.resumed) > 0 {
+		s.log.Info("[process service] resuming queued processes", "count", len(s.resumed))
+	}
+
+	// Split processes interrupted after the episode was downloaded
+	var publishing []*entities.Process
+	processes = slices.DeleteFunc(processes, func(process *entities.Process) bool {
+		if process.Step != entities.StepPublishing {
+			return false
+		}
+		publishing = append(publishing, process)
+		return true
+	})
+
+	// Fail other in-progress processes
 	if len(processes) > 0 {
 		s.log.Info("[process service] failing in-progress processes", "count", len(processes))
 		for _, process := range processes {
@@ -69,51 +110,263 @@ func (s *ProcessService) Init(ctx context.Context) error {
 			s.sendNotify(ctx, process)
 		}
 	}
+
+	// Publish processes interrupted while publishing
+	if len(publishing) > 0 {
+		s.log.Info("[process service] publishing interrupted processes", "count", len(publishing))
+		s.publish(ctx, time.Now(), publishing...)
+	}
 	return nil
 }
 
-// Start begins processing download requests.
+// Start begins processing download requests with cfg.DownloadWorkers concurrent workers.
 // It runs until the provided context is canceled.
 func (s *ProcessService) Start(ctx context.Context) {
+	go s.dispatch(ctx, s.resumed)
+	s.resumed = nil
+
+	workers := s.cfg.DownloadWorkers
+	if workers < 1 {
+		s.log.Warn("[process service] invalid number of workers, using default",
+			"workers", workers, "default", defaultWorkers)
+		workers = defaultWorkers
+	}
+
 	// Start multiple workers for better concurrency
-	for i := 0; i < s.cfg.DownloadWorkers; i++ {
+	for i := 0; i < workers; i++ {
 		go s.worker(ctx, i)
 	}
 }
 
-// worker processes requests from the input channel
-func (s *ProcessService) worker(ctx context.Context, workerID int) {
-	s.log.Info("[process service] worker started", "worker_id", workerID)
-	defer s.log.Info("[process service] worker stopped", "worker_id", workerID)
+// dispatch accepts the requests from the input channel and queues them for the workers
+// after the queued processes. Accepted requests are stored as processes at the creating step
+// before they are queued, so the requests waiting for a worker are resumed after a restart.
+// Accepted processes not taken by a worker at once are notified with the queue position.
+// No requests are accepted while cfg.DownloadQueueSize processes are waiting for a worker,
+// so the senders find the processor busy.
+func (s *ProcessService) dispatch(ctx context.Context, queued []*entities.Process) {
+	size := s.cfg.DownloadQueueSize
+	if size < 1 {
+		size = defaultQueueSize
+	}
 
 	for {
+		var next chan<- *entities.Process // nil if nothing is queued, blocking the send case
+		var head *entities.Process
+		if len(queued) > 0 {
+			next, head = s.queue, queued[0]
+		}
+		in := s.in // nil if the queue is full, blocking the receive case
+		if len(queued) >= size {
+			in = nil
+		}
+
 		select {
 		case <-ctx.Done():
 			return
-		case req := <-s.in:
-			req.ID = randtoken.New(10)
-			s.log.Info("[process service] received new request",
-				"worker_id", workerID, "request", req.LogValue())
-			s.handle(ctx, req)
+		case req := <-in:
+			process := s.accept(ctx, req)
+			if process == nil {
+				continue
+			}
+			if len(queued) == 0 {
+				select {
+				case s.queue <- process: // Taken by an idle worker
+					continue
+				default:
+				}
+			}
+			queued = append(queued, process)
+			s.notifyQueued(process, len(queued))
+		case processes := <-s.requeue:
+			queued = append(queued, processes...)
+		case next <- head:
+			queued = queued[1:]
 		}
 	}
 }
 
-// handle processes a single download request.
-// It updates the process status at each step and handles errors appropriately.
-func (s *ProcessService) handle(ctx context.Context, req entities.Request) {
-	var err error
+// accept creates the process for the request at the creating step.
+// It returns nil if the request is repeated or the process cannot be created.
+func (s *ProcessService) accept(ctx context.Context, req entities.Request) *entities.Process {
+	if s.isDuplicate(ctx, req) {
+		s.log.Info("[process service] skipping repeated request", "request", req.LogValue())
+		return nil
+	}
+	req.ID = randtoken.New(10)
+	s.log.Info("[process service] received new request", "request", req.LogValue())
+
 	process := &entities.Process{
 		Request: req,
 		Step:    entities.StepCreating,
 		Status:  entities.StatusInProgress,
 	}
-
-	// Create process
-	if err = s.update(ctx, process); err != nil {
+	if err := s.create(ctx, process); err != nil {
 		s.fail(ctx, process, err)
+		return nil
+	}
+	return process
+}
+
+// create stores the process at the creating step without notifying.
+// The process is notified when it is queued or taken by a worker.
+func (s *ProcessService) create(ctx context.Context, process *entities.Process) error {
+	if err := s.store.ProcessUpsert(ctx, process); err != nil {
+		return fmt.Errorf("%w: %w", ErrProcessUpsert, err)
+	}
+	s.log.Info("[process service] process created",
+		"process", process.LogValue())
+	return nil
+}
+
+// notifyQueued notifies the process waiting for a worker at the given position of the queue.
+// It runs on the dispatcher, so the notification is dropped rather than waited for
+// if the notify channel is full.
+func (s *ProcessService) notifyQueued(process *entities.Process, position int) {
+	notification := *process
+	notification.QueuePosition = position
+	s.log.Info("[process service] process queued",
+		"process", process.LogValue(), "position", position)
+	select {
+	case s.out <- notification:
+	default:
+		s.log.Error("[process service] notification buffer full, dropping notification",
+			"process", process.LogValue())
+	}
+}
+
+// Active implements Processor interface to return the processes in progress
+// in the order they were accepted, including the ones waiting for a worker.
+func (s *ProcessService) Active(ctx context.Context) ([]*entities.Process, error) {
+	processes, err := s.store.ProcessGetByStatus(ctx, entities.StatusInProgress)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProcessGetByStatus, err)
+	}
+	slices.SortFunc(processes, func(a, b *entities.Process) int { return cmp.Compare(a.ID, b.ID) })
+	return processes, nil
+}
+
+// worker handles the queued processes
+func (s *ProcessService) worker(ctx context.Context, workerID int) {
+	s.log.Info("[process service] worker started", "worker_id", workerID)
+	defer s.log.Info("[process service] worker stopped", "worker_id", workerID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case process := <-s.queue:
+			if ctx.Err() != nil {
+				return // The process is resumed after the restart
+			}
+			s.log.Info("[process service] handling process",
+				"worker_id", workerID, "process", process.LogValue())
+			s.handle(ctx, process)
+		}
+	}
+}
+
+// expand queues a process for each entry of the playlist requested by the process
+// and completes the process with the success status. The processes of the entries
+// are stored at the creating step before they are queued, like the accepted requests.
+// The entries are numbered by their index in the playlist within a new season.
+// Only the first cfg.MaxPlaylistEntries entries are queued if the limit is set.
+// The playlists are expanded one at a time: the season is allocated from the stored entries,
+// so the entries of a playlist are stored before the next playlist gets its season.
+func (s *ProcessService) expand(ctx context.Context, process *entities.Process, entries []string) {
+	if limit := s.cfg.MaxPlaylistEntries; limit > 0 && len(entries) > limit {
+		s.log.Warn("[process service] playlist entries over the limit are skipped",
+			"process", process.LogValue(), "entries", len(entries), "limit", limit)
+		entries = entries[:limit]
+	}
+	s.expanding.Lock()
+	season := s.nextSeason(ctx)
+	queued := make([]*entities.Process, 0, len(entries))
+	for i, entry := range entries {
+		req := process.Request
+		req.ID = randtoken.New(10)
+		req.Url = entry
+		req.TitleOverride = "" // Overrides are set for the playlist, not for every entry
+		req.EpisodeNumber = i + 1
+		req.SeasonNumber = season
+		p := &entities.Process{
+			Request: req,
+			Step:    entities.StepCreating,
+			Status:  entities.StatusInProgress,
+		}
+		if err := s.create(ctx, p); err != nil {
+			s.fail(ctx, p, err)
+			continue
+		}
+		queued = append(queued, p)
+	}
+	s.expanding.Unlock()
+	s.log.Info("[process service] playlist expanded",
+		"process", process.LogValue(), "entries", len(entries), "queued", len(queued))
+	s.expanded(ctx, process, len(queued))
+
+	select {
+	case s.requeue <- queued:
+	case <-ctx.Done(): // The processes are resumed after the restart
+	}
+}
+
+// expanded completes the process of the expanded playlist and notifies
+// about the number of queued entries. The playlist itself has no episode.
+func (s *ProcessService) expanded(ctx context.Context, process *entities.Process, queued int) {
+	process.Status = entities.StatusSuccess
+	if err := s.store.ProcessUpsert(ctx, process); err != nil {
+		s.fail(ctx, process, fmt.Errorf("%w: %w", ErrProcessUpsert, err))
 		return
 	}
+	notification := *process
+	notification.Expanded = queued
+	s.sendNotify(ctx, &notification)
+}
+
+// nextSeason returns the season number for the entries of a new playlist, following
+// the seasons of the stored episodes and of the playlist entries still in progress.
+// Store errors are logged and the entries are not assigned a season.
+func (s *ProcessService) nextSeason(ctx context.Context) int {
+	last, err := s.store.EpisodeGetLastSeason(ctx)
+	if err != nil {
+		s.log.Error("[process service] failed to get last season", "error", err)
+		return 0
+	}
+	processes, err := s.store.ProcessGetByStatus(ctx, entities.StatusInProgress)
+	if err != nil {
+		s.log.Error("[process service] failed to get seasons in progress", "error", err)
+		return 0
+	}
+	for _, p := range processes {
+		last = max(last, p.Request.SeasonNumber)
+	}
+	return last + 1
+}
+
+// isDuplicate reports whether a process has already been created for the request message.
+// Telegram may deliver the same update more than once, and each delivery
+// must not start another download. Store errors are logged and the request is treated as new.
+func (s *ProcessService) isDuplicate(ctx context.Context, req entities.Request) bool {
+	existing, err := s.store.ProcessGetByMessage(ctx, req.ChatID, req.MessageID)
+	if errors.Is(err, store.ErrNotFound) {
+		return false
+	}
+	if err != nil {
+		s.log.Error("[process service] failed to check for repeated request",
+			"error", err, "request", req.LogValue())
+		return false
+	}
+	s.log.Info("[process service] process already exists for request message",
+		"process", existing.LogValue())
+	return true
+}
+
+// handle processes the accepted download request from validation to publishing.
+// It updates the process status at each step and handles errors appropriately.
+func (s *ProcessService) handle(ctx context.Context, process *entities.Process) {
+	var err error
+	started := time.Now()
 
 	// Validate process
 	if err = s.validate(ctx, process); err != nil {
@@ -122,33 +375,88 @@ func (s *ProcessService) handle(ctx context.Context, req entities.Request) {
 	}
 
 	// Download episode
+	started = recordStep(process, started)
 	process.Step = entities.StepDownloading
 	if err = s.update(ctx, process); err != nil {
 		s.fail(ctx, process, err)
 		return
 	}
 
-	if process.Episode, err = s.downloader.Download(ctx, process.Request); err != nil {
+	// The process is moved to the publishing step in the same transaction the episode is stored
+	if err = s.download(ctx, process, started); err != nil {
+		var playlist *PlaylistError
+		if errors.As(err, &playlist) {
+			s.expand(ctx, process, playlist.Entries)
+			return
+		}
 		s.fail(ctx, process, err)
 		return
 	}
+	started = time.Now()
 	s.log.Info("[process service] episode downloaded",
 		"process", process.LogValue())
+	s.sendNotify(ctx, process)
 
-	// Build podcast feed
-	process.Step = entities.StepPublishing
-	if err = s.update(ctx, process); err != nil {
-		s.fail(ctx, process, err)
+	s.publish(ctx, started, process)
+}
+
+// publish builds the podcast feed and completes the processes at the publishing step.
+// The feed is built once for all the processes, if it fails, all of them are failed.
+func (s *ProcessService) publish(ctx context.Context, started time.Time, processes ...*entities.Process) {
+	if err := s.feeder.Build(ctx); err != nil {
+		for _, process := range processes {
+			s.fail(ctx, process, err)
+		}
 		return
 	}
-	if err = s.feeder.Build(ctx); err != nil {
-		s.fail(ctx, process, err)
-		return
+	for _, process := range processes {
+		recordStep(process, started)
+		process.Status = entities.StatusSuccess
+		if err := s.update(ctx, process); err != nil {
+			s.fail(ctx, process, err)
+		}
 	}
-	process.Status = entities.StatusSuccess
-	if err = s.update(ctx, process); err != nil {
-		s.fail(ctx, process, err)
-		return
+}
+
+// download downloads the episode of the process and moves the process to the publishing step.
+// The process is updated with the episode within the store transaction creating the episode.
+// Transient failures (ErrRetryable) are retried up to cfg.DownloadRetries times
+// with cfg.DownloadRetryDelay between attempts. Other failures are returned immediately.
+// The started is the time the downloading step has started.
+func (s *ProcessService) download(ctx context.Context, process *entities.Process, started time.Time) error {
+	for attempt := 1; ; attempt++ {
+		_, err := s.downloader.Download(ctx, process.Request, s.publishing(process, started))
+		if err != nil {
+			// The transaction saving the process has been rolled back
+			process.Episode = nil
+			process.Step = entities.StepDownloading
+			delete(process.StepTimings, entities.StepDownloading)
+		}
+		if err == nil || !errors.Is(err, ErrRetryable) || attempt > s.cfg.DownloadRetries {
+			return err
+		}
+		s.log.Warn("[process service] download failed with transient error, retrying",
+			"error", err, "attempt", attempt, "process", process.LogValue())
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(s.cfg.DownloadRetryDelay):
+		}
+	}
+}
+
+// publishing returns the function saving the process with the downloaded episode
+// at the publishing step within the store transaction tx.
+func (s *ProcessService) publishing(process *entities.Process, started time.Time) func(context.Context, Store, *entities.Episode) error {
+	return func(ctx context.Context, tx Store, episode *entities.Episode) error {
+		process.Episode = episode
+		recordStep(process, started)
+		process.Step = entities.StepPublishing
+		if err := tx.ProcessUpsert(ctx, process); err != nil {
+			return fmt.Errorf("%w: %w", ErrProcessUpsert, err)
+		}
+		return nil
 	}
 }
 
@@ -156,12 +464,13 @@ func (s *ProcessService) handle(ctx context.Context, req entities.Request) {
 // It checks for existing processes in progress and existing episodes.
 // If the process is valid, it returns nil. Otherwise, it returns an appropriate error.
 func (s *ProcessService) validate(ctx context.Context, process *entities.Process) error {
-	// Init processes in progress
-	count, err := s.store.ProcessCountByUrlAndStatus(ctx, process.Request.Url, entities.StatusInProgress)
+	// Init processes in progress accepted earlier for the same URL,
+	// so the first of the queued requests for the URL proceeds
+	count, err := s.store.ProcessCountByUrlAndStatus(ctx, process.Request.Url, entities.StatusInProgress, process.ID)
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrProcessCountByUrlAndStatus, err)
 	}
-	if count > 1 { // count > 1 because the current process is also counted
+	if count > 0 {
 		return ErrEpisodeInProgress
 	}
 
@@ -169,21 +478,35 @@ func (s *ProcessService) validate(ctx context.Context, process *entities.Process
 	if process.Request.Force {
 		return nil
 	}
-	existing, err := s.store.EpisodeGetByOriginalUrl(ctx, process.Request.Url)
+	exists, err := s.store.EpisodeExistsByOriginalUrl(ctx, process.Request.Url)
 	if err != nil {
-		return fmt.Errorf("%w: %w", ErrEpisodeGetByOriginalURL, err)
+		return fmt.Errorf("%w: %w", ErrEpisodeExistsByOriginalURL, err)
 	}
-	if len(existing) > 0 {
+	if exists {
 		return ErrEpisodeExists
 	}
 
 	return nil
 }
 
+// recordStep records the duration of the current step of the process started at the given time.
+// It returns the current time as the start of the next step.
+func recordStep(process *entities.Process, started time.Time) time.Time {
+	now := time.Now()
+	if process.StepTimings == nil {
+		process.StepTimings = make(map[entities.Step]time.Duration)
+	}
+	process.StepTimings[process.Step] = now.Sub(started)
+	return now
+}
+
 // sendNotify sends a notification.
+// The step timings are copied so the process can be modified after the notification is sent.
 func (s *ProcessService) sendNotify(ctx context.Context, process *entities.Process) {
+	notification := *process
+	notification.StepTimings = maps.Clone(process.StepTimings)
 	select {
-	case s.out <- *process: // Successfully sent
+	case s.out <- notification: // Successfully sent
 	case <-time.After(time.Second * 5): // Channel full
 		s.log.Error("[process service] notification buffer full, dropping notification",
 			"process", process.LogValue())
@@ -3,7 +3,10 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -13,6 +16,7 @@ import (
 	"github.com/ofstudio/voxify/internal/config"
 	"github.com/ofstudio/voxify/internal/entities"
 	"github.com/ofstudio/voxify/internal/mocks"
+	"github.com/ofstudio/voxify/internal/store"
 )
 
 // TestProcessServiceSuite is a test suite for ProcessService
@@ -65,6 +69,599 @@ func (suite *TestProcessServiceSuite) TestStart() {
 		// Assert - no error to check, just verify it doesn't panic
 		suite.True(true)
 	})
+
+	// assertWorkers starts the service with the given number of workers
+	// and asserts the expected number of workers are started
+	assertWorkers := func(workers, expected int) {
+		cfg := *suite.cfg
+		cfg.DownloadWorkers = workers
+		handler := &workerCounter{}
+		service := NewProcessService(&cfg, slog.New(handler), suite.mockStore, suite.mockDown, suite.mockFeeder)
+		ctx, cancel := context.WithCancel(suite.ctx)
+		defer cancel()
+
+		service.Start(ctx)
+
+		suite.Eventually(func() bool { return handler.started.Load() == int32(expected) },
+			time.Second, 5*time.Millisecond, "expected %d workers started", expected)
+	}
+
+	suite.Run("ConfiguredWorkers", func() {
+		assertWorkers(5, 5)
+	})
+
+	suite.Run("SingleWorker", func() {
+		assertWorkers(1, 1)
+	})
+
+	suite.Run("ZeroWorkersFallBackToDefault", func() {
+		assertWorkers(0, defaultWorkers)
+	})
+
+	suite.Run("NegativeWorkersFallBackToDefault", func() {
+		assertWorkers(-3, defaultWorkers)
+	})
+}
+
+// workerCounter is a slog.Handler counting the started workers
+type workerCounter struct {
+	started atomic.Int32
+}
+
+func (h *workerCounter) Enabled(context.Context, slog.Level) bool { return true }
+func (h *workerCounter) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *workerCounter) WithGroup(string) slog.Handler            { return h }
+
+func (h *workerCounter) Handle(_ context.Context, r slog.Record) error {
+	if r.Message == "[process service] worker started" {
+		h.started.Add(1)
+	}
+	return nil
+}
+
+// TestWorker_RepeatedDelivery tests that a repeated delivery of the same message
+// does not start a second download
+func (suite *TestProcessServiceSuite) TestWorker_RepeatedDelivery() {
+	suite.Run("SecondDeliverySkipped", func() {
+		// Arrange
+		ctx, cancel := context.WithCancel(suite.ctx)
+		defer cancel()
+		request := entities.Request{ChatID: 456, MessageID: 789, Url: "https://example.com/video"}
+		existing := &entities.Process{ID: 1, Request: request, Step: entities.StepDownloading, Status: entities.StatusInProgress}
+
+		// First delivery: no process yet, full handling
+		suite.mockStore.On("ProcessGetByMessage", ctx, request.ChatID, request.MessageID).
+			Return(nil, store.ErrNotFound).Once()
+		suite.mockStore.On("ProcessUpsert", ctx, mock.Anything).Return(nil)
+		suite.mockStore.On("ProcessCountByUrlAndStatus", ctx, request.Url, entities.StatusInProgress, mock.Anything).Return(0, nil)
+		suite.mockStore.On("EpisodeExistsByOriginalUrl", ctx, request.Url).Return(false, nil)
+		suite.mockDown.EXPECT().Download(ctx, mock.Anything, mock.Anything).RunAndReturn(suite.downloaded(&entities.Episode{ID: 1}))
+		suite.mockFeeder.On("Build", ctx).Return(nil)
+
+		// Repeated deliveries: process already exists
+		var repeated atomic.Int32
+		suite.mockStore.On("ProcessGetByMessage", ctx, request.ChatID, request.MessageID).
+			Run(func(mock.Arguments) { repeated.Add(1) }).
+			Return(existing, nil)
+
+		suite.service.Start(ctx)
+
+		// Act - repeated deliveries are skipped when accepted, before any worker takes them
+		for i := 0; i < 3; i++ {
+			suite.service.In() <- request
+		}
+		suite.waitStatus(entities.StatusSuccess)
+		suite.Eventually(func() bool { return repeated.Load() == 2 }, time.Second, 10*time.Millisecond)
+		cancel()
+
+		// Assert
+		suite.mockDown.AssertNumberOfCalls(suite.T(), "Download", 1)
+		suite.mockStore.AssertNumberOfCalls(suite.T(), "ProcessGetByMessage", 3)
+	})
+}
+
+// TestIsDuplicate tests the isDuplicate method
+func (suite *TestProcessServiceSuite) TestIsDuplicate() {
+	request := entities.Request{ChatID: 456, MessageID: 789, Url: "https://example.com/video"}
+
+	suite.Run("NewMessage", func() {
+		// Arrange
+		suite.mockStore.On("ProcessGetByMessage", suite.ctx, request.ChatID, request.MessageID).
+			Return(nil, store.ErrNotFound)
+
+		// Act & Assert
+		suite.False(suite.service.isDuplicate(suite.ctx, request))
+	})
+
+	suite.Run("RepeatedMessage", func() {
+		// Arrange
+		suite.mockStore.On("ProcessGetByMessage", suite.ctx, request.ChatID, request.MessageID).
+			Return(&entities.Process{ID: 1, Request: request}, nil)
+
+		// Act & Assert
+		suite.True(suite.service.isDuplicate(suite.ctx, request))
+	})
+
+	suite.Run("StoreError", func() {
+		// Arrange
+		suite.mockStore.On("ProcessGetByMessage", suite.ctx, request.ChatID, request.MessageID).
+			Return(nil, errors.New("store error"))
+
+		// Act & Assert
+		suite.False(suite.service.isDuplicate(suite.ctx, request), "store errors must not block requests")
+	})
+}
+
+// TestAccept tests that the accepted requests are stored before they are queued
+func (suite *TestProcessServiceSuite) TestAccept() {
+	request := entities.Request{ChatID: 456, MessageID: 789, Url: "https://example.com/video"}
+
+	suite.Run("Success", func() {
+		// Arrange
+		suite.mockStore.On("ProcessGetByMessage", suite.ctx, request.ChatID, request.MessageID).
+			Return(nil, store.ErrNotFound)
+		suite.mockStore.On("ProcessUpsert", suite.ctx, mock.MatchedBy(func(p *entities.Process) bool {
+			return p.Step == entities.StepCreating && p.Status == entities.StatusInProgress && p.Request.ID != ""
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*entities.Process).ID = 1
+		}).Return(nil)
+
+		// Act
+		process := suite.service.accept(suite.ctx, request)
+
+		// Assert
+		suite.Require().NotNil(process)
+		suite.Equal(int64(1), process.ID)
+		suite.Equal(request.Url, process.Request.Url)
+	})
+
+	suite.Run("RepeatedMessage", func() {
+		// Arrange
+		suite.mockStore.On("ProcessGetByMessage", suite.ctx, request.ChatID, request.MessageID).
+			Return(&entities.Process{ID: 1, Request: request}, nil)
+
+		// Act
+		process := suite.service.accept(suite.ctx, request)
+
+		// Assert
+		suite.Nil(process)
+		suite.mockStore.AssertNotCalled(suite.T(), "ProcessUpsert", mock.Anything, mock.Anything)
+	})
+
+	suite.Run("UpsertError", func() {
+		// Arrange
+		suite.mockStore.On("ProcessGetByMessage", suite.ctx, request.ChatID, request.MessageID).
+			Return(nil, store.ErrNotFound)
+		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessStatus(entities.StatusInProgress)).
+			Return(errors.New("db error")).Once()
+		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessError(ErrProcessUpsert)).Return(nil)
+
+		// Act
+		process := suite.service.accept(suite.ctx, request)
+
+		// Assert
+		suite.Nil(process)
+		suite.Equal(entities.StatusFailed, suite.waitStatus(entities.StatusFailed).Status)
+	})
+}
+
+// TestRestart tests that the requests queued but not started before a restart are resumed
+func (suite *TestProcessServiceSuite) TestRestart() {
+	suite.Run("QueuedRequestResumed", func() {
+		// Arrange - single worker is busy with the first request, the second one is queued
+		st := store.NewMemoryStore()
+		ctx, cancel := context.WithCancel(suite.ctx)
+		first := entities.Request{ChatID: 1, MessageID: 1, Url: "https://example.com/first"}
+		queued := entities.Request{ChatID: 1, MessageID: 2, Url: "https://example.com/queued"}
+
+		started := make(chan struct{})
+		suite.mockDown.On("Download", ctx, mock.MatchedBy(func(r entities.Request) bool { return r.Url == first.Url }), mock.Anything).
+			Run(func(mock.Arguments) {
+				close(started)
+				<-ctx.Done()
+			}).Return(nil, context.Canceled)
+
+		service := NewProcessService(suite.cfg, suite.log, st, suite.mockDown, suite.mockFeeder)
+		service.Start(ctx)
+		service.In() <- first
+		<-started
+		service.In() <- queued
+		suite.Eventually(func() bool {
+			_, err := st.ProcessGetByMessage(suite.ctx, queued.ChatID, queued.MessageID)
+			return err == nil
+		}, time.Second, 5*time.Millisecond, "queued request must be stored")
+
+		// Act - restart
+		cancel()
+		suite.Eventually(func() bool {
+			p, err := st.ProcessGetByMessage(suite.ctx, first.ChatID, first.MessageID)
+			return err == nil && p.Status == entities.StatusFailed
+		}, time.Second, 5*time.Millisecond, "started process must fail on shutdown")
+
+		ctx, cancel = context.WithCancel(suite.ctx)
+		defer cancel()
+		episode := &entities.Episode{Title: "Queued", OriginalURL: queued.Url}
+		suite.mockDown.On("Download", ctx, mock.MatchedBy(func(r entities.Request) bool { return r.Url == queued.Url }), mock.Anything).
+			Return(episode, nil).Run(func(args mock.Arguments) {
+			save := args.Get(2).(func(context.Context, store.Store, *entities.Episode) error)
+			suite.NoError(save(ctx, st, episode))
+		})
+		suite.mockFeeder.On("Build", ctx).Return(nil)
+
+		restarted := NewProcessService(suite.cfg, suite.log, st, suite.mockDown, suite.mockFeeder)
+		suite.Require().NoError(restarted.Init(ctx))
+		restarted.Start(ctx)
+
+		// Assert
+		suite.Eventually(func() bool {
+			p, err := st.ProcessGetByMessage(suite.ctx, queued.ChatID, queued.MessageID)
+			return err == nil && p.Status == entities.StatusSuccess
+		}, time.Second, 5*time.Millisecond, "queued process must be resumed")
+		p, err := st.ProcessGetByMessage(suite.ctx, queued.ChatID, queued.MessageID)
+		suite.Require().NoError(err)
+		suite.Nil(p.Error)
+		suite.Equal(entities.StepPublishing, p.Step)
+	})
+}
+
+// TestQueued tests that the requests waiting for a worker are notified with the queue position
+func (suite *TestProcessServiceSuite) TestQueued() {
+	suite.Run("QueuedNotifiedBeforeCreating", func() {
+		// Arrange - single worker is busy with the first request, the second one is queued
+		st := store.NewMemoryStore()
+		ctx, cancel := context.WithCancel(suite.ctx)
+		defer cancel()
+		first := entities.Request{ChatID: 1, MessageID: 1, Url: "https://example.com/first"}
+		second := entities.Request{ChatID: 1, MessageID: 2, Url: "https://example.com/second"}
+
+		started, release := make(chan struct{}), make(chan struct{})
+		suite.mockDown.On("Download", ctx, mock.MatchedBy(func(r entities.Request) bool { return r.Url == first.Url }), mock.Anything).
+			Run(func(mock.Arguments) {
+				close(started)
+				<-release
+			}).Return(nil, errors.New("download error"))
+		suite.mockDown.On("Download", ctx, mock.MatchedBy(func(r entities.Request) bool { return r.Url == second.Url }), mock.Anything).
+			Return(nil, errors.New("download error"))
+
+		service := NewProcessService(suite.cfg, suite.log, st, suite.mockDown, suite.mockFeeder)
+		service.Start(ctx)
+
+		// Act
+		service.In() <- first
+		<-started
+		service.In() <- second
+		var notifications []entities.Process
+		timeout := time.After(time.Second)
+		for len(notifications) == 0 || notifications[len(notifications)-1].Status != entities.StatusFailed {
+			select {
+			case p := <-service.Out():
+				if p.Request.MessageID == first.MessageID {
+					continue
+				}
+				notifications = append(notifications, p)
+				if p.QueuePosition > 0 {
+					close(release)
+				}
+			case <-timeout:
+				suite.FailNow("notifications not received")
+			}
+		}
+
+		// Assert
+		suite.Require().GreaterOrEqual(len(notifications), 2)
+		suite.Equal(entities.StepCreating, notifications[0].Step)
+		suite.Equal(entities.StatusInProgress, notifications[0].Status)
+		suite.Equal(1, notifications[0].QueuePosition)
+		suite.Equal(entities.StepDownloading, notifications[1].Step, "no notification when taken by a worker")
+		suite.Equal(entities.StatusInProgress, notifications[1].Status)
+		suite.Zero(notifications[1].QueuePosition)
+	})
+
+	suite.Run("QueuedNotificationNotBlocking", func() {
+		// Arrange - nobody reads the notifications
+		for range notifyBuffer {
+			suite.service.out <- entities.Process{}
+		}
+		done := make(chan struct{})
+
+		// Act
+		go func() {
+			suite.service.notifyQueued(&entities.Process{ID: 1}, 1)
+			close(done)
+		}()
+
+		// Assert
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			suite.Fail("queued notification must not block the dispatcher")
+		}
+	})
+
+	suite.Run("QueueFull", func() {
+		// Arrange - single worker is busy, the queue holds a single request
+		st := store.NewMemoryStore()
+		ctx, cancel := context.WithCancel(suite.ctx)
+		defer cancel()
+		cfg := *suite.cfg
+		cfg.DownloadQueueSize = 1
+
+		started, release := make(chan struct{}, 1), make(chan struct{})
+		suite.mockDown.On("Download", ctx, mock.Anything, mock.Anything).
+			Run(func(mock.Arguments) {
+				select {
+				case started <- struct{}{}:
+				default:
+				}
+				<-release
+			}).Return(nil, errors.New("download error"))
+
+		service := NewProcessService(&cfg, suite.log, st, suite.mockDown, suite.mockFeeder)
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-service.Out():
+				}
+			}
+		}()
+		service.Start(ctx)
+
+		// Act
+		service.In() <- entities.Request{ChatID: 1, MessageID: 1, Url: "https://example.com/first"}
+		<-started
+		service.In() <- entities.Request{ChatID: 1, MessageID: 2, Url: "https://example.com/second"}
+		var accepted bool
+		select {
+		case service.In() <- entities.Request{ChatID: 1, MessageID: 3, Url: "https://example.com/third"}:
+			accepted = true
+		case <-time.After(100 * time.Millisecond):
+		}
+		processes, err := st.ProcessGetByStatus(suite.ctx, entities.StatusInProgress)
+		close(release)
+
+		// Assert
+		suite.False(accepted, "request must not be accepted while the queue is full")
+		suite.Require().NoError(err)
+		suite.Len(processes, 2)
+	})
+}
+
+// TestPlaylist tests the expansion of a playlist request into the requests of its entries
+func (suite *TestProcessServiceSuite) TestPlaylist() {
+	suite.Run("EntriesQueued", func() {
+		// Arrange
+		st := store.NewMemoryStore()
+		ctx, cancel := context.WithCancel(suite.ctx)
+		defer cancel()
+		req := entities.Request{
+			ChatID:        1,
+			MessageID:     1,
+			Url:           "https://example.com/playlist?list=1",
+			TitleOverride: "Playlist title",
+			EpisodeNumber: 5,
+		}
+		entries := []string{"https://example.com/one", "https://example.com/two"}
+
+		suite.mockDown.On("Download", ctx, mock.MatchedBy(func(r entities.Request) bool { return r.Url == req.Url }), mock.Anything).
+			Return(nil, fmt.Errorf("%w: %w", ErrPlaylistNotSupported, &PlaylistError{Entries: entries})).Once()
+		var downloaded []entities.Request
+		suite.mockDown.On("Download", ctx, mock.MatchedBy(func(r entities.Request) bool { return r.Url != req.Url }), mock.Anything).
+			Return(nil, nil).Run(func(args mock.Arguments) {
+			r := args.Get(1).(entities.Request)
+			downloaded = append(downloaded, r)
+			episode := &entities.Episode{Title: r.Url, OriginalURL: r.Url}
+			save := args.Get(2).(func(context.Context, store.Store, *entities.Episode) error)
+			suite.NoError(st.EpisodeCreate(ctx, episode))
+			suite.NoError(save(ctx, st, episode))
+		}).Twice()
+		suite.mockFeeder.On("Build", ctx).Return(nil)
+
+		service := NewProcessService(suite.cfg, suite.log, st, suite.mockDown, suite.mockFeeder)
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-service.Out():
+				}
+			}
+		}()
+		service.Start(ctx)
+
+		// Act
+		service.In() <- req
+
+		// Assert
+		suite.Eventually(func() bool {
+			processes, err := st.ProcessGetByStatus(suite.ctx, entities.StatusSuccess)
+			return err == nil && len(processes) == len(entries)+1
+		}, time.Second, 5*time.Millisecond, "entries must be downloaded and the playlist completed")
+
+		failed, err := st.ProcessGetByStatus(suite.ctx, entities.StatusFailed)
+		suite.Require().NoError(err)
+		suite.Empty(failed, "expanded playlist must not fail")
+
+		completed, err := st.ProcessGetByStatus(suite.ctx, entities.StatusSuccess)
+		suite.Require().NoError(err)
+		parent := completed[slices.IndexFunc(completed, func(p *entities.Process) bool {
+			return p.Request.Url == req.Url
+		})]
+		suite.Nil(parent.Error)
+		suite.Nil(parent.Episode)
+
+		suite.Require().Len(downloaded, len(entries))
+		for i, r := range downloaded {
+			suite.Equal(entries[i], r.Url, "entries must be downloaded in the playlist order")
+			suite.Equal(req.ChatID, r.ChatID)
+			suite.Equal(req.MessageID, r.MessageID)
+			suite.Empty(r.TitleOverride)
+			suite.Equal(i+1, r.EpisodeNumber)
+			suite.Equal(1, r.SeasonNumber)
+			suite.NotEqual(parent.Request.ID, r.ID)
+		}
+	})
+}
+
+// TestExpand tests that the playlist entries are numbered within a new season
+func (suite *TestProcessServiceSuite) TestExpand() {
+	process := &entities.Process{
+		ID:      1,
+		Request: entities.Request{ID: "playlist", ChatID: 1, MessageID: 1, Url: "https://example.com/playlist?list=1"},
+		Step:    entities.StepDownloading,
+		Status:  entities.StatusInProgress,
+	}
+	entries := []string{"https://example.com/one", "https://example.com/two", "https://example.com/three"}
+
+	// expand expands the playlist and returns the queued processes of the entries
+	expand := func() []*entities.Process {
+		done := make(chan []*entities.Process)
+		go func() { done <- <-suite.service.requeue }()
+		p := *process
+		suite.service.expand(suite.ctx, &p, entries)
+		return <-done
+	}
+
+	suite.Run("NumberedInNewSeason", func() {
+		// Arrange
+		suite.mockStore.On("EpisodeGetLastSeason", suite.ctx).Return(1, nil)
+		suite.mockStore.On("ProcessGetByStatus", suite.ctx, entities.StatusInProgress).Return([]*entities.Process{
+			{ID: 2, Request: entities.Request{Url: "https://example.com/other", EpisodeNumber: 4, SeasonNumber: 2}},
+		}, nil)
+		suite.mockStore.On("ProcessUpsert", suite.ctx, mock.Anything).Return(nil)
+
+		// Act
+		queued := expand()
+
+		// Assert
+		suite.Require().Len(queued, len(entries))
+		for i, p := range queued {
+			suite.Equal(entries[i], p.Request.Url)
+			suite.Equal(i+1, p.Request.EpisodeNumber)
+			suite.Equal(3, p.Request.SeasonNumber, "entries must follow the seasons in progress")
+		}
+	})
+
+	suite.Run("LastSeasonError", func() {
+		// Arrange
+		suite.mockStore.On("EpisodeGetLastSeason", suite.ctx).Return(0, errors.New("db error"))
+		suite.mockStore.On("ProcessUpsert", suite.ctx, mock.Anything).Return(nil)
+
+		// Act
+		queued := expand()
+
+		// Assert
+		suite.Require().Len(queued, len(entries))
+		for i, p := range queued {
+			suite.Equal(i+1, p.Request.EpisodeNumber)
+			suite.Zero(p.Request.SeasonNumber)
+		}
+	})
+
+	suite.Run("EntriesLimited", func() {
+		// Arrange
+		cfg := *suite.cfg
+		cfg.MaxPlaylistEntries = 2
+		suite.service.cfg = &cfg
+		suite.mockStore.On("EpisodeGetLastSeason", suite.ctx).Return(0, nil)
+		suite.mockStore.On("ProcessGetByStatus", suite.ctx, entities.StatusInProgress).Return(nil, nil)
+		suite.mockStore.On("ProcessUpsert", suite.ctx, mock.Anything).Return(nil)
+
+		// Act
+		queued := expand()
+
+		// Assert
+		suite.Require().Len(queued, 2)
+		suite.Equal(entries[0], queued[0].Request.Url)
+		suite.Equal(entries[1], queued[1].Request.Url)
+	})
+
+	suite.Run("ConcurrentPlaylistsInDistinctSeasons", func() {
+		// Arrange
+		st := store.NewMemoryStore()
+		service := NewProcessService(suite.cfg, suite.log, slowSeasonStore{st}, suite.mockDown, suite.mockFeeder)
+		parents := make([]*entities.Process, 4)
+		for i := range parents {
+			parents[i] = &entities.Process{
+				Request: entities.Request{ID: fmt.Sprintf("playlist%d", i), ChatID: 1, MessageID: i + 1, Url: fmt.Sprintf("https://example.com/playlist?list=%d", i)},
+				Step:    entities.StepDownloading,
+				Status:  entities.StatusInProgress,
+			}
+			suite.Require().NoError(st.ProcessUpsert(suite.ctx, parents[i]))
+		}
+		go func() {
+			for range service.Out() {
+			}
+		}()
+
+		// Act
+		seasons := make(chan int, len(parents))
+		for range parents {
+			go func() { seasons <- (<-service.requeue)[0].Request.SeasonNumber }()
+		}
+		for _, parent := range parents {
+			go service.expand(suite.ctx, parent, entries)
+		}
+
+		// Assert
+		seen := make(map[int]bool)
+		for range parents {
+			select {
+			case season := <-seasons:
+				suite.False(seen[season], "season %d allocated twice", season)
+				seen[season] = true
+			case <-time.After(time.Second):
+				suite.FailNow("playlist not expanded")
+			}
+		}
+	})
+}
+
+// slowSeasonStore is a store delaying the processes in progress read for the season,
+// so that concurrent playlist expansions overlap
+type slowSeasonStore struct {
+	store.Store
+}
+
+func (s slowSeasonStore) ProcessGetByStatus(ctx context.Context, status entities.Status) ([]*entities.Process, error) {
+	processes, err := s.Store.ProcessGetByStatus(ctx, status)
+	time.Sleep(10 * time.Millisecond)
+	return processes, err
+}
+
+// TestActive tests the Active method
+func (suite *TestProcessServiceSuite) TestActive() {
+	suite.Run("SortedByAcceptance", func() {
+		// Arrange
+		suite.mockStore.On("ProcessGetByStatus", suite.ctx, entities.StatusInProgress).Return([]*entities.Process{
+			{ID: 3, Step: entities.StepCreating},
+			{ID: 1, Step: entities.StepPublishing},
+			{ID: 2, Step: entities.StepDownloading},
+		}, nil)
+
+		// Act
+		processes, err := suite.service.Active(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(processes, 3)
+		suite.Equal(int64(1), processes[0].ID)
+		suite.Equal(int64(2), processes[1].ID)
+		suite.Equal(int64(3), processes[2].ID)
+	})
+
+	suite.Run("StoreError", func() {
+		// Arrange
+		suite.mockStore.On("ProcessGetByStatus", suite.ctx, entities.StatusInProgress).Return(nil, errors.New("db error"))
+
+		// Act
+		processes, err := suite.service.Active(suite.ctx)
+
+		// Assert
+		suite.ErrorIs(err, ErrProcessGetByStatus)
+		suite.Nil(processes)
+	})
 }
 
 // TestInit tests the Init method
@@ -133,6 +730,106 @@ func (suite *TestProcessServiceSuite) TestInit() {
 		suite.True(true) // Test passes if we get here without hanging
 	})
 
+	suite.Run("QueuedProcessesResumed", func() {
+		// Arrange
+		processes := []*entities.Process{
+			{ID: 3, Step: entities.StepCreating, Status: entities.StatusInProgress},
+			{ID: 2, Step: entities.StepDownloading, Status: entities.StatusInProgress},
+			{ID: 1, Step: entities.StepCreating, Status: entities.StatusInProgress},
+		}
+		suite.mockStore.On("ProcessGetByStatus", suite.ctx, entities.StatusInProgress).
+			Return(processes, nil)
+		suite.mockStore.On("ProcessUpsert", suite.ctx, mock.MatchedBy(func(p *entities.Process) bool {
+			return p.ID == 2 && p.Status == entities.StatusFailed && errors.Is(p.Error, ErrProcessInterrupted)
+		})).Return(nil).Once()
+
+		// Act
+		err := suite.service.Init(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(suite.service.resumed, 2)
+		suite.Equal(int64(1), suite.service.resumed[0].ID, "processes must be resumed in the order they were accepted")
+		suite.Equal(int64(3), suite.service.resumed[1].ID)
+		for _, p := range suite.service.resumed {
+			suite.Equal(entities.StatusInProgress, p.Status)
+			suite.Nil(p.Error)
+		}
+	})
+
+	suite.Run("PublishingProcessesRetried", func() {
+		// Arrange
+		queued := &entities.Process{ID: 1, Step: entities.StepCreating, Status: entities.StatusInProgress}
+		downloading := &entities.Process{ID: 2, Step: entities.StepDownloading, Status: entities.StatusInProgress}
+		publishing := &entities.Process{ID: 3, Step: entities.StepPublishing, Status: entities.StatusInProgress}
+		suite.mockStore.On("ProcessGetByStatus", suite.ctx, entities.StatusInProgress).
+			Return([]*entities.Process{queued, downloading, publishing}, nil)
+		suite.mockStore.On("ProcessUpsert", suite.ctx, mock.MatchedBy(func(p *entities.Process) bool {
+			return p.ID == 2 && p.Status == entities.StatusFailed && errors.Is(p.Error, ErrProcessInterrupted)
+		})).Return(nil).Once()
+		suite.mockStore.On("ProcessUpsert", suite.ctx, mock.MatchedBy(func(p *entities.Process) bool {
+			return p.ID == 3 && p.Status == entities.StatusSuccess && p.Error == nil
+		})).Return(nil).Once()
+		suite.mockFeeder.On("Build", suite.ctx).Return(nil).Once()
+
+		// Act
+		err := suite.service.Init(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(suite.service.resumed, 1)
+		suite.Equal(queued, suite.service.resumed[0])
+		suite.Equal(entities.StatusFailed, downloading.Status)
+		suite.Equal(entities.StatusSuccess, publishing.Status)
+		suite.Contains(publishing.StepTimings, entities.StepPublishing)
+	})
+
+	suite.Run("PublishingProcessesBuildOnce", func() {
+		// Arrange
+		publishing := []*entities.Process{
+			{ID: 1, Step: entities.StepPublishing, Status: entities.StatusInProgress},
+			{ID: 2, Step: entities.StepPublishing, Status: entities.StatusInProgress},
+			{ID: 3, Step: entities.StepPublishing, Status: entities.StatusInProgress},
+		}
+		suite.mockStore.On("ProcessGetByStatus", suite.ctx, entities.StatusInProgress).Return(slices.Clone(publishing), nil)
+		suite.mockStore.On("ProcessUpsert", suite.ctx, mock.MatchedBy(func(p *entities.Process) bool {
+			return p.Status == entities.StatusSuccess && p.Error == nil
+		})).Return(nil).Times(3)
+		suite.mockFeeder.On("Build", suite.ctx).Return(nil).Once()
+
+		// Act
+		err := suite.service.Init(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.mockFeeder.AssertNumberOfCalls(suite.T(), "Build", 1)
+		for _, p := range publishing {
+			suite.Equal(entities.StatusSuccess, p.Status)
+		}
+	})
+
+	suite.Run("PublishingProcessesBuildFailed", func() {
+		// Arrange
+		publishing := []*entities.Process{
+			{ID: 1, Step: entities.StepPublishing, Status: entities.StatusInProgress},
+			{ID: 2, Step: entities.StepPublishing, Status: entities.StatusInProgress},
+		}
+		suite.mockStore.On("ProcessGetByStatus", suite.ctx, entities.StatusInProgress).Return(slices.Clone(publishing), nil)
+		suite.mockStore.On("ProcessUpsert", suite.ctx, mock.MatchedBy(func(p *entities.Process) bool {
+			return p.Status == entities.StatusFailed && errors.Is(p.Error, ErrFeedSave)
+		})).Return(nil).Times(2)
+		suite.mockFeeder.On("Build", suite.ctx).Return(ErrFeedSave).Once()
+
+		// Act
+		err := suite.service.Init(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		for _, p := range publishing {
+			suite.Equal(entities.StatusFailed, p.Status)
+		}
+	})
+
 	suite.Run("StoreError", func() {
 		// Arrange
 		suite.mockStore.On("ProcessGetByStatus", suite.ctx, entities.StatusInProgress).
@@ -166,9 +863,137 @@ func (suite *TestProcessServiceSuite) TestInit() {
 	})
 }
 
+// TestDownload tests that the download is retried only on transient failures
+func (suite *TestProcessServiceSuite) TestDownload() {
+	process := &entities.Process{
+		ID:      1,
+		Request: entities.Request{ID: "req1", Url: "https://example.com/video"},
+	}
+	episode := &entities.Episode{ID: 1}
+	retryable := fmt.Errorf("%w: %w", ErrDownloadFailed, fmt.Errorf("%w: HTTP Error 503", ErrRetryable))
+	permanent := fmt.Errorf("%w: %w", ErrDownloadFailed, fmt.Errorf("%w: Private video", ErrPermanent))
+
+	// newService creates a service configured with the given number of retries
+	newService := func(retries int) *ProcessService {
+		cfg := *suite.cfg
+		cfg.DownloadRetries = retries
+		cfg.DownloadRetryDelay = time.Millisecond
+		return NewProcessService(&cfg, suite.log, suite.mockStore, suite.mockDown, suite.mockFeeder)
+	}
+
+	suite.Run("RetryableThenSuccess", func() {
+		// Arrange
+		service := newService(2)
+		suite.mockDown.On("Download", suite.ctx, process.Request, mock.Anything).Return(nil, retryable).Once()
+		suite.mockDown.EXPECT().Download(suite.ctx, process.Request, mock.Anything).RunAndReturn(suite.downloaded(episode)).Once()
+
+		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessWithEpisode(episode)).Return(nil).Once()
+
+		// Act
+		err := service.download(suite.ctx, process, time.Now())
+
+		// Assert
+		suite.NoError(err)
+		suite.Equal(episode, process.Episode)
+		suite.Equal(entities.StepPublishing, process.Step)
+		suite.mockDown.AssertNumberOfCalls(suite.T(), "Download", 2)
+	})
+
+	suite.Run("RetriesExhausted", func() {
+		// Arrange
+		service := newService(2)
+		suite.mockDown.On("Download", suite.ctx, process.Request, mock.Anything).Return(nil, retryable)
+
+		// Act
+		err := service.download(suite.ctx, process, time.Now())
+
+		// Assert
+		suite.Nil(process.Episode)
+		suite.ErrorIs(err, ErrRetryable)
+		suite.ErrorIs(err, ErrDownloadFailed)
+		suite.mockDown.AssertNumberOfCalls(suite.T(), "Download", 3)
+	})
+
+	suite.Run("PermanentNotRetried", func() {
+		// Arrange
+		service := newService(2)
+		suite.mockDown.On("Download", suite.ctx, process.Request, mock.Anything).Return(nil, permanent).Once()
+
+		// Act
+		err := service.download(suite.ctx, process, time.Now())
+
+		// Assert
+		suite.Nil(process.Episode)
+		suite.ErrorIs(err, ErrPermanent)
+		suite.mockDown.AssertNumberOfCalls(suite.T(), "Download", 1)
+	})
+
+	suite.Run("UnclassifiedNotRetried", func() {
+		// Arrange
+		service := newService(2)
+		suite.mockDown.On("Download", suite.ctx, process.Request, mock.Anything).Return(nil, ErrInvalidRequest).Once()
+
+		// Act
+		err := service.download(suite.ctx, process, time.Now())
+
+		// Assert
+		suite.ErrorIs(err, ErrInvalidRequest)
+		suite.mockDown.AssertNumberOfCalls(suite.T(), "Download", 1)
+	})
+
+	suite.Run("RetriesDisabled", func() {
+		// Arrange
+		service := newService(0)
+		suite.mockDown.On("Download", suite.ctx, process.Request, mock.Anything).Return(nil, retryable).Once()
+
+		// Act
+		err := service.download(suite.ctx, process, time.Now())
+
+		// Assert
+		suite.ErrorIs(err, ErrRetryable)
+		suite.mockDown.AssertNumberOfCalls(suite.T(), "Download", 1)
+	})
+
+	suite.Run("PublishingUpsertFails", func() {
+		// Arrange
+		service := newService(2)
+		suite.mockDown.EXPECT().Download(suite.ctx, process.Request, mock.Anything).RunAndReturn(suite.downloaded(episode)).Once()
+		suite.mockStore.On("ProcessUpsert", suite.ctx, mock.Anything).Return(errors.New("db error")).Once()
+
+		// Act
+		err := service.download(suite.ctx, process, time.Now())
+
+		// Assert
+		suite.ErrorIs(err, ErrProcessUpsert)
+		suite.Nil(process.Episode)
+		suite.Equal(entities.StepDownloading, process.Step)
+		suite.NotContains(process.StepTimings, entities.StepDownloading)
+		suite.mockDown.AssertNumberOfCalls(suite.T(), "Download", 1)
+	})
+
+	suite.Run("ContextCanceled", func() {
+		// Arrange
+		ctx, cancel := context.WithCancel(suite.ctx)
+		cancel()
+		cfg := *suite.cfg
+		cfg.DownloadRetries = 2
+		cfg.DownloadRetryDelay = time.Hour
+		service := NewProcessService(&cfg, suite.log, suite.mockStore, suite.mockDown, suite.mockFeeder)
+		suite.mockDown.On("Download", ctx, process.Request, mock.Anything).Return(nil, retryable).Once()
+
+		// Act
+		err := service.download(ctx, process, time.Now())
+
+		// Assert
+		suite.ErrorIs(err, ErrRetryable)
+		suite.mockDown.AssertNumberOfCalls(suite.T(), "Download", 1)
+	})
+}
+
 // TestValidate tests the validate method
 func (suite *TestProcessServiceSuite) TestValidate() {
 	process := &entities.Process{
+		ID: 2,
 		Request: entities.Request{
 			Url:   "https://example.com/video",
 			Force: false,
@@ -177,10 +1002,10 @@ func (suite *TestProcessServiceSuite) TestValidate() {
 
 	suite.Run("Success", func() {
 		// Arrange
-		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, process.Request.Url, entities.StatusInProgress).
-			Return(1, nil) // Count of 1 is OK (current process)
-		suite.mockStore.On("EpisodeGetByOriginalUrl", suite.ctx, process.Request.Url).
-			Return([]*entities.Episode{}, nil)
+		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, process.Request.Url, entities.StatusInProgress, process.ID).
+			Return(0, nil)
+		suite.mockStore.On("EpisodeExistsByOriginalUrl", suite.ctx, process.Request.Url).
+			Return(false, nil)
 
 		// Act
 		err := suite.service.validate(suite.ctx, process)
@@ -191,8 +1016,8 @@ func (suite *TestProcessServiceSuite) TestValidate() {
 
 	suite.Run("EpisodeInProgress", func() {
 		// Arrange
-		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, process.Request.Url, entities.StatusInProgress).
-			Return(2, nil) // Count > 1 triggers error
+		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, process.Request.Url, entities.StatusInProgress, process.ID).
+			Return(1, nil) // Earlier process triggers error
 
 		// Act
 		err := suite.service.validate(suite.ctx, process)
@@ -204,11 +1029,10 @@ func (suite *TestProcessServiceSuite) TestValidate() {
 
 	suite.Run("EpisodeExists", func() {
 		// Arrange
-		existingEpisode := &entities.Episode{ID: 1, OriginalURL: process.Request.Url}
-		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, process.Request.Url, entities.StatusInProgress).
-			Return(1, nil) // Count of 1 is OK
-		suite.mockStore.On("EpisodeGetByOriginalUrl", suite.ctx, process.Request.Url).
-			Return([]*entities.Episode{existingEpisode}, nil)
+		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, process.Request.Url, entities.StatusInProgress, process.ID).
+			Return(0, nil)
+		suite.mockStore.On("EpisodeExistsByOriginalUrl", suite.ctx, process.Request.Url).
+			Return(true, nil)
 
 		// Act
 		err := suite.service.validate(suite.ctx, process)
@@ -221,13 +1045,14 @@ func (suite *TestProcessServiceSuite) TestValidate() {
 	suite.Run("ForceDownload", func() {
 		// Arrange
 		processForce := &entities.Process{
+			ID: 3,
 			Request: entities.Request{
 				Url:   "https://example.com/video",
 				Force: true,
 			},
 		}
-		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, processForce.Request.Url, entities.StatusInProgress).
-			Return(1, nil) // Count of 1 is OK
+		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, processForce.Request.Url, entities.StatusInProgress, processForce.ID).
+			Return(0, nil)
 
 		// Act
 		err := suite.service.validate(suite.ctx, processForce)
@@ -238,8 +1063,8 @@ func (suite *TestProcessServiceSuite) TestValidate() {
 
 	suite.Run("ProcessCountError", func() {
 		// Arrange
-		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, process.Request.Url, entities.StatusInProgress).
-			Return(0, errors.New("count error"))
+		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, process.Request.Url, entities.StatusInProgress, process.ID).
+			Return(0, errors.New("store error"))
 
 		// Act
 		err := suite.service.validate(suite.ctx, process)
@@ -249,19 +1074,19 @@ func (suite *TestProcessServiceSuite) TestValidate() {
 		suite.True(errors.Is(err, ErrProcessCountByUrlAndStatus))
 	})
 
-	suite.Run("EpisodeGetError", func() {
+	suite.Run("EpisodeExistsError", func() {
 		// Arrange
-		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, process.Request.Url, entities.StatusInProgress).
-			Return(1, nil)
-		suite.mockStore.On("EpisodeGetByOriginalUrl", suite.ctx, process.Request.Url).
-			Return(nil, errors.New("get episode error"))
+		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, process.Request.Url, entities.StatusInProgress, process.ID).
+			Return(0, nil)
+		suite.mockStore.On("EpisodeExistsByOriginalUrl", suite.ctx, process.Request.Url).
+			Return(false, errors.New("exists error"))
 
 		// Act
 		err := suite.service.validate(suite.ctx, process)
 
 		// Assert
 		suite.Error(err)
-		suite.True(errors.Is(err, ErrEpisodeGetByOriginalURL))
+		suite.True(errors.Is(err, ErrEpisodeExistsByOriginalURL))
 	})
 }
 
@@ -288,15 +1113,19 @@ func (suite *TestProcessServiceSuite) TestHandle() {
 		MediaDuration: 3600,
 	}
 
+	// newProcess returns the process accepted for the request
+	newProcess := func() *entities.Process {
+		return &entities.Process{ID: 2, Request: *request, Step: entities.StepCreating, Status: entities.StatusInProgress}
+	}
+
 	suite.Run("SuccessfulProcess", func() {
 		// Arrange - validate step
-		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessStep(entities.StepCreating)).Return(nil)
-		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, request.Url, entities.StatusInProgress).Return(1, nil)
-		suite.mockStore.On("EpisodeGetByOriginalUrl", suite.ctx, request.Url).Return([]*entities.Episode{}, nil)
+		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, request.Url, entities.StatusInProgress, int64(2)).Return(0, nil)
+		suite.mockStore.On("EpisodeExistsByOriginalUrl", suite.ctx, request.Url).Return(false, nil)
 
 		// Arrange - download step
 		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessStep(entities.StepDownloading)).Return(nil)
-		suite.mockDown.On("Download", suite.ctx, *request).Return(episode, nil)
+		suite.mockDown.EXPECT().Download(suite.ctx, *request, mock.Anything).RunAndReturn(suite.downloaded(episode))
 		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessWithEpisode(episode)).Return(nil)
 
 		// Arrange - publish step
@@ -320,7 +1149,7 @@ func (suite *TestProcessServiceSuite) TestHandle() {
 		}()
 
 		// Act
-		suite.service.handle(suite.ctx, *request)
+		suite.service.handle(suite.ctx, newProcess())
 
 		// Wait for consumer to finish to avoid race with next subtest
 		select {
@@ -334,10 +1163,49 @@ func (suite *TestProcessServiceSuite) TestHandle() {
 		suite.mockFeeder.AssertExpectations(suite.T())
 	})
 
+	suite.Run("StepTimings", func() {
+		// Arrange
+		suite.mockStore.On("ProcessUpsert", suite.ctx, mock.Anything).Return(nil)
+		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, request.Url, entities.StatusInProgress, int64(2)).Return(0, nil)
+		suite.mockStore.On("EpisodeExistsByOriginalUrl", suite.ctx, request.Url).Return(false, nil)
+		suite.mockDown.EXPECT().Download(suite.ctx, *request, mock.Anything).RunAndReturn(suite.downloaded(episode))
+		suite.mockFeeder.On("Build", suite.ctx).Return(nil)
+
+		ch := suite.service.Out()
+		result := make(chan entities.Process, 1)
+		go func() {
+			for {
+				select {
+				case p := <-ch:
+					if p.Status == entities.StatusSuccess {
+						result <- p
+						return
+					}
+				case <-time.After(300 * time.Millisecond):
+					return
+				}
+			}
+		}()
+
+		// Act
+		suite.service.handle(suite.ctx, newProcess())
+
+		// Assert
+		select {
+		case p := <-result:
+			suite.Len(p.StepTimings, 3)
+			suite.Contains(p.StepTimings, entities.StepCreating)
+			suite.Contains(p.StepTimings, entities.StepDownloading)
+			suite.Contains(p.StepTimings, entities.StepPublishing)
+		case <-time.After(time.Second):
+			suite.Fail("success notification not received")
+		}
+	})
+
 	suite.Run("ValidationFailure", func() {
 		// Arrange
-		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessStep(entities.StepCreating)).Return(nil)
-		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, request.Url, entities.StatusInProgress).Return(2, nil) // Count > 1 triggers error
+		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, request.Url, entities.StatusInProgress, int64(2)).
+			Return(1, nil) // Earlier process triggers error
 		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessError(ErrEpisodeInProgress)).Return(nil)
 
 		// Start goroutine to consume notifications
@@ -356,7 +1224,7 @@ func (suite *TestProcessServiceSuite) TestHandle() {
 		}()
 
 		// Act
-		suite.service.handle(suite.ctx, *request)
+		suite.service.handle(suite.ctx, newProcess())
 
 		// Wait for consumer to finish
 		select {
@@ -370,13 +1238,12 @@ func (suite *TestProcessServiceSuite) TestHandle() {
 
 	suite.Run("DownloadFailure", func() {
 		// Arrange - validate step
-		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessStep(entities.StepCreating)).Return(nil)
-		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, request.Url, entities.StatusInProgress).Return(1, nil)
-		suite.mockStore.On("EpisodeGetByOriginalUrl", suite.ctx, request.Url).Return([]*entities.Episode{}, nil)
+		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, request.Url, entities.StatusInProgress, int64(2)).Return(0, nil)
+		suite.mockStore.On("EpisodeExistsByOriginalUrl", suite.ctx, request.Url).Return(false, nil)
 
 		// Arrange - download step failure
 		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessStep(entities.StepDownloading)).Return(nil)
-		suite.mockDown.On("Download", suite.ctx, *request).Return(nil, ErrDownloadFailed)
+		suite.mockDown.On("Download", suite.ctx, *request, mock.Anything).Return(nil, ErrDownloadFailed)
 		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessError(ErrDownloadFailed)).Return(nil)
 
 		// Start goroutine to consume notifications
@@ -395,7 +1262,7 @@ func (suite *TestProcessServiceSuite) TestHandle() {
 		}()
 
 		// Act
-		suite.service.handle(suite.ctx, *request)
+		suite.service.handle(suite.ctx, newProcess())
 
 		// Wait for consumer to finish
 		select {
@@ -410,13 +1277,12 @@ func (suite *TestProcessServiceSuite) TestHandle() {
 
 	suite.Run("BuildFailure", func() {
 		// Arrange - validate step
-		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessStep(entities.StepCreating)).Return(nil)
-		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, request.Url, entities.StatusInProgress).Return(1, nil)
-		suite.mockStore.On("EpisodeGetByOriginalUrl", suite.ctx, request.Url).Return([]*entities.Episode{}, nil)
+		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, request.Url, entities.StatusInProgress, int64(2)).Return(0, nil)
+		suite.mockStore.On("EpisodeExistsByOriginalUrl", suite.ctx, request.Url).Return(false, nil)
 
 		// Arrange - download step
 		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessStep(entities.StepDownloading)).Return(nil)
-		suite.mockDown.On("Download", suite.ctx, *request).Return(episode, nil)
+		suite.mockDown.EXPECT().Download(suite.ctx, *request, mock.Anything).RunAndReturn(suite.downloaded(episode))
 		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessWithEpisode(episode)).Return(nil)
 
 		// Arrange - publish step failure
@@ -440,7 +1306,7 @@ func (suite *TestProcessServiceSuite) TestHandle() {
 		}()
 
 		// Act
-		suite.service.handle(suite.ctx, *request)
+		suite.service.handle(suite.ctx, newProcess())
 
 		// Wait for consumer to finish
 		select {
@@ -456,7 +1322,9 @@ func (suite *TestProcessServiceSuite) TestHandle() {
 
 	suite.Run("UpsertFailure", func() {
 		// Arrange
-		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessStep(entities.StepCreating)).Return(ErrProcessUpsert)
+		suite.mockStore.On("ProcessCountByUrlAndStatus", suite.ctx, request.Url, entities.StatusInProgress, int64(2)).Return(0, nil)
+		suite.mockStore.On("EpisodeExistsByOriginalUrl", suite.ctx, request.Url).Return(false, nil)
+		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessStep(entities.StepDownloading)).Return(ErrProcessUpsert).Once()
 		suite.mockStore.On("ProcessUpsert", suite.ctx, suite.matchProcessError(ErrProcessUpsert)).Return(nil)
 
 		// Start goroutine to consume notification
@@ -473,7 +1341,7 @@ func (suite *TestProcessServiceSuite) TestHandle() {
 		}()
 
 		// Act
-		suite.service.handle(suite.ctx, *request)
+		suite.service.handle(suite.ctx, newProcess())
 
 		// Wait for consumer to finish
 		select {
@@ -632,6 +1500,34 @@ func (suite *TestProcessServiceSuite) TestFail() {
 }
 
 // Helper matchers for mock arguments
+// downloaded returns a Download implementation calling the save function
+// with the mock store as the transaction and returning the episode.
+func (suite *TestProcessServiceSuite) downloaded(episode *entities.Episode) func(context.Context, entities.Request, func(context.Context, store.Store, *entities.Episode) error) (*entities.Episode, error) {
+	return func(ctx context.Context, _ entities.Request, save func(context.Context, store.Store, *entities.Episode) error) (*entities.Episode, error) {
+		if save != nil {
+			if err := save(ctx, suite.mockStore, episode); err != nil {
+				return nil, err
+			}
+		}
+		return episode, nil
+	}
+}
+
+// waitStatus returns the first notification of the suite service with the given status
+func (suite *TestProcessServiceSuite) waitStatus(status entities.Status) entities.Process {
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case p := <-suite.service.Out():
+			if p.Status == status {
+				return p
+			}
+		case <-timeout:
+			suite.FailNow("notification not received", "status %s", status)
+		}
+	}
+}
+
 func (suite *TestProcessServiceSuite) matchProcessStep(step entities.Step) interface{} {
 	return mock.MatchedBy(func(p *entities.Process) bool {
 		return p.Step == step
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PlatformFactory creates a platform matching URLs of the given host patterns.
+type PlatformFactory func(hosts []string) Platform
+
+// PlatformRegistry maps platform names to their implementations and default host patterns.
+// Platforms to use are picked from the registry by name at startup, see [PlatformRegistry.Build].
+type PlatformRegistry struct {
+	factories map[string]PlatformFactory
+	hosts     map[string][]string
+}
+
+// NewPlatformRegistry creates an empty PlatformRegistry.
+func NewPlatformRegistry() *PlatformRegistry {
+	return &PlatformRegistry{
+		factories: make(map[string]PlatformFactory),
+		hosts:     make(map[string][]string),
+	}
+}
+
+// Register adds a platform under the given name with its default host patterns.
+// Registering the same name again replaces the previous platform.
+func (r *PlatformRegistry) Register(name string, hosts []string, factory PlatformFactory) *PlatformRegistry {
+	r.factories[name] = factory
+	r.hosts[name] = hosts
+	return r
+}
+
+// Build creates the platforms listed in names.
+// Platforms are returned in the order of names, so the first listed platform
+// takes priority when several of them match the same URL.
+// Host patterns in hosts override the registered defaults of the platform with the same name.
+func (r *PlatformRegistry) Build(names []string, hosts map[string][]string) ([]Platform, error) {
+	if len(names) == 0 {
+		return nil, errors.New("no platforms enabled")
+	}
+	for name := range hosts {
+		if _, ok := r.factories[name]; !ok {
+			return nil, fmt.Errorf("hosts configured for unknown platform: %s", name)
+		}
+	}
+
+	platforms := make([]Platform, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		factory, ok := r.factories[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown platform: %s", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("platform enabled twice: %s", name)
+		}
+		seen[name] = true
+
+		h, ok := hosts[name]
+		if !ok {
+			h = r.hosts[name]
+		}
+		if len(h) == 0 {
+			return nil, fmt.Errorf("no hosts configured for platform: %s", name)
+		}
+		platforms = append(platforms, factory(h))
+	}
+	return platforms, nil
+}
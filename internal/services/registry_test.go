@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ofstudio/voxify/internal/config"
+	"github.com/ofstudio/voxify/internal/entities"
+	"github.com/ofstudio/voxify/internal/mocks"
+)
+
+// TestPlatformRegistrySuite is a test suite for PlatformRegistry
+type TestPlatformRegistrySuite struct {
+	suite.Suite
+	registry *PlatformRegistry
+}
+
+// SetupSubTest is called before each subtest
+func (suite *TestPlatformRegistrySuite) SetupSubTest() {
+	suite.registry = NewPlatformRegistry().
+		Register("youtube", []string{"youtube.com"}, suite.factory("youtube")).
+		Register("soundcloud", []string{"soundcloud.com"}, suite.factory("soundcloud")).
+		Register("vimeo", []string{"vimeo.com"}, suite.factory("vimeo"))
+}
+
+// factory returns a PlatformFactory creating mock platforms with the given ID
+// matching URLs of the given hosts exactly.
+func (suite *TestPlatformRegistrySuite) factory(id string) PlatformFactory {
+	return func(hosts []string) Platform {
+		p := mocks.NewMockPlatform(suite.T())
+		p.EXPECT().ID().Return(id).Maybe()
+		p.EXPECT().Match(mock.Anything).RunAndReturn(func(href string) bool {
+			u, err := url.Parse(href)
+			return err == nil && slices.Contains(hosts, u.Host)
+		}).Maybe()
+		return p
+	}
+}
+
+// ids returns the IDs of the platforms in order
+func ids(platforms []Platform) []string {
+	result := make([]string, 0, len(platforms))
+	for _, p := range platforms {
+		result = append(result, p.ID())
+	}
+	return result
+}
+
+// TestBuild tests the Build method
+func (suite *TestPlatformRegistrySuite) TestBuild() {
+	suite.Run("ConfiguredOrder", func() {
+		// Act
+		platforms, err := suite.registry.Build([]string{"vimeo", "youtube"}, nil)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal([]string{"vimeo", "youtube"}, ids(platforms))
+	})
+
+	suite.Run("AllPlatforms", func() {
+		// Act
+		platforms, err := suite.registry.Build([]string{"youtube", "soundcloud", "vimeo"}, nil)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal([]string{"youtube", "soundcloud", "vimeo"}, ids(platforms))
+	})
+
+	suite.Run("DefaultHosts", func() {
+		// Act
+		platforms, err := suite.registry.Build([]string{"youtube"}, nil)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.True(platforms[0].Match("https://youtube.com/watch?v=abc"))
+	})
+
+	suite.Run("HostsOverride", func() {
+		// Act
+		platforms, err := suite.registry.Build(
+			[]string{"youtube"},
+			map[string][]string{"youtube": {"youtube.example.com"}},
+		)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.True(platforms[0].Match("https://youtube.example.com/watch?v=abc"))
+		suite.False(platforms[0].Match("https://youtube.com/watch?v=abc"))
+	})
+
+	suite.Run("UnknownPlatform", func() {
+		// Act
+		_, err := suite.registry.Build([]string{"youtube", "dailymotion"}, nil)
+
+		// Assert
+		suite.ErrorContains(err, "unknown platform: dailymotion")
+	})
+
+	suite.Run("HostsOfUnknownPlatform", func() {
+		// Act
+		_, err := suite.registry.Build([]string{"youtube"}, map[string][]string{"dailymotion": {"dailymotion.com"}})
+
+		// Assert
+		suite.ErrorContains(err, "hosts configured for unknown platform: dailymotion")
+	})
+
+	suite.Run("EnabledTwice", func() {
+		// Act
+		_, err := suite.registry.Build([]string{"youtube", "vimeo", "youtube"}, nil)
+
+		// Assert
+		suite.ErrorContains(err, "platform enabled twice: youtube")
+	})
+
+	suite.Run("NoHosts", func() {
+		// Act
+		_, err := suite.registry.Build([]string{"youtube"}, map[string][]string{"youtube": {}})
+
+		// Assert
+		suite.ErrorContains(err, "no hosts configured for platform: youtube")
+	})
+
+	suite.Run("NoPlatforms", func() {
+		// Act
+		_, err := suite.registry.Build(nil, nil)
+
+		// Assert
+		suite.ErrorContains(err, "no platforms enabled")
+	})
+}
+
+// TestFindPlatform tests that the episode service picks platforms in the registry order
+func (suite *TestPlatformRegistrySuite) TestFindPlatform() {
+	cfg := &config.Settings{
+		DownloadFormat:           entities.DownloadMp3,
+		DownloadQuality:          "192k",
+		SupportedDownloadFormats: []entities.DownloadFormat{entities.DownloadMp3},
+	}
+
+	suite.Run("FirstMatchingWins", func() {
+		// Arrange
+		platforms, err := suite.registry.Build(
+			[]string{"vimeo", "youtube"},
+			map[string][]string{"vimeo": {"youtube.com"}},
+		)
+		suite.Require().NoError(err)
+		service := NewEpisodeService(cfg, slog.Default(), nil, nil, platforms...)
+
+		// Act
+		platform := service.findPlatform("https://youtube.com/watch?v=abc")
+
+		// Assert
+		suite.Require().NotNil(platform)
+		suite.Equal("vimeo", platform.ID())
+	})
+
+	suite.Run("UnmatchedURL", func() {
+		// Arrange
+		platforms, err := suite.registry.Build([]string{"youtube", "soundcloud"}, nil)
+		suite.Require().NoError(err)
+		service := NewEpisodeService(cfg, slog.Default(), nil, nil, platforms...)
+
+		// Act
+		episode, err := service.Download(context.Background(), entities.Request{Url: "https://vimeo.com/123"}, nil)
+
+		// Assert
+		suite.ErrorIs(err, ErrNoMatchingPlatform)
+		suite.Nil(episode)
+	})
+}
+
+func TestPlatformRegistry(t *testing.T) {
+	suite.Run(t, new(TestPlatformRegistrySuite))
+}
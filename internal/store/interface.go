@@ -2,11 +2,15 @@ package store
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/ofstudio/voxify/internal/entities"
 )
 
+// ErrNotFound is returned when the requested record does not exist.
+var ErrNotFound = errors.New("not found")
+
 // Store defines the interface for a data store that manages episodes and processes.
 type Store interface {
 	// Close the store and release all resources.
@@ -22,15 +26,40 @@ type Store interface {
 
 	// EpisodeCreate creates a new episode record in the store.
 	EpisodeCreate(ctx context.Context, episode *entities.Episode) error
+	// EpisodeCreateBatch creates all given episode records atomically within a single transaction.
+	EpisodeCreateBatch(ctx context.Context, episodes []*entities.Episode) error
+	// EpisodeUpdate updates the episode record and sets its UpdatedAt to the current time.
+	// Returns ErrNotFound if the episode does not exist.
+	EpisodeUpdate(ctx context.Context, episode *entities.Episode) error
 	// EpisodeListAll returns all episodes from the store in descending order by creation date.
 	EpisodeListAll(ctx context.Context) ([]*entities.Episode, error)
+	// EpisodeList returns up to limit episodes skipping the first offset ones,
+	// in descending order by creation date. Negative limit returns all the episodes
+	// after offset, negative offset is treated as zero.
+	EpisodeList(ctx context.Context, limit, offset int) ([]*entities.Episode, error)
+	// EpisodeListByDateRange returns the episodes created between from and to inclusive,
+	// in descending order by creation date. Zero from or to leaves the range open on that side.
+	EpisodeListByDateRange(ctx context.Context, from, to time.Time) ([]*entities.Episode, error)
 	// EpisodeCountAll returns the total count of episodes in the store.
 	EpisodeCountAll(ctx context.Context) (int, error)
 	// EpisodeGetByOriginalUrl returns episodes matching the given original URL.
 	EpisodeGetByOriginalUrl(ctx context.Context, url string) ([]*entities.Episode, error)
+	// EpisodeGetByHash returns episodes whose media file has the given SHA-256 checksum.
+	EpisodeGetByHash(ctx context.Context, hash string) ([]*entities.Episode, error)
+	// EpisodeExistsByOriginalUrl reports whether any episode matches the given original URL.
+	EpisodeExistsByOriginalUrl(ctx context.Context, url string) (bool, error)
 	// EpisodeGetLastTime returns the creation time of the most recently added episode.
 	// If no episodes exist, it returns zero time.
 	EpisodeGetLastTime(ctx context.Context) (time.Time, error)
+	// EpisodeGetLastSeason returns the highest season number of the episodes.
+	// If no episode has a season number, it returns 0.
+	EpisodeGetLastSeason(ctx context.Context) (int, error)
+	// EpisodeGetByID returns the episode with the given ID.
+	// If the episode does not exist, it returns ErrNotFound.
+	EpisodeGetByID(ctx context.Context, id int64) (*entities.Episode, error)
+	// EpisodeDelete deletes the episode with the given ID.
+	// If the episode does not exist, it returns ErrNotFound.
+	EpisodeDelete(ctx context.Context, id int64) error
 
 	// Process methods
 
@@ -38,6 +67,10 @@ type Store interface {
 	ProcessUpsert(ctx context.Context, process *entities.Process) error
 	// ProcessGetByStatus returns processes matching the given status.
 	ProcessGetByStatus(ctx context.Context, status entities.Status) ([]*entities.Process, error)
-	// ProcessCountByUrlAndStatus returns the count of processes matching the given URL and status.
-	ProcessCountByUrlAndStatus(ctx context.Context, url string, status entities.Status) (int, error)
+	// ProcessGetByMessage returns the latest process created for the given chat message.
+	// If there is no such process, it returns ErrNotFound.
+	ProcessGetByMessage(ctx context.Context, chatID int64, messageID int) (*entities.Process, error)
+	// ProcessCountByUrlAndStatus returns the count of processes matching the given URL and status
+	// accepted before the process with the given ID.
+	ProcessCountByUrlAndStatus(ctx context.Context, url string, status entities.Status, beforeID int64) (int, error)
 }
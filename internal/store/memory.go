@@ -0,0 +1,495 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ofstudio/voxify/internal/entities"
+)
+
+// MemoryStore is an in-memory implementation of the Store interface
+// for tests and local runs without SQLite.
+//
+// Like SQLiteStore limited to a single connection, it runs one transaction at a time:
+// Begin blocks any other access to the store until the transaction is committed or rolled back.
+// A transaction works on a snapshot of the data taken on Begin, which replaces the data on Commit.
+type MemoryStore struct {
+	db   *memoryDB
+	tx   *memoryData // Transaction snapshot, nil outside of a transaction
+	done bool        // Transaction is committed or rolled back
+}
+
+// memoryDB is the data shared by a MemoryStore and its transactions.
+type memoryDB struct {
+	mu   sync.Mutex // Guards data, held for the whole transaction
+	data memoryData
+}
+
+// memoryData is a set of stored records.
+type memoryData struct {
+	episodes      map[int64]entities.Episode
+	processes     map[int64]memoryProcess
+	lastEpisodeID int64
+	lastProcessID int64
+}
+
+// memoryProcess is a stored process record referencing its episode by ID, like a foreign key.
+type memoryProcess struct {
+	process   entities.Process
+	errorText *string
+	episodeID *int64
+}
+
+// NewMemoryStore creates a new empty MemoryStore instance.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		db: &memoryDB{
+			data: memoryData{
+				episodes:  make(map[int64]entities.Episode),
+				processes: make(map[int64]memoryProcess),
+			},
+		},
+	}
+}
+
+// clone returns a copy of the data.
+func (d *memoryData) clone() *memoryData {
+	return &memoryData{
+		episodes:      maps.Clone(d.episodes),
+		processes:     maps.Clone(d.processes),
+		lastEpisodeID: d.lastEpisodeID,
+		lastProcessID: d.lastProcessID,
+	}
+}
+
+// Close does nothing: there are no resources to release.
+func (s *MemoryStore) Close() {}
+
+// Begin returns a new MemoryStore within a transaction
+func (s *MemoryStore) Begin(_ context.Context) (Store, error) {
+	if s.tx != nil {
+		return nil, fmt.Errorf("unable to start a transaction within another transaction")
+	}
+	s.db.mu.Lock()
+	return &MemoryStore{
+		db: s.db,
+		tx: s.db.data.clone(),
+	}, nil
+}
+
+// Commit commits the transaction.
+func (s *MemoryStore) Commit() error {
+	if s.tx == nil {
+		return fmt.Errorf("unable to commit outside of a transaction")
+	}
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.db.data = *s.tx
+	s.done = true
+	s.db.mu.Unlock()
+	return nil
+}
+
+// Rollback aborts the transaction.
+func (s *MemoryStore) Rollback() error {
+	if s.tx == nil {
+		return fmt.Errorf("unable to rollback outside of a transaction")
+	}
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	s.db.mu.Unlock()
+	return nil
+}
+
+// access calls fn with the transaction snapshot or, outside of a transaction, with the locked store data.
+func (s *MemoryStore) access(fn func(data *memoryData) error) error {
+	if s.tx != nil {
+		if s.done {
+			return sql.ErrTxDone
+		}
+		return fn(s.tx)
+	}
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	return fn(&s.db.data)
+}
+
+// EpisodeCreate creates a new episode in the store
+func (s *MemoryStore) EpisodeCreate(_ context.Context, episode *entities.Episode) error {
+	return s.access(func(data *memoryData) error {
+		data.lastEpisodeID++
+		now := time.Now().UTC()
+		episode.ID = data.lastEpisodeID
+		episode.CreatedAt = now
+		episode.UpdatedAt = now
+		data.episodes[episode.ID] = *cloneEpisode(*episode)
+		return nil
+	})
+}
+
+// EpisodeCreateBatch creates all given episodes within a single transaction.
+// On success, IDs and timestamps are set on each episode.
+// If any episode fails to be created, the transaction is rolled back,
+// no episodes are stored and their IDs and timestamps are reset.
+func (s *MemoryStore) EpisodeCreateBatch(ctx context.Context, episodes []*entities.Episode) (err error) {
+	tx, err := s.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			for _, episode := range episodes {
+				episode.ID = 0
+				episode.CreatedAt = time.Time{}
+				episode.UpdatedAt = time.Time{}
+			}
+		}
+	}()
+
+	for i, episode := range episodes {
+		if err = tx.EpisodeCreate(ctx, episode); err != nil {
+			return fmt.Errorf("failed to create episode %d: %w", i, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// EpisodeUpdate updates the episode metadata and media information in the store
+// and sets its UpdatedAt to the current time.
+// The original URL and creation time are not changed.
+// If the episode does not exist, it returns ErrNotFound.
+func (s *MemoryStore) EpisodeUpdate(_ context.Context, episode *entities.Episode) error {
+	return s.access(func(data *memoryData) error {
+		stored, ok := data.episodes[episode.ID]
+		if !ok {
+			return ErrNotFound
+		}
+		updated := *cloneEpisode(*episode)
+		updated.OriginalURL = stored.OriginalURL
+		updated.CreatedAt = stored.CreatedAt
+		updated.UpdatedAt = time.Now().UTC()
+		data.episodes[episode.ID] = updated
+		episode.UpdatedAt = updated.UpdatedAt
+		return nil
+	})
+}
+
+// EpisodeListAll returns all episodes from the store
+func (s *MemoryStore) EpisodeListAll(_ context.Context) ([]*entities.Episode, error) {
+	var episodes []*entities.Episode
+	err := s.access(func(data *memoryData) error {
+		episodes = data.listEpisodes(func(entities.Episode) bool { return true })
+		return nil
+	})
+	return episodes, err
+}
+
+// EpisodeList returns a page of episodes from the store, newest first
+func (s *MemoryStore) EpisodeList(_ context.Context, limit, offset int) ([]*entities.Episode, error) {
+	var episodes []*entities.Episode
+	err := s.access(func(data *memoryData) error {
+		episodes = data.listEpisodes(func(entities.Episode) bool { return true })
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Same as SQLite: negative offset skips nothing, negative limit returns all the rest
+	offset = max(offset, 0)
+	if offset >= len(episodes) {
+		return nil, nil
+	}
+	if limit < 0 {
+		limit = len(episodes)
+	}
+	return episodes[offset:min(offset+limit, len(episodes))], nil
+}
+
+// EpisodeListByDateRange returns the episodes created between from and to inclusive, newest first.
+// Zero from or to leaves the range open on that side.
+func (s *MemoryStore) EpisodeListByDateRange(_ context.Context, from, to time.Time) ([]*entities.Episode, error) {
+	var episodes []*entities.Episode
+	err := s.access(func(data *memoryData) error {
+		episodes = data.listEpisodes(func(episode entities.Episode) bool {
+			return (from.IsZero() || !episode.CreatedAt.Before(from)) &&
+				(to.IsZero() || !episode.CreatedAt.After(to))
+		})
+		return nil
+	})
+	return episodes, err
+}
+
+// EpisodeCountAll returns the total count of episodes in the store
+func (s *MemoryStore) EpisodeCountAll(_ context.Context) (int, error) {
+	var count int
+	err := s.access(func(data *memoryData) error {
+		count = len(data.episodes)
+		return nil
+	})
+	return count, err
+}
+
+// EpisodeExistsByOriginalUrl checks whether an episode with the given original URL exists
+func (s *MemoryStore) EpisodeExistsByOriginalUrl(ctx context.Context, url string) (bool, error) {
+	episodes, err := s.EpisodeGetByOriginalUrl(ctx, url)
+	return len(episodes) > 0, err
+}
+
+// EpisodeGetByOriginalUrl returns episodes by original URL
+func (s *MemoryStore) EpisodeGetByOriginalUrl(_ context.Context, url string) ([]*entities.Episode, error) {
+	var episodes []*entities.Episode
+	err := s.access(func(data *memoryData) error {
+		episodes = data.listEpisodes(func(episode entities.Episode) bool { return episode.OriginalURL == url })
+		return nil
+	})
+	return episodes, err
+}
+
+// EpisodeGetByHash returns episodes by the checksum of the media file
+func (s *MemoryStore) EpisodeGetByHash(_ context.Context, hash string) ([]*entities.Episode, error) {
+	var episodes []*entities.Episode
+	err := s.access(func(data *memoryData) error {
+		episodes = data.listEpisodes(func(episode entities.Episode) bool { return episode.MediaHash == hash })
+		return nil
+	})
+	return episodes, err
+}
+
+// EpisodeGetLastTime returns the creation time of the most recently added episode.
+// If no episodes exist, it returns zero time.
+func (s *MemoryStore) EpisodeGetLastTime(_ context.Context) (time.Time, error) {
+	var last time.Time
+	err := s.access(func(data *memoryData) error {
+		for _, episode := range data.episodes {
+			if episode.CreatedAt.After(last) {
+				last = episode.CreatedAt
+			}
+		}
+		return nil
+	})
+	return last, err
+}
+
+// EpisodeGetLastSeason returns the highest season number of the episodes.
+// If no episode has a season number, it returns 0.
+func (s *MemoryStore) EpisodeGetLastSeason(_ context.Context) (int, error) {
+	var last int
+	err := s.access(func(data *memoryData) error {
+		for _, episode := range data.episodes {
+			last = max(last, episode.SeasonNumber)
+		}
+		return nil
+	})
+	return last, err
+}
+
+// EpisodeGetByID returns an episode by its ID.
+// If the episode does not exist, it returns ErrNotFound.
+func (s *MemoryStore) EpisodeGetByID(_ context.Context, id int64) (*entities.Episode, error) {
+	var episode *entities.Episode
+	err := s.access(func(data *memoryData) error {
+		stored, ok := data.episodes[id]
+		if !ok {
+			return ErrNotFound
+		}
+		episode = cloneEpisode(stored)
+		return nil
+	})
+	return episode, err
+}
+
+// EpisodeDelete deletes an episode by its ID and clears the references to it from processes.
+// If the episode does not exist, it returns ErrNotFound.
+func (s *MemoryStore) EpisodeDelete(_ context.Context, id int64) error {
+	return s.access(func(data *memoryData) error {
+		if _, ok := data.episodes[id]; !ok {
+			return ErrNotFound
+		}
+		delete(data.episodes, id)
+		for processID, stored := range data.processes {
+			if stored.episodeID != nil && *stored.episodeID == id {
+				stored.episodeID = nil
+				data.processes[processID] = stored
+			}
+		}
+		return nil
+	})
+}
+
+// ProcessUpsert creates or updates a process in the store
+func (s *MemoryStore) ProcessUpsert(_ context.Context, process *entities.Process) error {
+	if err := validateProcess(process); err != nil {
+		return err
+	}
+	return s.access(func(data *memoryData) error {
+		stored := memoryProcess{process: *process}
+		stored.process.Error = nil
+		stored.process.Episode = nil
+		stored.process.StepTimings = cloneStepTimings(process.StepTimings)
+		if process.Episode != nil {
+			episodeID := process.Episode.ID
+			stored.episodeID = &episodeID
+		}
+		if process.Error != nil {
+			errorText := process.Error.Error()
+			stored.errorText = &errorText
+		}
+
+		// Request ID is unique
+		for id, other := range data.processes {
+			if id != process.ID && other.process.Request.ID == process.Request.ID {
+				return fmt.Errorf("failed to upsert process: request id is not unique: %s", process.Request.ID)
+			}
+		}
+
+		now := time.Now().UTC()
+		if process.ID == 0 {
+			data.lastProcessID++
+			stored.process.ID = data.lastProcessID
+			stored.process.CreatedAt = now
+		} else {
+			existing, ok := data.processes[process.ID]
+			if !ok {
+				return fmt.Errorf("failed to update process: %w", ErrNotFound)
+			}
+			stored.process.CreatedAt = existing.process.CreatedAt
+		}
+		stored.process.UpdatedAt = now
+		data.processes[stored.process.ID] = stored
+
+		process.ID = stored.process.ID
+		process.CreatedAt = stored.process.CreatedAt
+		process.UpdatedAt = stored.process.UpdatedAt
+		return nil
+	})
+}
+
+// ProcessGetByStatus returns processes by status, newest first
+func (s *MemoryStore) ProcessGetByStatus(_ context.Context, status entities.Status) ([]*entities.Process, error) {
+	var processes []*entities.Process
+	err := s.access(func(data *memoryData) error {
+		processes = data.listProcesses(func(process entities.Process) bool { return process.Status == status })
+		return nil
+	})
+	return processes, err
+}
+
+// ProcessGetByMessage returns the latest process created for the given chat message
+func (s *MemoryStore) ProcessGetByMessage(_ context.Context, chatID int64, messageID int) (*entities.Process, error) {
+	var processes []*entities.Process
+	err := s.access(func(data *memoryData) error {
+		processes = data.listProcesses(func(process entities.Process) bool {
+			return process.Request.ChatID == chatID && process.Request.MessageID == messageID
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(processes) == 0 {
+		return nil, ErrNotFound
+	}
+	return processes[0], nil
+}
+
+// ProcessCountByUrlAndStatus returns the count of processes matching the given URL and status
+// accepted before the process with the given ID.
+func (s *MemoryStore) ProcessCountByUrlAndStatus(_ context.Context, url string, status entities.Status, beforeID int64) (int, error) {
+	var count int
+	err := s.access(func(data *memoryData) error {
+		for _, stored := range data.processes {
+			if stored.process.Request.Url == url && stored.process.Status == status && stored.process.ID < beforeID {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// listEpisodes returns copies of the episodes matching the filter, newest first.
+func (d *memoryData) listEpisodes(filter func(entities.Episode) bool) []*entities.Episode {
+	var episodes []*entities.Episode
+	for _, episode := range d.episodes {
+		if filter(episode) {
+			episodes = append(episodes, cloneEpisode(episode))
+		}
+	}
+	slices.SortFunc(episodes, func(a, b *entities.Episode) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(b.ID, a.ID))
+	})
+	return episodes
+}
+
+// listProcesses returns copies of the processes matching the filter
+// with their episodes, newest first.
+func (d *memoryData) listProcesses(filter func(entities.Process) bool) []*entities.Process {
+	var processes []*entities.Process
+	for _, stored := range d.processes {
+		if !filter(stored.process) {
+			continue
+		}
+		process := stored.process
+		process.StepTimings = cloneStepTimings(stored.process.StepTimings)
+		if stored.errorText != nil {
+			process.Error = errors.New(*stored.errorText)
+		}
+		if stored.episodeID != nil {
+			if episode, ok := d.episodes[*stored.episodeID]; ok {
+				process.Episode = cloneEpisode(episode)
+			}
+		}
+		processes = append(processes, &process)
+	}
+	slices.SortFunc(processes, func(a, b *entities.Process) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(b.ID, a.ID))
+	})
+	return processes
+}
+
+// cloneEpisode returns a copy of the episode not sharing the optional flags with the original.
+func cloneEpisode(episode entities.Episode) *entities.Episode {
+	if episode.Explicit != nil {
+		explicit := *episode.Explicit
+		episode.Explicit = &explicit
+	}
+	return &episode
+}
+
+// cloneStepTimings returns a copy of the process step timings, nil if there are no timings.
+func cloneStepTimings(timings map[entities.Step]time.Duration) map[entities.Step]time.Duration {
+	if len(timings) == 0 {
+		return nil
+	}
+	return maps.Clone(timings)
+}
+
+// validateProcess checks the process step and status, like the SQLite schema constraints.
+func validateProcess(process *entities.Process) error {
+	switch process.Step {
+	case entities.StepCreating, entities.StepDownloading, entities.StepPublishing:
+	default:
+		return fmt.Errorf("failed to upsert process: invalid step: %q", process.Step)
+	}
+	switch process.Status {
+	case entities.StatusInProgress, entities.StatusSuccess, entities.StatusFailed:
+	default:
+		return fmt.Errorf("failed to upsert process: invalid status: %q", process.Status)
+	}
+	return nil
+}
@@ -0,0 +1,928 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ofstudio/voxify/internal/entities"
+)
+
+// TestStoreParitySuite is a test suite for the behavior shared by all Store implementations
+type TestStoreParitySuite struct {
+	suite.Suite
+	newStore func() Store
+	store    Store
+	ctx      context.Context
+}
+
+// SetupTest is called once before each test start
+func (suite *TestStoreParitySuite) SetupTest() {
+	suite.SetupSubTest()
+}
+
+// SetupSubTest is called before each subtest in the suite
+func (suite *TestStoreParitySuite) SetupSubTest() {
+	suite.store = suite.newStore()
+	suite.ctx = context.Background()
+}
+
+// TearDownTest is called after each test in the suite completes
+func (suite *TestStoreParitySuite) TearDownTest() {
+	suite.TearDownSubTest()
+}
+
+// TearDownSubTest is called after each subtest in the suite
+func (suite *TestStoreParitySuite) TearDownSubTest() {
+	suite.store.Close()
+}
+
+// newEpisode returns an episode to create with the given number
+func (suite *TestStoreParitySuite) newEpisode(n int) *entities.Episode {
+	return &entities.Episode{
+		Title:         fmt.Sprintf("Episode %d", n),
+		Description:   fmt.Sprintf("Description %d", n),
+		ThumbnailFile: fmt.Sprintf("thumb%d.jpg", n),
+		MediaFile:     fmt.Sprintf("audio%d.mp3", n),
+		MediaDuration: 1800,
+		MediaSize:     512000,
+		MediaHash:     fmt.Sprintf("hash%d", n),
+		MediaType:     "audio/mpeg",
+		Author:        fmt.Sprintf("Author %d", n),
+		OriginalURL:   fmt.Sprintf("https://example.com/%d", n),
+		CanonicalURL:  fmt.Sprintf("https://example.com/canonical/%d", n),
+		EpisodeNumber: n,
+		SeasonNumber:  n,
+		PublishedAt:   time.Date(2020, 1, n, 12, 30, 0, 0, time.UTC),
+	}
+}
+
+// setCreatedAt moves the creation time of the stored episode
+func (suite *TestStoreParitySuite) setCreatedAt(id int64, createdAt time.Time) {
+	switch s := suite.store.(type) {
+	case *SQLiteStore:
+		_, err := s.db.ExecContext(suite.ctx, `UPDATE episodes SET created_at = ? WHERE id = ?`,
+			createdAt.UTC().Format(time.DateTime), id)
+		suite.Require().NoError(err)
+	case *MemoryStore:
+		s.db.mu.Lock()
+		defer s.db.mu.Unlock()
+		episode := s.db.data.episodes[id]
+		episode.CreatedAt = createdAt.UTC()
+		s.db.data.episodes[id] = episode
+	default:
+		suite.FailNow("unsupported store")
+	}
+}
+
+// newProcess returns a process to create with the given request ID and status
+func (suite *TestStoreParitySuite) newProcess(id string, status entities.Status) *entities.Process {
+	return &entities.Process{
+		Request: entities.Request{
+			ID:              id,
+			UserID:          1,
+			ChatID:          10,
+			MessageID:       100,
+			Url:             "https://example.com/video",
+			DownloadFormat:  entities.DownloadMp3,
+			DownloadQuality: "192k",
+		},
+		Step:   entities.StepDownloading,
+		Status: status,
+	}
+}
+
+func (suite *TestStoreParitySuite) TestEpisodeCreate() {
+	// Arrange
+	episode := suite.newEpisode(1)
+
+	// Act
+	err := suite.store.EpisodeCreate(suite.ctx, episode)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.NotZero(episode.ID, "ID should be set after creation")
+	suite.NotZero(episode.CreatedAt, "CreatedAt should be set after creation")
+	suite.Equal(episode.CreatedAt, episode.UpdatedAt, "UpdatedAt should equal CreatedAt after creation")
+
+	stored, err := suite.store.EpisodeGetByID(suite.ctx, episode.ID)
+	suite.Require().NoError(err)
+	suite.Equal(episode, stored)
+}
+
+func (suite *TestStoreParitySuite) TestEpisodeCreate_PublishedAtNotReported() {
+	// Arrange
+	episode := suite.newEpisode(1)
+	episode.PublishedAt = time.Time{}
+
+	// Act
+	err := suite.store.EpisodeCreate(suite.ctx, episode)
+
+	// Assert
+	suite.Require().NoError(err)
+	stored, err := suite.store.EpisodeGetByID(suite.ctx, episode.ID)
+	suite.Require().NoError(err)
+	suite.Zero(stored.PublishedAt)
+	suite.Equal(stored.CreatedAt, stored.PubDate())
+}
+
+func (suite *TestStoreParitySuite) TestEpisodeExplicit() {
+	explicit, clean := true, false
+	tests := []struct {
+		name     string
+		explicit *bool
+	}{
+		{"NotSet", nil},
+		{"Explicit", &explicit},
+		{"Clean", &clean},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Arrange
+			episode := suite.newEpisode(1)
+			suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, episode))
+			episode.Explicit = tt.explicit
+
+			// Act
+			err := suite.store.EpisodeUpdate(suite.ctx, episode)
+
+			// Assert
+			suite.Require().NoError(err)
+			stored, err := suite.store.EpisodeGetByID(suite.ctx, episode.ID)
+			suite.Require().NoError(err)
+			suite.Equal(tt.explicit, stored.Explicit)
+		})
+	}
+}
+
+func (suite *TestStoreParitySuite) TestEpisodeCreateBatch() {
+	suite.Run("Success", func() {
+		// Arrange
+		episodes := []*entities.Episode{suite.newEpisode(1), suite.newEpisode(2), suite.newEpisode(3)}
+
+		// Act
+		err := suite.store.EpisodeCreateBatch(suite.ctx, episodes)
+
+		// Assert
+		suite.Require().NoError(err)
+		ids := make(map[int64]bool)
+		for _, episode := range episodes {
+			suite.NotZero(episode.ID, "ID should be set after creation")
+			ids[episode.ID] = true
+		}
+		suite.Len(ids, 3, "IDs should be unique")
+		count, err := suite.store.EpisodeCountAll(suite.ctx)
+		suite.Require().NoError(err)
+		suite.Equal(3, count)
+	})
+
+	suite.Run("WithinTransaction", func() {
+		// Arrange
+		txStore, err := suite.store.Begin(suite.ctx)
+		suite.Require().NoError(err)
+		defer func() { _ = txStore.Rollback() }()
+
+		// Act
+		err = txStore.EpisodeCreateBatch(suite.ctx, []*entities.Episode{suite.newEpisode(1)})
+
+		// Assert
+		suite.Error(err)
+	})
+}
+
+func (suite *TestStoreParitySuite) TestEpisodeList() {
+	// createEpisodes creates three episodes, the last one is the newest
+	createEpisodes := func() {
+		for i := 1; i <= 3; i++ {
+			suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, suite.newEpisode(i)))
+		}
+	}
+
+	suite.Run("All", func() {
+		// Arrange
+		createEpisodes()
+
+		// Act
+		result, err := suite.store.EpisodeListAll(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(result, 3)
+		for i := 1; i < len(result); i++ {
+			suite.False(result[i].CreatedAt.After(result[i-1].CreatedAt), "Episodes should be ordered by creation time DESC")
+		}
+	})
+
+	suite.Run("FirstPage", func() {
+		// Arrange
+		createEpisodes()
+
+		// Act
+		result, err := suite.store.EpisodeList(suite.ctx, 2, 0)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(result, 2)
+		suite.Equal("Episode 3", result[0].Title)
+		suite.Equal("Episode 2", result[1].Title)
+	})
+
+	suite.Run("LastPage", func() {
+		// Arrange
+		createEpisodes()
+
+		// Act
+		result, err := suite.store.EpisodeList(suite.ctx, 2, 2)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(result, 1)
+		suite.Equal("Episode 1", result[0].Title)
+	})
+
+	suite.Run("BeyondLastPage", func() {
+		// Arrange
+		createEpisodes()
+
+		// Act
+		result, err := suite.store.EpisodeList(suite.ctx, 2, 3)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Empty(result)
+	})
+
+	suite.Run("NegativeLimit", func() {
+		// Arrange
+		createEpisodes()
+
+		// Act
+		result, err := suite.store.EpisodeList(suite.ctx, -1, 1)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(result, 2)
+		suite.Equal("Episode 2", result[0].Title)
+		suite.Equal("Episode 1", result[1].Title)
+	})
+
+	suite.Run("NegativeOffset", func() {
+		// Arrange
+		createEpisodes()
+
+		// Act
+		result, err := suite.store.EpisodeList(suite.ctx, 2, -5)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(result, 2)
+		suite.Equal("Episode 3", result[0].Title)
+		suite.Equal("Episode 2", result[1].Title)
+	})
+}
+
+func (suite *TestStoreParitySuite) TestEpisodeListByDateRange() {
+	day := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	// createEpisodes creates episodes on January 10, February 10 and March 10 at noon
+	createEpisodes := func() {
+		for i := 1; i <= 3; i++ {
+			episode := suite.newEpisode(i)
+			suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, episode))
+			suite.setCreatedAt(episode.ID, day.AddDate(0, i-3, 0))
+		}
+	}
+	titles := func(episodes []*entities.Episode) []string {
+		result := make([]string, len(episodes))
+		for i, episode := range episodes {
+			result[i] = episode.Title
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		expected []string
+	}{
+		{"Unbounded", time.Time{}, time.Time{}, []string{"Episode 3", "Episode 2", "Episode 1"}},
+		{"Month", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 28, 23, 59, 59, 0, time.UTC), []string{"Episode 2"}},
+		{"InclusiveBounds", day.AddDate(0, -2, 0), day.AddDate(0, -1, 0), []string{"Episode 2", "Episode 1"}},
+		{"FromOnly", day.AddDate(0, -1, 0), time.Time{}, []string{"Episode 3", "Episode 2"}},
+		{"ToOnly", time.Time{}, day.AddDate(0, -1, -1), []string{"Episode 1"}},
+		{"OtherTimeZone", day.In(time.FixedZone("UTC+3", 3*60*60)), time.Time{}, []string{"Episode 3"}},
+		{"NoneInRange", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), []string{}},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Arrange
+			createEpisodes()
+
+			// Act
+			result, err := suite.store.EpisodeListByDateRange(suite.ctx, tt.from, tt.to)
+
+			// Assert
+			suite.Require().NoError(err)
+			suite.Equal(tt.expected, titles(result))
+		})
+	}
+}
+
+func (suite *TestStoreParitySuite) TestEpisodeGetByHash() {
+	suite.Run("Found", func() {
+		// Arrange
+		first, second, other := suite.newEpisode(1), suite.newEpisode(2), suite.newEpisode(3)
+		second.MediaHash = first.MediaHash
+		for _, episode := range []*entities.Episode{first, second, other} {
+			suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, episode))
+		}
+
+		// Act
+		result, err := suite.store.EpisodeGetByHash(suite.ctx, "hash1")
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(result, 2)
+		for _, episode := range result {
+			suite.Equal("hash1", episode.MediaHash)
+			suite.NotEqual("Episode 3", episode.Title)
+		}
+	})
+
+	suite.Run("Updated", func() {
+		// Arrange
+		episode := suite.newEpisode(1)
+		suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, episode))
+		episode.MediaHash = "rehashed"
+		suite.Require().NoError(suite.store.EpisodeUpdate(suite.ctx, episode))
+
+		// Act
+		stale, staleErr := suite.store.EpisodeGetByHash(suite.ctx, "hash1")
+		result, err := suite.store.EpisodeGetByHash(suite.ctx, "rehashed")
+
+		// Assert
+		suite.Require().NoError(staleErr)
+		suite.Empty(stale)
+		suite.Require().NoError(err)
+		suite.Require().Len(result, 1)
+		suite.Equal(episode.ID, result[0].ID)
+	})
+
+	suite.Run("NotFound", func() {
+		// Arrange
+		suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, suite.newEpisode(1)))
+
+		// Act
+		result, err := suite.store.EpisodeGetByHash(suite.ctx, "nonexistent")
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Empty(result)
+	})
+}
+
+func (suite *TestStoreParitySuite) TestEpisodeGetByOriginalUrl() {
+	suite.Run("Found", func() {
+		// Arrange
+		suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, suite.newEpisode(1)))
+		suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, suite.newEpisode(2)))
+
+		// Act
+		result, err := suite.store.EpisodeGetByOriginalUrl(suite.ctx, "https://example.com/1")
+		exists, existsErr := suite.store.EpisodeExistsByOriginalUrl(suite.ctx, "https://example.com/1")
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(result, 1)
+		suite.Equal("Episode 1", result[0].Title)
+		suite.Require().NoError(existsErr)
+		suite.True(exists)
+	})
+
+	suite.Run("NotFound", func() {
+		// Act
+		result, err := suite.store.EpisodeGetByOriginalUrl(suite.ctx, "https://nonexistent.com")
+		exists, existsErr := suite.store.EpisodeExistsByOriginalUrl(suite.ctx, "https://nonexistent.com")
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Empty(result)
+		suite.Require().NoError(existsErr)
+		suite.False(exists)
+	})
+}
+
+func (suite *TestStoreParitySuite) TestEpisodeGetByID() {
+	suite.Run("NotFound", func() {
+		// Act
+		result, err := suite.store.EpisodeGetByID(suite.ctx, 999)
+
+		// Assert
+		suite.ErrorIs(err, ErrNotFound)
+		suite.Nil(result)
+	})
+}
+
+func (suite *TestStoreParitySuite) TestEpisodeUpdate() {
+	suite.Run("Success", func() {
+		// Arrange
+		episode := suite.newEpisode(1)
+		suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, episode))
+		createdAt := episode.CreatedAt
+
+		// Act
+		episode.Title = "Updated Title"
+		episode.MediaSize = 2048
+		episode.OriginalURL = "https://example.com/changed"
+		err := suite.store.EpisodeUpdate(suite.ctx, episode)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.False(episode.UpdatedAt.Before(createdAt), "UpdatedAt should not go back on update")
+		stored, err := suite.store.EpisodeGetByID(suite.ctx, episode.ID)
+		suite.Require().NoError(err)
+		suite.Equal("Updated Title", stored.Title)
+		suite.Equal(int64(2048), stored.MediaSize)
+		suite.Equal("https://example.com/1", stored.OriginalURL, "OriginalURL should not change on update")
+		suite.True(stored.CreatedAt.Equal(createdAt), "CreatedAt should not change on update")
+		suite.True(stored.UpdatedAt.Equal(episode.UpdatedAt))
+	})
+
+	suite.Run("NotFound", func() {
+		// Act
+		err := suite.store.EpisodeUpdate(suite.ctx, &entities.Episode{ID: 999, Title: "Missing"})
+
+		// Assert
+		suite.ErrorIs(err, ErrNotFound)
+	})
+}
+
+func (suite *TestStoreParitySuite) TestEpisodeDelete() {
+	suite.Run("Success", func() {
+		// Arrange
+		episode := suite.newEpisode(1)
+		suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, episode))
+		process := suite.newProcess("req-delete", entities.StatusSuccess)
+		process.Step = entities.StepPublishing
+		process.Episode = episode
+		suite.Require().NoError(suite.store.ProcessUpsert(suite.ctx, process))
+
+		// Act
+		err := suite.store.EpisodeDelete(suite.ctx, episode.ID)
+
+		// Assert
+		suite.Require().NoError(err)
+		_, err = suite.store.EpisodeGetByID(suite.ctx, episode.ID)
+		suite.ErrorIs(err, ErrNotFound)
+
+		// Verify process is kept with episode reference cleared
+		processes, err := suite.store.ProcessGetByStatus(suite.ctx, entities.StatusSuccess)
+		suite.Require().NoError(err)
+		suite.Require().Len(processes, 1)
+		suite.Nil(processes[0].Episode)
+	})
+
+	suite.Run("NotFound", func() {
+		// Act
+		err := suite.store.EpisodeDelete(suite.ctx, 999)
+
+		// Assert
+		suite.ErrorIs(err, ErrNotFound)
+	})
+}
+
+func (suite *TestStoreParitySuite) TestProcessUpsert() {
+	suite.Run("InsertAndUpdate", func() {
+		// Arrange
+		process := suite.newProcess("req-1", entities.StatusInProgress)
+
+		// Act
+		err := suite.store.ProcessUpsert(suite.ctx, process)
+		suite.Require().NoError(err)
+		id, createdAt := process.ID, process.CreatedAt
+		process.Step = entities.StepPublishing
+		process.Status = entities.StatusSuccess
+		err = suite.store.ProcessUpsert(suite.ctx, process)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.NotZero(id, "ID should be set after creation")
+		suite.Equal(id, process.ID, "ID should not change on update")
+		suite.True(process.CreatedAt.Equal(createdAt), "CreatedAt should not change on update")
+		suite.False(process.UpdatedAt.Before(createdAt))
+
+		result, err := suite.store.ProcessGetByStatus(suite.ctx, entities.StatusSuccess)
+		suite.Require().NoError(err)
+		suite.Require().Len(result, 1)
+		suite.Equal(id, result[0].ID)
+		suite.Equal(entities.StepPublishing, result[0].Step)
+		suite.Equal(process.Request, result[0].Request)
+		suite.Nil(result[0].Error)
+		suite.Nil(result[0].Episode)
+		suite.Nil(result[0].StepTimings)
+	})
+
+	suite.Run("WithEpisodeAndError", func() {
+		// Arrange
+		episode := suite.newEpisode(1)
+		suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, episode))
+		process := suite.newProcess("req-1", entities.StatusFailed)
+		process.Error = errors.New("test error")
+		process.Episode = episode
+
+		// Act
+		err := suite.store.ProcessUpsert(suite.ctx, process)
+
+		// Assert
+		suite.Require().NoError(err)
+		result, err := suite.store.ProcessGetByStatus(suite.ctx, entities.StatusFailed)
+		suite.Require().NoError(err)
+		suite.Require().Len(result, 1)
+		suite.EqualError(result[0].Error, "test error")
+		suite.Equal(episode, result[0].Episode)
+	})
+
+	suite.Run("WithOverrides", func() {
+		// Arrange
+		process := suite.newProcess("req-1", entities.StatusInProgress)
+		process.Request.TitleOverride = "Custom Title"
+		process.Request.EpisodeNumber = 12
+		process.Request.SeasonNumber = 3
+		process.Request.Lang = "ru"
+
+		// Act
+		err := suite.store.ProcessUpsert(suite.ctx, process)
+
+		// Assert
+		suite.Require().NoError(err)
+		result, err := suite.store.ProcessGetByMessage(suite.ctx, 10, 100)
+		suite.Require().NoError(err)
+		suite.Equal("Custom Title", result.Request.TitleOverride)
+		suite.Equal(12, result.Request.EpisodeNumber)
+		suite.Equal(3, result.Request.SeasonNumber)
+		suite.Equal("ru", result.Request.Lang)
+	})
+
+	suite.Run("WithStepTimings", func() {
+		// Arrange
+		process := suite.newProcess("req-1", entities.StatusSuccess)
+		process.StepTimings = map[entities.Step]time.Duration{
+			entities.StepCreating:    15 * time.Millisecond,
+			entities.StepDownloading: 90 * time.Second,
+			entities.StepPublishing:  2 * time.Second,
+		}
+
+		// Act
+		err := suite.store.ProcessUpsert(suite.ctx, process)
+		suite.Require().NoError(err)
+		process.StepTimings[entities.StepPublishing] = 3 * time.Second
+		err = suite.store.ProcessUpsert(suite.ctx, process)
+
+		// Assert
+		suite.Require().NoError(err)
+		result, err := suite.store.ProcessGetByMessage(suite.ctx, 10, 100)
+		suite.Require().NoError(err)
+		suite.Equal(process.StepTimings, result.StepTimings)
+
+		result.StepTimings[entities.StepCreating] = time.Hour
+		stored, err := suite.store.ProcessGetByStatus(suite.ctx, entities.StatusSuccess)
+		suite.Require().NoError(err)
+		suite.Require().Len(stored, 1)
+		suite.Equal(15*time.Millisecond, stored[0].StepTimings[entities.StepCreating], "returned timings should be a copy")
+	})
+
+	suite.Run("DuplicateRequestID", func() {
+		// Arrange
+		suite.Require().NoError(suite.store.ProcessUpsert(suite.ctx, suite.newProcess("req-1", entities.StatusInProgress)))
+
+		// Act
+		err := suite.store.ProcessUpsert(suite.ctx, suite.newProcess("req-1", entities.StatusInProgress))
+
+		// Assert
+		suite.Error(err)
+	})
+
+	suite.Run("InvalidStatus", func() {
+		// Act
+		err := suite.store.ProcessUpsert(suite.ctx, suite.newProcess("req-1", "unknown"))
+
+		// Assert
+		suite.Error(err)
+	})
+}
+
+func (suite *TestStoreParitySuite) TestProcessQueries() {
+	// createProcesses creates four processes, the last one is the newest
+	createProcesses := func() []*entities.Process {
+		processes := []*entities.Process{
+			suite.newProcess("aaa", entities.StatusInProgress),
+			suite.newProcess("bbb", entities.StatusInProgress),
+			suite.newProcess("ccc", entities.StatusInProgress),
+			suite.newProcess("ddd", entities.StatusSuccess),
+		}
+		processes[1].Request.MessageID = 101
+		processes[2].Request.Url = "https://other.com"
+		for _, p := range processes {
+			suite.Require().NoError(suite.store.ProcessUpsert(suite.ctx, p))
+		}
+		return processes
+	}
+
+	suite.Run("GetByStatus", func() {
+		// Arrange
+		createProcesses()
+
+		// Act
+		result, err := suite.store.ProcessGetByStatus(suite.ctx, entities.StatusInProgress)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Len(result, 3)
+		for _, p := range result {
+			suite.Equal(entities.StatusInProgress, p.Status)
+		}
+	})
+
+	suite.Run("InProgressSteps", func() {
+		// Arrange
+		creating := suite.newProcess("creating", entities.StatusInProgress)
+		creating.Step = entities.StepCreating
+		creating.Request.MessageID = 101
+		downloading := suite.newProcess("downloading", entities.StatusInProgress)
+		for _, p := range []*entities.Process{creating, downloading} {
+			suite.Require().NoError(suite.store.ProcessUpsert(suite.ctx, p))
+		}
+
+		// Act
+		result, err := suite.store.ProcessGetByStatus(suite.ctx, entities.StatusInProgress)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(result, 2)
+		steps := map[string]entities.Step{}
+		for _, p := range result {
+			steps[p.Request.ID] = p.Step
+			suite.False(p.CreatedAt.IsZero(), "creation time is required for the elapsed time")
+		}
+		suite.Equal(map[string]entities.Step{
+			"creating":    entities.StepCreating,
+			"downloading": entities.StepDownloading,
+		}, steps)
+	})
+
+	suite.Run("CountByUrlAndStatus", func() {
+		// Arrange
+		processes := createProcesses()
+
+		// Act
+		count, err := suite.store.ProcessCountByUrlAndStatus(suite.ctx, "https://example.com/video", entities.StatusInProgress, processes[3].ID)
+		countBefore, errBefore := suite.store.ProcessCountByUrlAndStatus(suite.ctx, "https://example.com/video", entities.StatusInProgress, processes[1].ID)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(2, count)
+		suite.Require().NoError(errBefore)
+		suite.Equal(1, countBefore, "only the processes accepted before the given one are counted")
+	})
+
+	suite.Run("GetByMessage", func() {
+		// Arrange
+		createProcesses()
+
+		// Act
+		process, err := suite.store.ProcessGetByMessage(suite.ctx, 10, 101)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("bbb", process.Request.ID)
+	})
+
+	suite.Run("GetByMessageLatest", func() {
+		// Arrange
+		createProcesses()
+
+		// Act
+		process, err := suite.store.ProcessGetByMessage(suite.ctx, 10, 100)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("ddd", process.Request.ID, "the latest process should be returned")
+	})
+
+	suite.Run("GetByMessageNotFound", func() {
+		// Arrange
+		createProcesses()
+
+		// Act
+		process, err := suite.store.ProcessGetByMessage(suite.ctx, 11, 100)
+
+		// Assert
+		suite.Nil(process)
+		suite.ErrorIs(err, ErrNotFound)
+	})
+}
+
+func (suite *TestStoreParitySuite) TestTransaction() {
+	suite.Run("Commit", func() {
+		// Arrange
+		txStore, err := suite.store.Begin(suite.ctx)
+		suite.Require().NoError(err)
+
+		// Act
+		suite.Require().NoError(txStore.EpisodeCreate(suite.ctx, suite.newEpisode(1)))
+		suite.Require().NoError(txStore.ProcessUpsert(suite.ctx, suite.newProcess("req-1", entities.StatusSuccess)))
+		err = txStore.Commit()
+
+		// Assert
+		suite.Require().NoError(err)
+		count, err := suite.store.EpisodeCountAll(suite.ctx)
+		suite.Require().NoError(err)
+		suite.Equal(1, count)
+		processes, err := suite.store.ProcessGetByStatus(suite.ctx, entities.StatusSuccess)
+		suite.Require().NoError(err)
+		suite.Len(processes, 1)
+	})
+
+	suite.Run("Rollback", func() {
+		// Arrange
+		suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, suite.newEpisode(1)))
+		txStore, err := suite.store.Begin(suite.ctx)
+		suite.Require().NoError(err)
+
+		// Act
+		suite.Require().NoError(txStore.EpisodeCreate(suite.ctx, suite.newEpisode(2)))
+		suite.Require().NoError(txStore.EpisodeDelete(suite.ctx, 1))
+		err = txStore.Rollback()
+
+		// Assert
+		suite.Require().NoError(err)
+		episodes, err := suite.store.EpisodeListAll(suite.ctx)
+		suite.Require().NoError(err)
+		suite.Require().Len(episodes, 1, "Changes should be discarded after rollback")
+		suite.Equal("Episode 1", episodes[0].Title)
+	})
+
+	suite.Run("ReadsOwnWrites", func() {
+		// Arrange
+		txStore, err := suite.store.Begin(suite.ctx)
+		suite.Require().NoError(err)
+		defer func() { _ = txStore.Rollback() }()
+
+		// Act
+		suite.Require().NoError(txStore.EpisodeCreate(suite.ctx, suite.newEpisode(1)))
+		count, err := txStore.EpisodeCountAll(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(1, count)
+	})
+
+	suite.Run("Finished", func() {
+		// Arrange
+		txStore, err := suite.store.Begin(suite.ctx)
+		suite.Require().NoError(err)
+		suite.Require().NoError(txStore.Commit())
+
+		// Act
+		commitErr := txStore.Commit()
+		rollbackErr := txStore.Rollback()
+
+		// Assert
+		suite.ErrorIs(commitErr, sql.ErrTxDone)
+		suite.ErrorIs(rollbackErr, sql.ErrTxDone)
+	})
+
+	suite.Run("NestedTransaction", func() {
+		// Arrange
+		txStore, err := suite.store.Begin(suite.ctx)
+		suite.Require().NoError(err)
+
+		// Act
+		_, err = txStore.Begin(suite.ctx)
+
+		// Assert
+		suite.ErrorContains(err, "unable to start a transaction within another transaction")
+		suite.Require().NoError(txStore.Rollback())
+	})
+
+	suite.Run("OutsideTransaction", func() {
+		// Act
+		commitErr := suite.store.Commit()
+		rollbackErr := suite.store.Rollback()
+
+		// Assert
+		suite.ErrorContains(commitErr, "unable to commit outside of a transaction")
+		suite.ErrorContains(rollbackErr, "unable to rollback outside of a transaction")
+	})
+}
+
+func (suite *TestStoreParitySuite) TestEpisodeGetLastTime() {
+	suite.Run("WithEpisodes", func() {
+		// Arrange
+		episodes := []*entities.Episode{suite.newEpisode(1), suite.newEpisode(2)}
+		for _, episode := range episodes {
+			suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, episode))
+		}
+
+		// Act
+		lastTime, err := suite.store.EpisodeGetLastTime(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.True(lastTime.Equal(episodes[1].CreatedAt), "expected lastTime to equal the latest created_at")
+	})
+
+	suite.Run("NoEpisodes", func() {
+		// Act
+		lastTime, err := suite.store.EpisodeGetLastTime(suite.ctx)
+
+		// Assert
+		suite.NoError(err)
+		suite.True(lastTime.IsZero())
+	})
+}
+
+// TestEpisodeGetLastSeason tests the highest season number of the episodes
+func (suite *TestStoreParitySuite) TestEpisodeGetLastSeason() {
+	suite.Run("WithSeasons", func() {
+		// Arrange
+		episodes := []*entities.Episode{suite.newEpisode(2), suite.newEpisode(1)}
+		for _, episode := range episodes {
+			suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, episode))
+		}
+
+		// Act
+		season, err := suite.store.EpisodeGetLastSeason(suite.ctx)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(2, season)
+	})
+
+	suite.Run("NoEpisodes", func() {
+		// Act
+		season, err := suite.store.EpisodeGetLastSeason(suite.ctx)
+
+		// Assert
+		suite.NoError(err)
+		suite.Zero(season)
+	})
+}
+
+// TestMemoryStoreTransaction tests that a MemoryStore transaction blocks other access to the store
+func TestMemoryStoreTransaction(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := NewMemoryStore()
+	txStore, err := store.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	counted := make(chan int)
+
+	// Act
+	go func() {
+		count, _ := store.EpisodeCountAll(ctx)
+		counted <- count
+	}()
+	if err = txStore.EpisodeCreate(ctx, &entities.Episode{Title: "TX Episode"}); err != nil {
+		t.Fatalf("EpisodeCreate failed: %v", err)
+	}
+
+	// Assert
+	select {
+	case <-counted:
+		t.Fatal("Store should be locked until the transaction is finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if err = txStore.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if count := <-counted; count != 1 {
+		t.Errorf("Expected committed episode to be counted, got %d", count)
+	}
+}
+
+// TestStoreParity runs the parity test suite against SQLiteStore and MemoryStore
+func TestStoreParity(t *testing.T) {
+	t.Run("SQLite", func(t *testing.T) {
+		suite.Run(t, &TestStoreParitySuite{newStore: func() Store {
+			db, err := NewSQLite(":memory:", 11)
+			if err != nil {
+				t.Fatalf("Failed to create in-memory database: %v", err)
+			}
+			return NewSQLiteStore(db)
+		}})
+	})
+	t.Run("Memory", func(t *testing.T) {
+		suite.Run(t, &TestStoreParitySuite{newStore: func() Store {
+			return NewMemoryStore()
+		}})
+	})
+}
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_processes_request_message;
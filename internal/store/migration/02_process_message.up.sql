@@ -0,0 +1,2 @@
+-- Index for looking up processes by the originating Telegram message
+CREATE INDEX idx_processes_request_message ON processes (request_chat_id, request_message_id);
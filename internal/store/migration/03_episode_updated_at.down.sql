@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_episodes_updated_at;
+ALTER TABLE episodes DROP COLUMN updated_at;
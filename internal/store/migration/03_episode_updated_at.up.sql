@@ -0,0 +1,4 @@
+-- Time of the last episode metadata change, equal to created_at for new episodes
+ALTER TABLE episodes ADD COLUMN updated_at DATETIME;
+UPDATE episodes SET updated_at = created_at;
+CREATE INDEX idx_episodes_updated_at ON episodes (updated_at);
@@ -0,0 +1,3 @@
+ALTER TABLE episodes DROP COLUMN episode_number;
+ALTER TABLE processes DROP COLUMN request_episode_number;
+ALTER TABLE processes DROP COLUMN request_title_override;
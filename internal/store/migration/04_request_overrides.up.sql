@@ -0,0 +1,4 @@
+-- Title and episode number overrides set by the user in the request
+ALTER TABLE processes ADD COLUMN request_title_override TEXT NOT NULL DEFAULT '';
+ALTER TABLE processes ADD COLUMN request_episode_number INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE episodes ADD COLUMN episode_number INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- Durations of the completed process steps in nanoseconds, JSON object keyed by step
+ALTER TABLE processes ADD COLUMN step_timings TEXT NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_episodes_media_hash;
+ALTER TABLE episodes DROP COLUMN media_hash;
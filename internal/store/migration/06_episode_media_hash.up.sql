@@ -0,0 +1,3 @@
+-- SHA-256 checksum of the media file, hex encoded. Empty for episodes created before
+ALTER TABLE episodes ADD COLUMN media_hash TEXT NOT NULL DEFAULT '';
+CREATE INDEX idx_episodes_media_hash ON episodes (media_hash);
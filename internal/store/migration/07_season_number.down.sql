@@ -0,0 +1,2 @@
+ALTER TABLE episodes DROP COLUMN season_number;
+ALTER TABLE processes DROP COLUMN request_season_number;
@@ -0,0 +1,3 @@
+-- Season number of the episodes of a playlist
+ALTER TABLE processes ADD COLUMN request_season_number INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE episodes ADD COLUMN season_number INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE episodes DROP COLUMN published_at;
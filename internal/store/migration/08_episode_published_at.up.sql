@@ -0,0 +1,2 @@
+-- Original publish time of the media on the platform. NULL if not reported
+ALTER TABLE episodes ADD COLUMN published_at DATETIME;
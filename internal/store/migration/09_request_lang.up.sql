@@ -0,0 +1,2 @@
+-- Language code of the user for notifications
+ALTER TABLE processes ADD COLUMN request_lang TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,35 @@
+-- Rebuild episodes with the nullable updated_at of version 3
+CREATE TABLE episodes_new
+(
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    title          TEXT     NOT NULL,
+    description    TEXT,
+    thumbnail_file TEXT,
+    media_file     TEXT     NOT NULL,
+    media_type     TEXT     NOT NULL,
+    media_duration INTEGER  NOT NULL DEFAULT 0,
+    media_size     INTEGER  NOT NULL DEFAULT 0,
+    author         TEXT     NOT NULL,
+    original_url   TEXT     NOT NULL,
+    canonical_url  TEXT     NOT NULL,
+    created_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     DATETIME,
+    episode_number INTEGER  NOT NULL DEFAULT 0,
+    media_hash     TEXT     NOT NULL DEFAULT '',
+    season_number  INTEGER  NOT NULL DEFAULT 0,
+    published_at   DATETIME
+);
+INSERT INTO episodes_new (id, title, description, thumbnail_file, media_file, media_type, media_duration, media_size, author, original_url, canonical_url, created_at, updated_at, episode_number, media_hash, season_number, published_at)
+SELECT id, title, description, thumbnail_file, media_file, media_type, media_duration, media_size, author, original_url, canonical_url, created_at, updated_at, episode_number, media_hash, season_number, published_at
+FROM episodes;
+-- Keep AUTOINCREMENT from reusing ids of deleted episodes
+UPDATE sqlite_sequence
+SET seq = (SELECT seq FROM sqlite_sequence WHERE name = 'episodes')
+WHERE name = 'episodes_new'
+  AND EXISTS (SELECT 1 FROM sqlite_sequence WHERE name = 'episodes');
+DROP TABLE episodes;
+ALTER TABLE episodes_new RENAME TO episodes;
+CREATE INDEX idx_episodes_original_url ON episodes (original_url);
+CREATE INDEX idx_episodes_created_at ON episodes (created_at);
+CREATE INDEX idx_episodes_updated_at ON episodes (updated_at);
+CREATE INDEX idx_episodes_media_hash ON episodes (media_hash);
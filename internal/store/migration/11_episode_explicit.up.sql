@@ -0,0 +1,2 @@
+-- Parental advisory set for the episode. NULL to apply the feed default
+ALTER TABLE episodes ADD COLUMN explicit INTEGER;
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -68,12 +69,13 @@ func (s *SQLiteStore) EpisodeCreate(ctx context.Context, episode *entities.Episo
 	query := `
 		INSERT INTO episodes (
 			title, description, thumbnail_file, media_file, 
-			media_duration, media_size, media_type, author, original_url, canonical_url
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-		RETURNING id, created_at`
+			media_duration, media_size, media_hash, media_type, author, original_url, canonical_url, episode_number,
+			season_number, published_at, explicit, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		RETURNING id, created_at, updated_at`
 
 	var id int64
-	var createdAt time.Time
+	var createdAt, updatedAt time.Time
 
 	err := s.execer.QueryRowContext(ctx, query,
 		episode.Title,
@@ -82,11 +84,16 @@ func (s *SQLiteStore) EpisodeCreate(ctx context.Context, episode *entities.Episo
 		episode.MediaFile,
 		episode.MediaDuration,
 		episode.MediaSize,
+		episode.MediaHash,
 		string(episode.MediaType),
 		episode.Author,
 		episode.OriginalURL,
 		episode.CanonicalURL,
-	).Scan(&id, &createdAt)
+		episode.EpisodeNumber,
+		episode.SeasonNumber,
+		timeValue(episode.PublishedAt),
+		boolValue(episode.Explicit),
+	).Scan(&id, &createdAt, &updatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create episode: %w", err)
@@ -94,6 +101,97 @@ func (s *SQLiteStore) EpisodeCreate(ctx context.Context, episode *entities.Episo
 
 	episode.ID = id
 	episode.CreatedAt = createdAt
+	episode.UpdatedAt = updatedAt
+	return nil
+}
+
+// WithTx runs fn within a transaction and commits the transaction if fn succeeds.
+// The transaction is rolled back if fn returns an error or panics,
+// the panic is re-raised after the rollback.
+func (s *SQLiteStore) WithTx(ctx context.Context, fn func(Store) error) (err error) {
+	tx, err := s.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// EpisodeCreateBatch creates all given episodes within a single transaction.
+// On success, IDs and timestamps are set on each episode.
+// If any episode fails to be created, the transaction is rolled back,
+// no episodes are stored and their IDs and timestamps are reset.
+func (s *SQLiteStore) EpisodeCreateBatch(ctx context.Context, episodes []*entities.Episode) error {
+	err := s.WithTx(ctx, func(tx Store) error {
+		for i, episode := range episodes {
+			if err := tx.EpisodeCreate(ctx, episode); err != nil {
+				return fmt.Errorf("failed to create episode %d: %w", i, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		for _, episode := range episodes {
+			episode.ID = 0
+			episode.CreatedAt = time.Time{}
+			episode.UpdatedAt = time.Time{}
+		}
+	}
+	return err
+}
+
+// EpisodeUpdate updates the episode metadata and media information in the database
+// and sets its UpdatedAt to the current time.
+// The original URL and creation time are not changed.
+// If the episode does not exist, it returns ErrNotFound.
+func (s *SQLiteStore) EpisodeUpdate(ctx context.Context, episode *entities.Episode) error {
+	query := `
+		UPDATE episodes SET
+			title = ?, description = ?, thumbnail_file = ?, media_file = ?,
+			media_duration = ?, media_size = ?, media_hash = ?, media_type = ?, author = ?, canonical_url = ?,
+			episode_number = ?, season_number = ?, published_at = ?, explicit = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+		RETURNING updated_at`
+
+	var updatedAt time.Time
+	err := s.execer.QueryRowContext(ctx, query,
+		episode.Title,
+		episode.Description,
+		episode.ThumbnailFile,
+		episode.MediaFile,
+		episode.MediaDuration,
+		episode.MediaSize,
+		episode.MediaHash,
+		string(episode.MediaType),
+		episode.Author,
+		episode.CanonicalURL,
+		episode.EpisodeNumber,
+		episode.SeasonNumber,
+		timeValue(episode.PublishedAt),
+		boolValue(episode.Explicit),
+		episode.ID,
+	).Scan(&updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update episode: %w", err)
+	}
+
+	episode.UpdatedAt = updatedAt
 	return nil
 }
 
@@ -101,7 +199,8 @@ func (s *SQLiteStore) EpisodeCreate(ctx context.Context, episode *entities.Episo
 func (s *SQLiteStore) EpisodeListAll(ctx context.Context) ([]*entities.Episode, error) {
 	query := `
 		SELECT id, title, description, thumbnail_file, media_file,
-			   media_duration, media_size, media_type, author, original_url, canonical_url, created_at
+			   media_duration, media_size, media_hash, media_type, author, original_url, canonical_url, episode_number,
+			   season_number, published_at, explicit, created_at, updated_at
 		FROM episodes
 		ORDER BY created_at DESC`
 
@@ -124,11 +223,143 @@ func (s *SQLiteStore) EpisodeListAll(ctx context.Context) ([]*entities.Episode,
 			&episode.MediaFile,
 			&episode.MediaDuration,
 			&episode.MediaSize,
+			&episode.MediaHash,
+			&mediaType,
+			&episode.Author,
+			&episode.OriginalURL,
+			&episode.CanonicalURL,
+			&episode.EpisodeNumber,
+			&episode.SeasonNumber,
+			nullTime{&episode.PublishedAt},
+			nullBool{&episode.Explicit},
+			&episode.CreatedAt,
+			&episode.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan episode: %w", err)
+		}
+		episode.MediaType = entities.MediaType(mediaType)
+		episodes = append(episodes, episode)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over episodes: %w", err)
+	}
+
+	return episodes, nil
+}
+
+// EpisodeList returns a page of episodes from the database, newest first
+func (s *SQLiteStore) EpisodeList(ctx context.Context, limit, offset int) ([]*entities.Episode, error) {
+	query := `
+		SELECT id, title, description, thumbnail_file, media_file,
+			   media_duration, media_size, media_hash, media_type, author, original_url, canonical_url, episode_number,
+			   season_number, published_at, explicit, created_at, updated_at
+		FROM episodes
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?`
+
+	rows, err := s.execer.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query episodes: %w", err)
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var episodes []*entities.Episode
+	for rows.Next() {
+		episode := &entities.Episode{}
+		var mediaType string
+		err = rows.Scan(
+			&episode.ID,
+			&episode.Title,
+			&episode.Description,
+			&episode.ThumbnailFile,
+			&episode.MediaFile,
+			&episode.MediaDuration,
+			&episode.MediaSize,
+			&episode.MediaHash,
+			&mediaType,
+			&episode.Author,
+			&episode.OriginalURL,
+			&episode.CanonicalURL,
+			&episode.EpisodeNumber,
+			&episode.SeasonNumber,
+			nullTime{&episode.PublishedAt},
+			nullBool{&episode.Explicit},
+			&episode.CreatedAt,
+			&episode.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan episode: %w", err)
+		}
+		episode.MediaType = entities.MediaType(mediaType)
+		episodes = append(episodes, episode)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over episodes: %w", err)
+	}
+
+	return episodes, nil
+}
+
+// EpisodeListByDateRange returns the episodes created between from and to inclusive from the database, newest first.
+// Zero from or to leaves the range open on that side.
+func (s *SQLiteStore) EpisodeListByDateRange(ctx context.Context, from, to time.Time) ([]*entities.Episode, error) {
+	// Format the bounds like CURRENT_TIMESTAMP the creation time is stored with
+	var where string
+	var args []any
+	switch {
+	case !from.IsZero() && !to.IsZero():
+		where = "WHERE created_at BETWEEN ? AND ?"
+		args = []any{from.UTC().Format(time.DateTime), to.UTC().Format(time.DateTime)}
+	case !from.IsZero():
+		where = "WHERE created_at >= ?"
+		args = []any{from.UTC().Format(time.DateTime)}
+	case !to.IsZero():
+		where = "WHERE created_at <= ?"
+		args = []any{to.UTC().Format(time.DateTime)}
+	}
+
+	query := `
+		SELECT id, title, description, thumbnail_file, media_file,
+			   media_duration, media_size, media_hash, media_type, author, original_url, canonical_url, episode_number,
+			   season_number, published_at, explicit, created_at, updated_at
+		FROM episodes
+		` + where + `
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := s.execer.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query episodes: %w", err)
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var episodes []*entities.Episode
+	for rows.Next() {
+		episode := &entities.Episode{}
+		var mediaType string
+		err = rows.Scan(
+			&episode.ID,
+			&episode.Title,
+			&episode.Description,
+			&episode.ThumbnailFile,
+			&episode.MediaFile,
+			&episode.MediaDuration,
+			&episode.MediaSize,
+			&episode.MediaHash,
 			&mediaType,
 			&episode.Author,
 			&episode.OriginalURL,
 			&episode.CanonicalURL,
+			&episode.EpisodeNumber,
+			&episode.SeasonNumber,
+			nullTime{&episode.PublishedAt},
+			nullBool{&episode.Explicit},
 			&episode.CreatedAt,
+			&episode.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan episode: %w", err)
@@ -156,11 +387,24 @@ func (s *SQLiteStore) EpisodeCountAll(ctx context.Context) (int, error) {
 	return count, nil
 }
 
+// EpisodeExistsByOriginalUrl checks whether an episode with the given original URL exists
+func (s *SQLiteStore) EpisodeExistsByOriginalUrl(ctx context.Context, url string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM episodes WHERE original_url = ?)`
+
+	var exists bool
+	err := s.execer.QueryRowContext(ctx, query, url).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check episode existence by URL: %w", err)
+	}
+	return exists, nil
+}
+
 // EpisodeGetByOriginalUrl returns episodes by original URL
 func (s *SQLiteStore) EpisodeGetByOriginalUrl(ctx context.Context, url string) ([]*entities.Episode, error) {
 	query := `
 		SELECT id, title, description, thumbnail_file, media_file,
-			   media_duration, media_size, media_type, author, original_url, canonical_url, created_at
+			   media_duration, media_size, media_hash, media_type, author, original_url, canonical_url, episode_number,
+			   season_number, published_at, explicit, created_at, updated_at
 		FROM episodes
 		WHERE original_url = ?
 		ORDER BY created_at DESC`
@@ -184,11 +428,72 @@ func (s *SQLiteStore) EpisodeGetByOriginalUrl(ctx context.Context, url string) (
 			&episode.MediaFile,
 			&episode.MediaDuration,
 			&episode.MediaSize,
+			&episode.MediaHash,
 			&mediaType,
 			&episode.Author,
 			&episode.OriginalURL,
 			&episode.CanonicalURL,
+			&episode.EpisodeNumber,
+			&episode.SeasonNumber,
+			nullTime{&episode.PublishedAt},
+			nullBool{&episode.Explicit},
 			&episode.CreatedAt,
+			&episode.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan episode: %w", err)
+		}
+		episode.MediaType = entities.MediaType(mediaType)
+		episodes = append(episodes, episode)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over episodes: %w", err)
+	}
+
+	return episodes, nil
+}
+
+// EpisodeGetByHash returns episodes by the checksum of the media file
+func (s *SQLiteStore) EpisodeGetByHash(ctx context.Context, hash string) ([]*entities.Episode, error) {
+	query := `
+		SELECT id, title, description, thumbnail_file, media_file,
+			   media_duration, media_size, media_hash, media_type, author, original_url, canonical_url, episode_number,
+			   season_number, published_at, explicit, created_at, updated_at
+		FROM episodes
+		WHERE media_hash = ?
+		ORDER BY created_at DESC`
+
+	rows, err := s.execer.QueryContext(ctx, query, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query episodes by media hash: %w", err)
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var episodes []*entities.Episode
+	for rows.Next() {
+		episode := &entities.Episode{}
+		var mediaType string
+		err = rows.Scan(
+			&episode.ID,
+			&episode.Title,
+			&episode.Description,
+			&episode.ThumbnailFile,
+			&episode.MediaFile,
+			&episode.MediaDuration,
+			&episode.MediaSize,
+			&episode.MediaHash,
+			&mediaType,
+			&episode.Author,
+			&episode.OriginalURL,
+			&episode.CanonicalURL,
+			&episode.EpisodeNumber,
+			&episode.SeasonNumber,
+			nullTime{&episode.PublishedAt},
+			nullBool{&episode.Explicit},
+			&episode.CreatedAt,
+			&episode.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan episode: %w", err)
@@ -224,6 +529,80 @@ func (s *SQLiteStore) EpisodeGetLastTime(ctx context.Context) (time.Time, error)
 	return createdAt, nil
 }
 
+// EpisodeGetLastSeason returns the highest season number of the episodes.
+// If no episode has a season number, it returns 0.
+func (s *SQLiteStore) EpisodeGetLastSeason(ctx context.Context) (int, error) {
+	query := `SELECT COALESCE(MAX(season_number), 0) FROM episodes`
+
+	var season int
+	err := s.execer.QueryRowContext(ctx, query).Scan(&season)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last episode season: %w", err)
+	}
+	return season, nil
+}
+
+// EpisodeGetByID returns an episode by its ID.
+// If the episode does not exist, it returns ErrNotFound.
+func (s *SQLiteStore) EpisodeGetByID(ctx context.Context, id int64) (*entities.Episode, error) {
+	query := `
+		SELECT id, title, description, thumbnail_file, media_file,
+			   media_duration, media_size, media_hash, media_type, author, original_url, canonical_url, episode_number,
+			   season_number, published_at, explicit, created_at, updated_at
+		FROM episodes
+		WHERE id = ?`
+
+	episode := &entities.Episode{}
+	var mediaType string
+	err := s.execer.QueryRowContext(ctx, query, id).Scan(
+		&episode.ID,
+		&episode.Title,
+		&episode.Description,
+		&episode.ThumbnailFile,
+		&episode.MediaFile,
+		&episode.MediaDuration,
+		&episode.MediaSize,
+		&episode.MediaHash,
+		&mediaType,
+		&episode.Author,
+		&episode.OriginalURL,
+		&episode.CanonicalURL,
+		&episode.EpisodeNumber,
+		&episode.SeasonNumber,
+		nullTime{&episode.PublishedAt},
+		nullBool{&episode.Explicit},
+		&episode.CreatedAt,
+		&episode.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get episode by ID: %w", err)
+	}
+	episode.MediaType = entities.MediaType(mediaType)
+	return episode, nil
+}
+
+// EpisodeDelete deletes an episode by its ID.
+// If the episode does not exist, it returns ErrNotFound.
+func (s *SQLiteStore) EpisodeDelete(ctx context.Context, id int64) error {
+	query := `DELETE FROM episodes WHERE id = ?`
+
+	res, err := s.execer.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete episode: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
 // ProcessUpsert creates or updates a process in the database
 func (s *SQLiteStore) ProcessUpsert(ctx context.Context, process *entities.Process) error {
 	var episodeID *int64
@@ -237,14 +616,20 @@ func (s *SQLiteStore) ProcessUpsert(ctx context.Context, process *entities.Proce
 		errorText = &errStr
 	}
 
+	stepTimings, err := marshalStepTimings(process.StepTimings)
+	if err != nil {
+		return err
+	}
+
 	if process.ID == 0 {
 		// INSERT with RETURNING
 		query := `
 			INSERT INTO processes (
 				request_id, request_user_id, request_chat_id, request_message_id, 
 				request_url, request_download_format, request_download_quality, request_force,
-				step, status, error, episode_id
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				request_title_override, request_episode_number, request_season_number, request_lang,
+				step, status, error, episode_id, step_timings
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 			RETURNING id, created_at, updated_at`
 
 		var id int64
@@ -259,10 +644,15 @@ func (s *SQLiteStore) ProcessUpsert(ctx context.Context, process *entities.Proce
 			string(process.Request.DownloadFormat),
 			process.Request.DownloadQuality,
 			process.Request.Force,
+			process.Request.TitleOverride,
+			process.Request.EpisodeNumber,
+			process.Request.SeasonNumber,
+			process.Request.Lang,
 			string(process.Step),
 			string(process.Status),
 			errorText,
 			episodeID,
+			stepTimings,
 		).Scan(&id, &createdAt, &updatedAt)
 
 		if err != nil {
@@ -278,7 +668,8 @@ func (s *SQLiteStore) ProcessUpsert(ctx context.Context, process *entities.Proce
 			UPDATE processes SET
 				request_id = ?, request_user_id = ?, request_chat_id = ?, request_message_id = ?, 
 				request_url = ?, request_download_format = ?, request_download_quality = ?, request_force = ?, 
-				step = ?, status = ?, error = ?, episode_id = ?, updated_at = CURRENT_TIMESTAMP
+				request_title_override = ?, request_episode_number = ?, request_season_number = ?, request_lang = ?,
+				step = ?, status = ?, error = ?, episode_id = ?, step_timings = ?, updated_at = CURRENT_TIMESTAMP
 			WHERE id = ?
 			RETURNING updated_at`
 
@@ -293,10 +684,15 @@ func (s *SQLiteStore) ProcessUpsert(ctx context.Context, process *entities.Proce
 			string(process.Request.DownloadFormat),
 			process.Request.DownloadQuality,
 			process.Request.Force,
+			process.Request.TitleOverride,
+			process.Request.EpisodeNumber,
+			process.Request.SeasonNumber,
+			process.Request.Lang,
 			string(process.Step),
 			string(process.Status),
 			errorText,
 			episodeID,
+			stepTimings,
 			process.ID,
 		).Scan(&updatedAt)
 
@@ -314,9 +710,11 @@ func (s *SQLiteStore) ProcessGetByStatus(ctx context.Context, status entities.St
 	query := `
 		SELECT p.id, p.request_id, p.request_user_id, p.request_chat_id, p.request_message_id, 
 			   p.request_url, p.request_download_format, p.request_download_quality, p.request_force, 
-			   p.step, p.status, p.error, p.episode_id, p.created_at, p.updated_at,
+			   p.request_title_override, p.request_episode_number, p.request_season_number, p.request_lang,
+			   p.step, p.status, p.error, p.episode_id, p.step_timings, p.created_at, p.updated_at,
 			   e.id, e.title, e.description, e.thumbnail_file, e.media_file,
-			   e.media_duration, e.media_size, e.media_type, e.author, e.original_url, e.canonical_url, e.created_at
+			   e.media_duration, e.media_size, e.media_hash, e.media_type, e.author, e.original_url, e.canonical_url, e.episode_number,
+			   e.season_number, e.published_at, e.explicit, e.created_at, e.updated_at
 		FROM processes p
 		LEFT JOIN episodes e ON p.episode_id = e.id
 		WHERE p.status = ?
@@ -332,14 +730,49 @@ func (s *SQLiteStore) ProcessGetByStatus(ctx context.Context, status entities.St
 	return s.scanProcesses(rows)
 }
 
-func (s *SQLiteStore) ProcessCountByUrlAndStatus(ctx context.Context, url string, status entities.Status) (int, error) {
+// ProcessGetByMessage returns the latest process created for the given chat message
+func (s *SQLiteStore) ProcessGetByMessage(ctx context.Context, chatID int64, messageID int) (*entities.Process, error) {
+	query := `
+		SELECT p.id, p.request_id, p.request_user_id, p.request_chat_id, p.request_message_id, 
+			   p.request_url, p.request_download_format, p.request_download_quality, p.request_force, 
+			   p.request_title_override, p.request_episode_number, p.request_season_number, p.request_lang,
+			   p.step, p.status, p.error, p.episode_id, p.step_timings, p.created_at, p.updated_at,
+			   e.id, e.title, e.description, e.thumbnail_file, e.media_file,
+			   e.media_duration, e.media_size, e.media_hash, e.media_type, e.author, e.original_url, e.canonical_url, e.episode_number,
+			   e.season_number, e.published_at, e.explicit, e.created_at, e.updated_at
+		FROM processes p
+		LEFT JOIN episodes e ON p.episode_id = e.id
+		WHERE p.request_chat_id = ? AND p.request_message_id = ?
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT 1`
+
+	rows, err := s.execer.QueryContext(ctx, query, chatID, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query process by message: %w", err)
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	processes, err := s.scanProcesses(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(processes) == 0 {
+		return nil, ErrNotFound
+	}
+	return processes[0], nil
+}
+
+// ProcessCountByUrlAndStatus returns the count of processes matching the given URL and status
+// accepted before the process with the given ID.
+func (s *SQLiteStore) ProcessCountByUrlAndStatus(ctx context.Context, url string, status entities.Status, beforeID int64) (int, error) {
 	query := `
 		SELECT COUNT(*) 
 		FROM processes 
-		WHERE request_url = ? AND status = ?`
+		WHERE request_url = ? AND status = ? AND id < ?`
 
 	var count int
-	err := s.execer.QueryRowContext(ctx, query, url, string(status)).Scan(&count)
+	err := s.execer.QueryRowContext(ctx, query, url, string(status), beforeID).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count processes by URL and status: %w", err)
 	}
@@ -354,12 +787,14 @@ func (s *SQLiteStore) scanProcesses(rows *sql.Rows) ([]*entities.Process, error)
 	for rows.Next() {
 		process := &entities.Process{}
 		var episodeID sql.NullInt64
-		var episodeTitle, episodeDesc, episodeThumbnail, episodeMedia, mediaType, episodeAuthor sql.NullString
-		var episodeDuration, episodeMediaSize sql.NullInt64
+		var episodeTitle, episodeDesc, episodeThumbnail, episodeMedia, episodeMediaHash, mediaType, episodeAuthor sql.NullString
+		var episodeDuration, episodeMediaSize, episodeNumber, seasonNumber sql.NullInt64
 		var episodeOriginalURL, episodeCanonicalURL sql.NullString
-		var episodeCreatedAt sql.NullTime
+		var episodePublishedAt, episodeCreatedAt, episodeUpdatedAt sql.NullTime
+		var episodeExplicit *bool
 		var errorText sql.NullString
 		var requestDownloadFormat, requestDownloadQuality sql.NullString
+		var stepTimings string
 
 		err := rows.Scan(
 			&process.ID,
@@ -371,10 +806,15 @@ func (s *SQLiteStore) scanProcesses(rows *sql.Rows) ([]*entities.Process, error)
 			&requestDownloadFormat,
 			&requestDownloadQuality,
 			&process.Request.Force,
+			&process.Request.TitleOverride,
+			&process.Request.EpisodeNumber,
+			&process.Request.SeasonNumber,
+			&process.Request.Lang,
 			&process.Step,
 			&process.Status,
 			&errorText,
 			&episodeID,
+			&stepTimings,
 			&process.CreatedAt,
 			&process.UpdatedAt,
 			&episodeID,
@@ -384,11 +824,17 @@ func (s *SQLiteStore) scanProcesses(rows *sql.Rows) ([]*entities.Process, error)
 			&episodeMedia,
 			&episodeDuration,
 			&episodeMediaSize,
+			&episodeMediaHash,
 			&mediaType,
 			&episodeAuthor,
 			&episodeOriginalURL,
 			&episodeCanonicalURL,
+			&episodeNumber,
+			&seasonNumber,
+			&episodePublishedAt,
+			nullBool{&episodeExplicit},
 			&episodeCreatedAt,
+			&episodeUpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan process: %w", err)
@@ -402,6 +848,10 @@ func (s *SQLiteStore) scanProcesses(rows *sql.Rows) ([]*entities.Process, error)
 			process.Request.DownloadQuality = requestDownloadQuality.String
 		}
 
+		if process.StepTimings, err = unmarshalStepTimings(stepTimings); err != nil {
+			return nil, err
+		}
+
 		// Create error if exists
 		if errorText.Valid {
 			process.Error = fmt.Errorf("%s", errorText.String)
@@ -417,11 +867,17 @@ func (s *SQLiteStore) scanProcesses(rows *sql.Rows) ([]*entities.Process, error)
 				MediaFile:     episodeMedia.String,
 				MediaDuration: episodeDuration.Int64,
 				MediaSize:     episodeMediaSize.Int64,
+				MediaHash:     episodeMediaHash.String,
 				MediaType:     entities.MediaType(mediaType.String),
 				Author:        episodeAuthor.String,
 				OriginalURL:   episodeOriginalURL.String,
 				CanonicalURL:  episodeCanonicalURL.String,
+				EpisodeNumber: int(episodeNumber.Int64),
+				SeasonNumber:  int(seasonNumber.Int64),
+				PublishedAt:   episodePublishedAt.Time,
+				Explicit:      episodeExplicit,
 				CreatedAt:     episodeCreatedAt.Time,
+				UpdatedAt:     episodeUpdatedAt.Time,
 			}
 		}
 
@@ -451,3 +907,77 @@ type txer interface {
 	Commit() error
 	Rollback() error
 }
+
+// marshalStepTimings encodes the process step timings as JSON object.
+func marshalStepTimings(timings map[entities.Step]time.Duration) (string, error) {
+	if len(timings) == 0 {
+		return "{}", nil
+	}
+	data, err := json.Marshal(timings)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal step timings: %w", err)
+	}
+	return string(data), nil
+}
+
+// unmarshalStepTimings decodes the process step timings from JSON object.
+// It returns nil if there are no timings.
+func unmarshalStepTimings(data string) (map[entities.Step]time.Duration, error) {
+	var timings map[entities.Step]time.Duration
+	if err := json.Unmarshal([]byte(data), &timings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal step timings: %w", err)
+	}
+	if len(timings) == 0 {
+		return nil, nil
+	}
+	return timings, nil
+}
+
+// timeValue returns the time as a DATETIME column value in UTC, or NULL if the time is zero.
+func timeValue(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.DateTime)
+}
+
+// nullTime scans a nullable DATETIME column into t, leaving the zero time for NULL.
+type nullTime struct {
+	t *time.Time
+}
+
+// Scan implements sql.Scanner.
+func (n nullTime) Scan(value any) error {
+	var v sql.NullTime
+	if err := v.Scan(value); err != nil {
+		return err
+	}
+	*n.t = v.Time
+	return nil
+}
+
+// boolValue returns the optional flag as an INTEGER column value, or NULL if the flag is not set.
+func boolValue(b *bool) any {
+	if b == nil {
+		return nil
+	}
+	return *b
+}
+
+// nullBool scans a nullable INTEGER flag column into b, leaving nil for NULL.
+type nullBool struct {
+	b **bool
+}
+
+// Scan implements sql.Scanner.
+func (n nullBool) Scan(value any) error {
+	var v sql.NullBool
+	if err := v.Scan(value); err != nil {
+		return err
+	}
+	*n.b = nil
+	if v.Valid {
+		*n.b = &v.Bool
+	}
+	return nil
+}
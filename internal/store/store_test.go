@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -29,7 +30,7 @@ func (suite *TestSQLiteStoreSuite) SetupTest() {
 // SetupSubTest is called before each subtest in the suite
 func (suite *TestSQLiteStoreSuite) SetupSubTest() {
 	var err error
-	suite.db, err = NewSQLite(":memory:", 1)
+	suite.db, err = NewSQLite(":memory:", 11)
 	suite.Require().NoError(err, "Failed to create in-memory database")
 	suite.store = NewSQLiteStore(suite.db)
 	suite.ctx = context.Background()
@@ -71,6 +72,7 @@ func (suite *TestSQLiteStoreSuite) TestEpisodeCreate() {
 	suite.Require().NoError(err)
 	suite.NotZero(episode.ID, "ID should be set after creation")
 	suite.NotZero(episode.CreatedAt, "CreatedAt should be set after creation")
+	suite.Equal(episode.CreatedAt, episode.UpdatedAt, "UpdatedAt should equal CreatedAt after creation")
 
 	// Verify all fields were stored correctly in database
 	var storedEpisode entities.Episode
@@ -110,6 +112,89 @@ func (suite *TestSQLiteStoreSuite) TestEpisodeCreate() {
 	suite.Equal(episode.CreatedAt, storedEpisode.CreatedAt)
 }
 
+func (suite *TestSQLiteStoreSuite) TestEpisodeCreateBatch() {
+	// newEpisodes returns n episodes to create
+	newEpisodes := func(n int) []*entities.Episode {
+		episodes := make([]*entities.Episode, n)
+		for i := range episodes {
+			episodes[i] = &entities.Episode{
+				Title:        fmt.Sprintf("Episode %d", i+1),
+				MediaFile:    fmt.Sprintf("audio%d.mp3", i+1),
+				MediaType:    "audio/mpeg",
+				OriginalURL:  fmt.Sprintf("https://example.com/%d", i+1),
+				CanonicalURL: fmt.Sprintf("https://example.com/%d", i+1),
+			}
+		}
+		return episodes
+	}
+
+	suite.Run("Success", func() {
+		// Arrange
+		episodes := newEpisodes(50)
+
+		// Act
+		err := suite.store.EpisodeCreateBatch(suite.ctx, episodes)
+
+		// Assert
+		suite.Require().NoError(err)
+		ids := make(map[int64]bool)
+		for _, episode := range episodes {
+			suite.NotZero(episode.ID, "ID should be set after creation")
+			suite.NotZero(episode.CreatedAt, "CreatedAt should be set after creation")
+			suite.Equal(episode.CreatedAt, episode.UpdatedAt)
+			ids[episode.ID] = true
+		}
+		suite.Len(ids, 50, "IDs should be unique")
+		count, err := suite.store.EpisodeCountAll(suite.ctx)
+		suite.Require().NoError(err)
+		suite.Equal(50, count)
+	})
+
+	suite.Run("Empty", func() {
+		// Act
+		err := suite.store.EpisodeCreateBatch(suite.ctx, nil)
+
+		// Assert
+		suite.NoError(err)
+	})
+
+	suite.Run("Rollback", func() {
+		// Arrange - make inserting one of the episodes fail
+		_, err := suite.db.Exec(`
+			CREATE TRIGGER fail_episode BEFORE INSERT ON episodes
+			WHEN NEW.title = 'Episode 25'
+			BEGIN SELECT RAISE(ABORT, 'forced failure'); END`)
+		suite.Require().NoError(err)
+		episodes := newEpisodes(50)
+
+		// Act
+		err = suite.store.EpisodeCreateBatch(suite.ctx, episodes)
+
+		// Assert
+		suite.Require().Error(err)
+		suite.Contains(err.Error(), "forced failure")
+		count, err := suite.store.EpisodeCountAll(suite.ctx)
+		suite.Require().NoError(err)
+		suite.Zero(count, "no episodes should be stored after rollback")
+		for _, episode := range episodes {
+			suite.Zero(episode.ID, "ID should be reset after rollback")
+		}
+	})
+
+	suite.Run("WithinTransaction", func() {
+		// Arrange
+		txStore, err := suite.store.Begin(suite.ctx)
+		suite.Require().NoError(err)
+		defer func() { _ = txStore.Rollback() }()
+
+		// Act
+		err = txStore.EpisodeCreateBatch(suite.ctx, newEpisodes(1))
+
+		// Assert
+		suite.Error(err)
+	})
+}
+
 func (suite *TestSQLiteStoreSuite) TestEpisodeListAll() {
 	// Arrange - create test episodes
 	episodes := []*entities.Episode{
@@ -246,6 +331,219 @@ func (suite *TestSQLiteStoreSuite) TestEpisodeGetByOriginalUrl() {
 	})
 }
 
+func (suite *TestSQLiteStoreSuite) TestEpisodeList() {
+	// createEpisodes creates three episodes, the last one is the newest
+	createEpisodes := func() {
+		for i := 1; i <= 3; i++ {
+			err := suite.store.EpisodeCreate(suite.ctx, &entities.Episode{
+				Title:        fmt.Sprintf("Episode %d", i),
+				MediaFile:    fmt.Sprintf("audio%d.mp3", i),
+				MediaSize:    512000,
+				MediaType:    "audio/mpeg",
+				OriginalURL:  fmt.Sprintf("https://example.com/%d", i),
+				CanonicalURL: fmt.Sprintf("https://example.com/%d", i),
+			})
+			suite.Require().NoError(err)
+		}
+	}
+
+	suite.Run("FirstPage", func() {
+		// Arrange
+		createEpisodes()
+
+		// Act
+		result, err := suite.store.EpisodeList(suite.ctx, 2, 0)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(result, 2)
+		suite.Equal("Episode 3", result[0].Title)
+		suite.Equal("Episode 2", result[1].Title)
+	})
+
+	suite.Run("LastPage", func() {
+		// Arrange
+		createEpisodes()
+
+		// Act
+		result, err := suite.store.EpisodeList(suite.ctx, 2, 2)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(result, 1)
+		suite.Equal("Episode 1", result[0].Title)
+		suite.Equal("https://example.com/1", result[0].OriginalURL)
+	})
+
+	suite.Run("BeyondLastPage", func() {
+		// Arrange
+		createEpisodes()
+
+		// Act
+		result, err := suite.store.EpisodeList(suite.ctx, 2, 3)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Empty(result)
+	})
+}
+
+func (suite *TestSQLiteStoreSuite) TestEpisodeExistsByOriginalUrl() {
+	suite.Run("Exists", func() {
+		// Arrange
+		targetURL := "https://example.com/exists"
+		err := suite.store.EpisodeCreate(suite.ctx, &entities.Episode{
+			Title:        "Existing Episode",
+			MediaFile:    "exists.mp3",
+			MediaSize:    256000,
+			MediaType:    "audio/mpeg",
+			OriginalURL:  targetURL,
+			CanonicalURL: targetURL,
+		})
+		suite.Require().NoError(err)
+
+		// Act
+		exists, err := suite.store.EpisodeExistsByOriginalUrl(suite.ctx, targetURL)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.True(exists)
+	})
+
+	suite.Run("NotExists", func() {
+		// Act
+		exists, err := suite.store.EpisodeExistsByOriginalUrl(suite.ctx, "https://nonexistent.com")
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.False(exists)
+	})
+}
+
+func (suite *TestSQLiteStoreSuite) TestEpisodeGetByID() {
+	suite.Run("Found", func() {
+		// Arrange
+		episode := &entities.Episode{
+			Title:         "Target Episode",
+			Description:   "Target Description",
+			ThumbnailFile: "target.jpg",
+			MediaFile:     "target.mp3",
+			MediaDuration: 120,
+			MediaSize:     256000,
+			MediaType:     "audio/mpeg",
+			Author:        "Target Author",
+			OriginalURL:   "https://example.com/target",
+			CanonicalURL:  "https://example.com/target-canonical",
+		}
+		suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, episode))
+
+		// Act
+		result, err := suite.store.EpisodeGetByID(suite.ctx, episode.ID)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(episode, result)
+	})
+
+	suite.Run("NotFound", func() {
+		// Act
+		result, err := suite.store.EpisodeGetByID(suite.ctx, 999)
+
+		// Assert
+		suite.ErrorIs(err, ErrNotFound)
+		suite.Nil(result)
+	})
+}
+
+func (suite *TestSQLiteStoreSuite) TestEpisodeUpdate() {
+	suite.Run("Success", func() {
+		// Arrange
+		episode := &entities.Episode{
+			Title:        "Original Title",
+			MediaFile:    "update.mp3",
+			MediaType:    "audio/mpeg",
+			OriginalURL:  "https://example.com/update",
+			CanonicalURL: "https://example.com/update",
+		}
+		suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, episode))
+		// Move creation back in time to observe the update time advance
+		createdAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+		_, err := suite.db.Exec(`UPDATE episodes SET created_at = ?, updated_at = ? WHERE id = ?`,
+			createdAt, createdAt, episode.ID)
+		suite.Require().NoError(err)
+
+		// Act
+		episode.Title = "Updated Title"
+		episode.Description = "Updated Description"
+		episode.MediaSize = 2048
+		err = suite.store.EpisodeUpdate(suite.ctx, episode)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.True(episode.UpdatedAt.After(createdAt), "UpdatedAt should advance on update")
+
+		stored, err := suite.store.EpisodeGetByID(suite.ctx, episode.ID)
+		suite.Require().NoError(err)
+		suite.Equal("Updated Title", stored.Title)
+		suite.Equal("Updated Description", stored.Description)
+		suite.Equal(int64(2048), stored.MediaSize)
+		suite.Equal("https://example.com/update", stored.OriginalURL)
+		suite.True(stored.CreatedAt.Equal(createdAt), "CreatedAt should not change on update")
+		suite.True(stored.UpdatedAt.Equal(episode.UpdatedAt))
+	})
+
+	suite.Run("NotFound", func() {
+		// Act
+		err := suite.store.EpisodeUpdate(suite.ctx, &entities.Episode{ID: 999, Title: "Missing"})
+
+		// Assert
+		suite.ErrorIs(err, ErrNotFound)
+	})
+}
+
+func (suite *TestSQLiteStoreSuite) TestEpisodeDelete() {
+	suite.Run("Success", func() {
+		// Arrange
+		episode := &entities.Episode{
+			Title:        "Episode to delete",
+			MediaFile:    "delete.mp3",
+			MediaType:    "audio/mpeg",
+			OriginalURL:  "https://example.com/delete",
+			CanonicalURL: "https://example.com/delete",
+		}
+		suite.Require().NoError(suite.store.EpisodeCreate(suite.ctx, episode))
+		process := &entities.Process{
+			Request: entities.Request{ID: "req-delete", Url: episode.OriginalURL},
+			Step:    entities.StepPublishing,
+			Status:  entities.StatusSuccess,
+			Episode: episode,
+		}
+		suite.Require().NoError(suite.store.ProcessUpsert(suite.ctx, process))
+
+		// Act
+		err := suite.store.EpisodeDelete(suite.ctx, episode.ID)
+
+		// Assert
+		suite.Require().NoError(err)
+		_, err = suite.store.EpisodeGetByID(suite.ctx, episode.ID)
+		suite.ErrorIs(err, ErrNotFound)
+
+		// Verify process is kept with episode reference cleared
+		var episodeID sql.NullInt64
+		err = suite.db.QueryRow(`SELECT episode_id FROM processes WHERE id = ?`, process.ID).Scan(&episodeID)
+		suite.Require().NoError(err)
+		suite.False(episodeID.Valid)
+	})
+
+	suite.Run("NotFound", func() {
+		// Act
+		err := suite.store.EpisodeDelete(suite.ctx, 999)
+
+		// Assert
+		suite.ErrorIs(err, ErrNotFound)
+	})
+}
+
 // Test Process methods
 
 func (suite *TestSQLiteStoreSuite) TestProcessUpsert() {
@@ -530,6 +828,42 @@ func (suite *TestSQLiteStoreSuite) TestProcessUpsert() {
 		suite.Equal("192", retrievedProcess.Request.DownloadQuality)
 		suite.Equal(true, retrievedProcess.Request.Force)
 	})
+
+	suite.Run("WithOverrides", func() {
+		// Arrange
+		process := &entities.Process{
+			Request: entities.Request{
+				ID:            "req-overrides",
+				UserID:        12345,
+				ChatID:        67890,
+				MessageID:     111,
+				Url:           "https://example.com/video",
+				TitleOverride: "Custom Title",
+				EpisodeNumber: 12,
+				Lang:          "ru",
+			},
+			Step:   entities.StepCreating,
+			Status: entities.StatusInProgress,
+		}
+
+		// Act
+		err := suite.store.ProcessUpsert(suite.ctx, process)
+
+		// Assert
+		suite.Require().NoError(err)
+		var titleOverride string
+		var episodeNumber int
+		err = suite.db.QueryRow(`
+			SELECT request_title_override, request_episode_number
+			FROM processes WHERE id = ?`, process.ID).Scan(&titleOverride, &episodeNumber)
+		suite.Require().NoError(err)
+		suite.Equal("Custom Title", titleOverride)
+		suite.Equal(12, episodeNumber)
+
+		result, err := suite.store.ProcessGetByMessage(suite.ctx, 67890, 111)
+		suite.Require().NoError(err)
+		suite.Equal(process.Request, result.Request)
+	})
 }
 
 func (suite *TestSQLiteStoreSuite) TestProcessGetByStatus() {
@@ -682,11 +1016,65 @@ func (suite *TestSQLiteStoreSuite) TestProcessCountByUrlAndStatus() {
 	}
 
 	// Act
-	count, err := suite.store.ProcessCountByUrlAndStatus(suite.ctx, url, entities.StatusInProgress)
+	count, err := suite.store.ProcessCountByUrlAndStatus(suite.ctx, url, entities.StatusInProgress, processes[3].ID)
+	countBefore, errBefore := suite.store.ProcessCountByUrlAndStatus(suite.ctx, url, entities.StatusInProgress, processes[1].ID)
 
 	// Assert
 	suite.Require().NoError(err)
 	suite.Equal(2, count, "Should count 2 processes with matching URL and status")
+	suite.Require().NoError(errBefore)
+	suite.Equal(1, countBefore, "Should count only the processes accepted before the given one")
+}
+
+func (suite *TestSQLiteStoreSuite) TestProcessGetByMessage() {
+	suite.Run("Found", func() {
+		// Arrange
+		processes := []*entities.Process{
+			{
+				Request: entities.Request{
+					ID: "aaa", UserID: 1, ChatID: 10, MessageID: 100, Url: "https://example.com/video",
+				},
+				Step: entities.StepDownloading, Status: entities.StatusInProgress,
+			},
+			{
+				Request: entities.Request{
+					ID: "bbb", UserID: 1, ChatID: 10, MessageID: 101, Url: "https://example.com/other",
+				},
+				Step: entities.StepCreating, Status: entities.StatusInProgress,
+			},
+		}
+		for _, p := range processes {
+			suite.Require().NoError(suite.store.ProcessUpsert(suite.ctx, p))
+		}
+
+		// Act
+		process, err := suite.store.ProcessGetByMessage(suite.ctx, 10, 100)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(processes[0].ID, process.ID)
+		suite.Equal("aaa", process.Request.ID)
+		suite.Equal("https://example.com/video", process.Request.Url)
+		suite.Equal(entities.StepDownloading, process.Step)
+	})
+
+	suite.Run("NotFound", func() {
+		// Arrange
+		err := suite.store.ProcessUpsert(suite.ctx, &entities.Process{
+			Request: entities.Request{
+				ID: "aaa", UserID: 1, ChatID: 10, MessageID: 100, Url: "https://example.com/video",
+			},
+			Step: entities.StepCreating, Status: entities.StatusInProgress,
+		})
+		suite.Require().NoError(err)
+
+		// Act
+		process, err := suite.store.ProcessGetByMessage(suite.ctx, 11, 100)
+
+		// Assert
+		suite.Nil(process)
+		suite.ErrorIs(err, ErrNotFound)
+	})
 }
 
 // Test Transaction methods
@@ -761,6 +1149,82 @@ func (suite *TestSQLiteStoreSuite) TestBegin() {
 	})
 }
 
+func (suite *TestSQLiteStoreSuite) TestWithTx() {
+	newEpisode := func() *entities.Episode {
+		return &entities.Episode{
+			Title:        "TX Episode",
+			MediaFile:    "tx.mp3",
+			MediaType:    "audio/mpeg",
+			OriginalURL:  "https://example.com/tx",
+			CanonicalURL: "https://example.com/tx",
+		}
+	}
+
+	suite.Run("CommitOnSuccess", func() {
+		// Act
+		err := suite.store.WithTx(suite.ctx, func(tx Store) error {
+			return tx.EpisodeCreate(suite.ctx, newEpisode())
+		})
+
+		// Assert
+		suite.Require().NoError(err)
+		episodes, err := suite.store.EpisodeListAll(suite.ctx)
+		suite.Require().NoError(err)
+		suite.Len(episodes, 1)
+		suite.Equal("TX Episode", episodes[0].Title)
+	})
+
+	suite.Run("RollbackOnError", func() {
+		// Arrange
+		errFn := errors.New("fn failed")
+
+		// Act
+		err := suite.store.WithTx(suite.ctx, func(tx Store) error {
+			suite.Require().NoError(tx.EpisodeCreate(suite.ctx, newEpisode()))
+			return errFn
+		})
+
+		// Assert
+		suite.ErrorIs(err, errFn)
+		episodes, err := suite.store.EpisodeListAll(suite.ctx)
+		suite.Require().NoError(err)
+		suite.Empty(episodes, "No episodes should exist after rollback")
+	})
+
+	suite.Run("RollbackOnPanic", func() {
+		// Act
+		suite.PanicsWithValue("fn panicked", func() {
+			_ = suite.store.WithTx(suite.ctx, func(tx Store) error {
+				suite.Require().NoError(tx.EpisodeCreate(suite.ctx, newEpisode()))
+				panic("fn panicked")
+			})
+		})
+
+		// Assert
+		episodes, err := suite.store.EpisodeListAll(suite.ctx)
+		suite.Require().NoError(err)
+		suite.Empty(episodes, "No episodes should exist after rollback")
+	})
+
+	suite.Run("NestedTransaction", func() {
+		// Arrange
+		txStore, err := suite.store.Begin(suite.ctx)
+		suite.Require().NoError(err)
+		called := false
+
+		// Act
+		err = txStore.(*SQLiteStore).WithTx(suite.ctx, func(Store) error {
+			called = true
+			return nil
+		})
+
+		// Assert
+		suite.Error(err, "Should not allow nested transactions")
+		suite.False(called)
+		suite.Require().NoError(txStore.Rollback())
+	})
+}
+
 func (suite *TestSQLiteStoreSuite) TestCommit() {
 	suite.Run("WithoutTransaction", func() {
 		// Act
@@ -879,6 +1343,81 @@ func (suite *TestSQLiteStoreSuite) TestEpisodeCountAll() {
 	})
 }
 
+func (suite *TestSQLiteStoreSuite) TestEpisodeUpdatedAtMigration() {
+	suite.Run("NullFilledFromCreatedAt", func() {
+		// Arrange: version 9 database with an episode without updated_at
+		path := filepath.Join(suite.T().TempDir(), "voxify.db")
+		db, err := NewSQLite(path, 9)
+		suite.Require().NoError(err)
+		_, err = db.ExecContext(suite.ctx, `
+			INSERT INTO episodes (id, title, media_file, media_type, author, original_url, canonical_url, created_at, updated_at)
+			VALUES (5, 'Ep', 'ep.mp3', 'audio/mpeg', 'author', 'https://example.com/1', 'https://example.com/1', '2024-01-02 03:04:05', NULL)
+		`)
+		suite.Require().NoError(err)
+		_, err = db.ExecContext(suite.ctx, `
+			INSERT INTO processes (step, status, request_id, request_user_id, request_chat_id, request_message_id,
+			                       request_url, request_download_format, request_download_quality, episode_id)
+			VALUES ('publishing', 'success', 'req-1', 1, 1, 1, 'https://example.com/1', 'mp3', 'high', 5)
+		`)
+		suite.Require().NoError(err)
+		_, err = db.ExecContext(suite.ctx, `DELETE FROM episodes WHERE id = 5`)
+		suite.Require().NoError(err)
+		_, err = db.ExecContext(suite.ctx, `
+			INSERT INTO episodes (id, title, media_file, media_type, author, original_url, canonical_url, created_at, updated_at)
+			VALUES (3, 'Ep', 'ep.mp3', 'audio/mpeg', 'author', 'https://example.com/1', 'https://example.com/1', '2024-01-02 03:04:05', NULL)
+		`)
+		suite.Require().NoError(err)
+		_, err = db.ExecContext(suite.ctx, `UPDATE processes SET episode_id = 3`)
+		suite.Require().NoError(err)
+		suite.Require().NoError(db.Close())
+
+		// Act
+		db, err = NewSQLite(path, 10)
+		suite.Require().NoError(err)
+		defer func() { suite.Require().NoError(db.Close()) }()
+
+		// Assert
+		var updatedAt, createdAt time.Time
+		suite.Require().NoError(db.QueryRowContext(suite.ctx,
+			`SELECT created_at, updated_at FROM episodes WHERE id = 3`).Scan(&createdAt, &updatedAt))
+		suite.Equal(createdAt, updatedAt)
+
+		var episodeID sql.NullInt64
+		suite.Require().NoError(db.QueryRowContext(suite.ctx,
+			`SELECT episode_id FROM processes WHERE request_id = 'req-1'`).Scan(&episodeID))
+		suite.Equal(int64(3), episodeID.Int64, "process must keep its episode")
+
+		res, err := db.ExecContext(suite.ctx, `
+			INSERT INTO episodes (title, media_file, media_type, author, original_url, canonical_url)
+			VALUES ('Ep 2', 'ep2.mp3', 'audio/mpeg', 'author', 'https://example.com/2', 'https://example.com/2')
+		`)
+		suite.Require().NoError(err)
+		id, err := res.LastInsertId()
+		suite.Require().NoError(err)
+		suite.Equal(int64(6), id, "ids of deleted episodes must not be reused")
+	})
+
+	suite.Run("DefaultAndNotNull", func() {
+		// Act
+		_, err := suite.db.ExecContext(suite.ctx, `
+			INSERT INTO episodes (title, media_file, media_type, author, original_url, canonical_url)
+			VALUES ('Ep', 'ep.mp3', 'audio/mpeg', 'author', 'https://example.com/1', 'https://example.com/1')
+		`)
+		suite.Require().NoError(err)
+		_, errNull := suite.db.ExecContext(suite.ctx, `
+			INSERT INTO episodes (title, media_file, media_type, author, original_url, canonical_url, updated_at)
+			VALUES ('Ep', 'ep.mp3', 'audio/mpeg', 'author', 'https://example.com/2', 'https://example.com/2', NULL)
+		`)
+
+		// Assert
+		var updatedAt sql.NullTime
+		suite.Require().NoError(suite.db.QueryRowContext(suite.ctx,
+			`SELECT updated_at FROM episodes`).Scan(&updatedAt))
+		suite.True(updatedAt.Valid)
+		suite.Error(errNull)
+	})
+}
+
 // TestStore is the entry point for running the test suite
 func TestStore(t *testing.T) {
 	suite.Run(t, new(TestSQLiteStoreSuite))
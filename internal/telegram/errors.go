@@ -13,14 +13,14 @@ var (
 )
 
 // msgErr returns message to be sent to user based on the error
-func msgErr(err error) string {
+func msgErr(m *locales.Messages, err error) string {
 	if err == nil {
-		return fmt.Sprintf(locales.MsgSomethingWentWrongWithCode, 0)
+		return fmt.Sprintf(m.SomethingWentWrongWithCode, 0)
 	}
 
 	// Handle specific known error
 	if errors.Is(err, errProcessorBusy) {
-		return locales.MsgDownloadBusy
+		return m.DownloadBusy
 	}
 
 	// Handle business logic errors with codes
@@ -28,22 +28,36 @@ func msgErr(err error) string {
 	if errors.As(err, &e) {
 		switch e.Code {
 		case 101:
-			return locales.MsgNoMatchingPlatform
+			return m.NoMatchingPlatform
 		case 102:
-			return locales.MsgDownloadFailed
+			return m.DownloadFailed
 		case 103:
-			return locales.MsgEpisodeInProgress
+			return m.EpisodeInProgress
 		case 104:
-			return locales.MsgEpisodeExists
+			return m.EpisodeExists
 		case 105:
-			return locales.MsgProcessInterrupted
+			return m.ProcessInterrupted
 		case 106:
-			return locales.MsgEmptyFeed
+			return m.EmptyFeed
 		case 107:
-			return locales.MsgInvalidRequest
+			return m.InvalidRequest
+		case 108:
+			return m.EpisodeNotFound
+		case 109:
+			return m.FeedInvalid
+		case 110:
+			return m.DownloadTimeout
+		case 111:
+			return m.DiskFull
+		case 112:
+			return m.TooShort
+		case 113:
+			return m.PlaylistNotSupported
+		case 115:
+			return m.UnsupportedFormatForPlatform
 		default:
-			return fmt.Sprintf(locales.MsgSomethingWentWrongWithCode, e.Code)
+			return fmt.Sprintf(m.SomethingWentWrongWithCode, e.Code)
 		}
 	}
-	return locales.MsgSomethingWentWrong
+	return m.SomethingWentWrong
 }
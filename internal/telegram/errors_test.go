@@ -10,6 +10,9 @@ import (
 	"github.com/ofstudio/voxify/internal/services"
 )
 
+// msgEn is the table of English messages
+var msgEn = locales.Get(string(locales.LangEn), "")
+
 func TestMsgErr(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -24,42 +27,77 @@ func TestMsgErr(t *testing.T) {
 		{
 			name:     "ProcessorBusyError",
 			err:      errProcessorBusy,
-			expected: locales.MsgDownloadBusy,
+			expected: msgEn.DownloadBusy,
 		},
 		{
 			name:     "NoMatchingPlatformError",
 			err:      services.NewError(101, "no matching platform"),
-			expected: locales.MsgNoMatchingPlatform,
+			expected: msgEn.NoMatchingPlatform,
 		},
 		{
 			name:     "DownloadFailedError",
 			err:      services.NewError(102, "download failed"),
-			expected: locales.MsgDownloadFailed,
+			expected: msgEn.DownloadFailed,
 		},
 		{
 			name:     "EpisodeInProgressError",
 			err:      services.NewError(103, "episode in progress"),
-			expected: locales.MsgEpisodeInProgress,
+			expected: msgEn.EpisodeInProgress,
 		},
 		{
 			name:     "EpisodeExistsError",
 			err:      services.NewError(104, "episode exists"),
-			expected: locales.MsgEpisodeExists,
+			expected: msgEn.EpisodeExists,
 		},
 		{
 			name:     "ProcessInterruptedError",
 			err:      services.NewError(105, "process interrupted"),
-			expected: locales.MsgProcessInterrupted,
+			expected: msgEn.ProcessInterrupted,
 		},
 		{
 			name:     "EmptyFeedError",
 			err:      services.NewError(106, "empty feed"),
-			expected: locales.MsgEmptyFeed,
+			expected: msgEn.EmptyFeed,
 		},
 		{
 			name:     "InvalidRequestError",
 			err:      services.NewError(107, "invalid request"),
-			expected: locales.MsgInvalidRequest,
+			expected: msgEn.InvalidRequest,
+		},
+		{
+			name:     "EpisodeNotFoundError",
+			err:      services.NewError(108, "episode not found"),
+			expected: msgEn.EpisodeNotFound,
+		},
+		{
+			name:     "FeedInvalidError",
+			err:      services.NewError(109, "feed validation failed"),
+			expected: msgEn.FeedInvalid,
+		},
+		{
+			name:     "DownloadTimeoutError",
+			err:      services.NewError(110, "download timed out"),
+			expected: msgEn.DownloadTimeout,
+		},
+		{
+			name:     "DiskFullError",
+			err:      services.NewError(111, "server storage is full"),
+			expected: msgEn.DiskFull,
+		},
+		{
+			name:     "TooShortError",
+			err:      services.NewError(112, "media is too short"),
+			expected: msgEn.TooShort,
+		},
+		{
+			name:     "PlaylistNotSupportedError",
+			err:      services.NewError(113, "playlists are not supported"),
+			expected: msgEn.PlaylistNotSupported,
+		},
+		{
+			name:     "UnsupportedFormatForPlatformError",
+			err:      services.NewError(115, "download format is not supported by the platform"),
+			expected: msgEn.UnsupportedFormatForPlatform,
 		},
 		{
 			name:     "ProcessUpsertError",
@@ -94,18 +132,18 @@ func TestMsgErr(t *testing.T) {
 		{
 			name:     "GenericError",
 			err:      errors.New("generic error"),
-			expected: locales.MsgSomethingWentWrong,
+			expected: msgEn.SomethingWentWrong,
 		},
 		{
 			name:     "WrappedServiceError",
 			err:      errors.Join(services.NewError(102, "download failed"), errors.New("network error")),
-			expected: locales.MsgDownloadFailed,
+			expected: msgEn.DownloadFailed,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := msgErr(tt.err)
+			result := msgErr(msgEn, tt.err)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -2,8 +2,11 @@ package telegram
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"html"
 	"log/slog"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,21 +15,26 @@ import (
 	"github.com/ofstudio/voxify/internal/config"
 	"github.com/ofstudio/voxify/internal/entities"
 	"github.com/ofstudio/voxify/internal/locales"
+	"github.com/ofstudio/voxify/internal/services"
 )
 
 type Handlers struct {
-	log    *slog.Logger
-	cfg    config.Settings
-	out    chan<- entities.Request
-	feeder Feeder
+	log       *slog.Logger
+	cfg       config.Settings
+	out       chan<- entities.Request
+	feeder    Feeder
+	processor Processor
+	now       func() time.Time // Current time of the /status elapsed times
 }
 
-func NewHandlers(cfg config.Settings, log *slog.Logger, out chan<- entities.Request, f Feeder) *Handlers {
+func NewHandlers(cfg config.Settings, log *slog.Logger, out chan<- entities.Request, f Feeder, p Processor) *Handlers {
 	return &Handlers{
-		log:    log,
-		cfg:    cfg,
-		feeder: f,
-		out:    out,
+		log:       log,
+		cfg:       cfg,
+		feeder:    f,
+		processor: p,
+		out:       out,
+		now:       time.Now,
 	}
 }
 
@@ -43,7 +51,8 @@ func (h *Handlers) CmdStart() bot.HandlerFunc {
 			return
 		}
 		h.log.Info("[bot] start command received", "update_id", update.ID, "message", logMessage(update.Message))
-		h.sendMessage(ctx, b, update.Message.Chat, locales.MsgStart)
+		m := h.messages(update.Message.From)
+		h.sendMessage(ctx, b, update.Message.Chat, m.Start)
 	}
 }
 
@@ -56,16 +65,55 @@ func (h *Handlers) CmdBuild() bot.HandlerFunc {
 
 		h.log.Info("[bot] build command received", "update_id", update.ID, "message", logMessage(update.Message))
 
-		msg := locales.MsgBuildSuccess
-		if err := h.feeder.Build(ctx); err != nil {
-			msg = msgErr(err)
-			h.log.Error("[bot] failed to build podcast feed",
-				"error", err.Error(), "chat", logChat(&update.Message.Chat))
+		m := h.messages(update.Message.From)
+		msg := h.getBuildMessage(ctx, m)
+		h.sendMessage(ctx, b, update.Message.Chat, msg)
+	}
+}
+
+// getBuildMessage builds the podcast feed and returns the result message
+// with the number of episodes, the feed size and the build duration.
+func (h *Handlers) getBuildMessage(ctx context.Context, m *locales.Messages) string {
+	report, err := h.feeder.BuildReport(ctx)
+	if err != nil {
+		h.log.Error("[bot] failed to build podcast feed", "error", err.Error())
+		return msgErr(m, err)
+	}
+	return m.BuildSuccess + fmt.Sprintf(m.BuildReport,
+		report.EpisodeCount, formatSize(report.FeedSize), report.Duration.Round(time.Millisecond))
+}
+
+// CmdValidate handles the /validate command to check the RSS feed without writing it.
+func (h *Handlers) CmdValidate() bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		if update.Message == nil {
+			return
 		}
+
+		h.log.Info("[bot] validate command received", "update_id", update.ID, "message", logMessage(update.Message))
+
+		m := h.messages(update.Message.From)
+		msg := h.getValidateMessage(ctx, m)
 		h.sendMessage(ctx, b, update.Message.Chat, msg)
 	}
 }
 
+// getValidateMessage validates the podcast feed and returns the result message.
+// If the feed is invalid, the message contains the specific validation error.
+func (h *Handlers) getValidateMessage(ctx context.Context, m *locales.Messages) string {
+	err := h.feeder.Validate(ctx)
+	switch {
+	case err == nil:
+		return m.ValidateSuccess
+	case errors.Is(err, services.ErrFeedInvalid):
+		h.log.Info("[bot] podcast feed is invalid", "error", err.Error())
+		return fmt.Sprintf(m.ValidateFailed, err.Error())
+	default:
+		h.log.Error("[bot] failed to validate podcast feed", "error", err.Error())
+		return msgErr(m, err)
+	}
+}
+
 // CmdInfo handles the /info command to provide information about the podcast feed
 func (h *Handlers) CmdInfo() bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
@@ -75,9 +123,10 @@ func (h *Handlers) CmdInfo() bot.HandlerFunc {
 
 		h.log.Info("[bot] info command received", "update_id", update.ID, "message", logMessage(update.Message))
 
-		msg, err := h.getInfoMessage(ctx)
+		m := h.messages(update.Message.From)
+		msg, err := h.getInfoMessage(ctx, m)
 		if err != nil {
-			msg = msgErr(err)
+			msg = msgErr(m, err)
 		}
 
 		h.sendMessage(ctx, b, update.Message.Chat, msg, models.ParseModeHTML)
@@ -93,6 +142,8 @@ func (h *Handlers) CmdInfo() bot.HandlerFunc {
 //	💬 This is description
 //
 //	👨‍💻 By Oleg Fomin
+//	👤 Owner: Oleg Fomin (oleg@example.com)
+//	©️ 2025 Oleg Fomin
 //	🌐 Language: en
 //	📚 Categories: Science, Physics
 //	🔑 Keywords: Tech,Talks,News
@@ -102,51 +153,275 @@ func (h *Handlers) CmdInfo() bot.HandlerFunc {
 //	🔞 Explicit content
 //
 //	📡 RSS: https://example.com/feed.rss
-func (h *Handlers) getInfoMessage(ctx context.Context) (string, error) {
+func (h *Handlers) getInfoMessage(ctx context.Context, m *locales.Messages) (string, error) {
 	// Retrieve feed info
 	feed, err := h.feeder.Feed(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to get feed info: %w", err)
 	}
 
+	// The message is sent in HTML parse mode: the feed settings are escaped
+	esc := html.EscapeString
+
 	// Basic info: title, description
-	msg := fmt.Sprintf(locales.MsgFeedInfoBasic, feed.Title, feed.Description)
+	msg := fmt.Sprintf(m.FeedInfoBasic, esc(feed.Title), esc(feed.Description))
 	// Author
 	if feed.Author != "" {
-		msg += fmt.Sprintf(locales.MsgFeedInfoAuthor, feed.Author)
+		msg += fmt.Sprintf(m.FeedInfoAuthor, esc(feed.Author))
+	}
+	// Owner
+	if feed.Owner != nil {
+		msg += fmt.Sprintf(m.FeedInfoOwner, esc(ownerToString(feed.Owner)))
+	}
+	// Copyright
+	if feed.Copyright != "" {
+		msg += fmt.Sprintf(m.FeedInfoCopyright, esc(feed.Copyright))
 	}
 	// Language, categories
-	msg += fmt.Sprintf(locales.MsgFeedInfoLanguage, feed.Language)
+	msg += fmt.Sprintf(m.FeedInfoLanguage, esc(feed.Language))
 	// Categories
-	msg += fmt.Sprintf(locales.MsgFeedInfoCategories, categoriesToString(feed.Categories))
+	msg += fmt.Sprintf(m.FeedInfoCategories, esc(categoriesToString(feed.Categories)))
 	// Keywords
 	if feed.Keywords != "" {
-		msg += fmt.Sprintf(locales.MsgFeedInfoKeywords, feed.Keywords)
+		msg += fmt.Sprintf(m.FeedInfoKeywords, esc(feed.Keywords))
 	}
 	// Artwork
 	if feed.ImageUrl != "" {
-		msg += fmt.Sprintf(locales.MsgFeedInfoArtwork, feed.ImageUrl)
+		msg += fmt.Sprintf(m.FeedInfoArtwork, esc(feed.ImageUrl))
 	}
 	// Website
 	if feed.WebsiteLink != "" {
-		msg += fmt.Sprintf(locales.MsgFeedInfoWebsite, feed.WebsiteLink)
+		msg += fmt.Sprintf(m.FeedInfoWebsite, esc(feed.WebsiteLink))
 	}
 	// Episodes
 	if feed.EpisodeCount > 0 {
-		msg += fmt.Sprintf(locales.MsgFeedInfoEpisodes, feed.EpisodeCount)
+		msg += fmt.Sprintf(m.FeedInfoEpisodes, feed.EpisodeCount)
 	} else {
-		msg += locales.MsgFeedInfoNoEpisodes
+		msg += m.FeedInfoNoEpisodes
 	}
 	// Explicit
 	if feed.Explicit {
-		msg += locales.MsgFeedInfoExplicit
+		msg += m.FeedInfoExplicit
+	}
+	// Completed
+	if feed.FeedCompleted {
+		msg += m.FeedInfoCompleted
+	}
+	// Blocked
+	if feed.FeedBlocked {
+		msg += m.FeedInfoBlocked
 	}
 	// RSS link
-	msg += fmt.Sprintf(locales.MsgFeedInfoRSS, feed.RSSLink)
+	msg += fmt.Sprintf(m.FeedInfoRSS, esc(feed.RSSLink))
+
+	return msg, nil
+}
+
+const (
+	listPageSize       = 10      // Number of episodes per /list page
+	listCallbackPrefix = "list:" // Callback data prefix of the /list "next" button
+)
+
+// CmdList handles the /list command to show the most recent episodes.
+func (h *Handlers) CmdList() bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		if update.Message == nil {
+			return
+		}
+
+		h.log.Info("[bot] list command received", "update_id", update.ID, "message", logMessage(update.Message))
+
+		m := h.messages(update.Message.From)
+		msg, markup, err := h.getListMessage(ctx, m, 0)
+		if err != nil {
+			h.log.Error("[bot] failed to list episodes",
+				"error", err.Error(), "chat", logChat(&update.Message.Chat))
+			h.sendMessage(ctx, b, update.Message.Chat, msgErr(m, err))
+			return
+		}
+
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:             update.Message.Chat.ID,
+			Text:               msg,
+			ParseMode:          models.ParseModeHTML,
+			LinkPreviewOptions: &models.LinkPreviewOptions{IsDisabled: bot.True()},
+			ReplyMarkup:        markup,
+		})
+		if err != nil {
+			h.log.Error("[bot] failed to send message", "error", err.Error(), "chat", logChat(&update.Message.Chat))
+		}
+	}
+}
+
+// CbList handles the /list "next" button to show the next page of episodes.
+func (h *Handlers) CbList() bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		if update.CallbackQuery == nil {
+			return
+		}
+		query := update.CallbackQuery
+		h.log.Info("[bot] list callback received", "update_id", update.ID, "data", query.Data)
+
+		if _, err := b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{CallbackQueryID: query.ID}); err != nil {
+			h.log.Error("[bot] failed to answer callback query", "error", err.Error())
+		}
+
+		message := query.Message.Message
+		if message == nil {
+			return
+		}
+		offset, err := strconv.Atoi(strings.TrimPrefix(query.Data, listCallbackPrefix))
+		if err != nil || offset < 0 {
+			h.log.Error("[bot] invalid list callback data", "data", query.Data)
+			return
+		}
+
+		m := h.messages(&query.From)
+		msg, markup, err := h.getListMessage(ctx, m, offset)
+		if err != nil {
+			h.log.Error("[bot] failed to list episodes",
+				"error", err.Error(), "chat", logChat(&message.Chat))
+			h.sendMessage(ctx, b, message.Chat, msgErr(m, err))
+			return
+		}
+
+		_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:             message.Chat.ID,
+			MessageID:          message.ID,
+			Text:               msg,
+			ParseMode:          models.ParseModeHTML,
+			LinkPreviewOptions: &models.LinkPreviewOptions{IsDisabled: bot.True()},
+			ReplyMarkup:        markup,
+		})
+		if err != nil {
+			h.log.Error("[bot] failed to edit message", "error", err.Error(), "chat", logChat(&message.Chat))
+		}
+	}
+}
+
+// getListMessage retrieves a page of recent episodes starting at offset and formats it into a message.
+// The returned markup contains the "next" button if there are more episodes, otherwise it is nil.
+// Example output:
+//
+//	🎧 Recent episodes
+//
+//	1. Episode title (1:02:03) <- link to media file
+//	2. Another episode (42:10)
+func (h *Handlers) getListMessage(ctx context.Context, m *locales.Messages, offset int) (string, models.ReplyMarkup, error) {
+	// Request one more episode to find out if there is a next page
+	episodes, err := h.feeder.RecentEpisodes(ctx, listPageSize+1, offset)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get recent episodes: %w", err)
+	}
+	if len(episodes) == 0 {
+		return m.ListEmpty, nil, nil
+	}
 
+	hasNext := len(episodes) > listPageSize
+	if hasNext {
+		episodes = episodes[:listPageSize]
+	}
+
+	msg := m.ListHeader
+	for i, episode := range episodes {
+		mediaUrl := h.cfg.MediaUrl(episode.MediaFile)
+		msg += fmt.Sprintf(m.ListItem,
+			offset+i+1, html.EscapeString(mediaUrl), html.EscapeString(episode.Title), formatDuration(episode.MediaDuration))
+	}
+
+	if !hasNext {
+		return msg, nil, nil
+	}
+	markup := &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{{
+			{Text: m.ListNext, CallbackData: listCallbackPrefix + strconv.Itoa(offset+listPageSize)},
+		}},
+	}
+	return msg, markup, nil
+}
+
+// CmdStatus handles the /status command to show the downloads in progress.
+func (h *Handlers) CmdStatus() bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		if update.Message == nil {
+			return
+		}
+
+		h.log.Info("[bot] status command received", "update_id", update.ID, "message", logMessage(update.Message))
+
+		m := h.messages(update.Message.From)
+		msg, err := h.getStatusMessage(ctx, m)
+		if err != nil {
+			h.log.Error("[bot] failed to get active processes",
+				"error", err.Error(), "chat", logChat(&update.Message.Chat))
+			msg = msgErr(m, err)
+		}
+
+		h.sendMessage(ctx, b, update.Message.Chat, msg, models.ParseModeHTML)
+	}
+}
+
+// getStatusMessage retrieves the processes in progress and formats them into a message
+// with the step and the time elapsed since each request was accepted.
+// Example output:
+//
+//	⏳ Active downloads
+//
+//	1. https://youtu.be/dQw4w9WgXcQ — downloading, 1m30s
+//	2. https://youtu.be/jNQXAC9IVRw — creating, 12s
+func (h *Handlers) getStatusMessage(ctx context.Context, m *locales.Messages) (string, error) {
+	processes, err := h.processor.Active(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get active processes: %w", err)
+	}
+	if len(processes) == 0 {
+		return m.StatusEmpty, nil
+	}
+
+	msg := m.StatusHeader
+	for i, process := range processes {
+		elapsed := max(h.now().Sub(process.CreatedAt), 0).Round(time.Second)
+		msg += fmt.Sprintf(m.StatusItem,
+			i+1, html.EscapeString(process.Request.Url), stepName(m, process.Step), elapsed)
+	}
 	return msg, nil
 }
 
+// stepName returns the name of the process step in the language of the messages.
+func stepName(m *locales.Messages, step entities.Step) string {
+	switch step {
+	case entities.StepCreating:
+		return m.StepCreating
+	case entities.StepDownloading:
+		return m.StepDownloading
+	case entities.StepPublishing:
+		return m.StepPublishing
+	default:
+		return string(step)
+	}
+}
+
+// formatDuration formats duration in seconds as h:mm:ss or m:ss.
+func formatDuration(seconds int64) string {
+	h, m, s := seconds/3600, seconds%3600/60, seconds%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// formatSize formats size in bytes as B, KB or MB.
+func formatSize(size int64) string {
+	switch {
+	case size < 1024:
+		return fmt.Sprintf("%d B", size)
+	case size < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	}
+}
+
 // categoriesToString converts a slice of FeedCategory to a comma-separated string.
 // It includes subcategories as well.
 func categoriesToString(categories []entities.FeedCategory) string {
@@ -160,25 +435,110 @@ func categoriesToString(categories []entities.FeedCategory) string {
 	return strings.Join(cats, ", ")
 }
 
+// ownerToString formats the podcast owner as "Name (email)",
+// or only the name or email if the other one is empty.
+func ownerToString(owner *entities.FeedOwner) string {
+	switch {
+	case owner.Name == "":
+		return owner.Email
+	case owner.Email == "":
+		return owner.Name
+	default:
+		return owner.Name + " (" + owner.Email + ")"
+	}
+}
+
 func (h *Handlers) Url() bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
 		if update.Message == nil || update.Message.Text == "" {
 			return
 		}
 		h.log.Info("[bot] url received", "update_id", update.ID, "url", update.Message.Text)
+		m := h.messages(update.Message.From)
 		request := entities.Request{
 			UserID:    update.Message.From.ID,
 			ChatID:    update.Message.Chat.ID,
 			MessageID: update.Message.ID,
 			Url:       update.Message.Text,
 			Force:     false,
+			Lang:      update.Message.From.LanguageCode,
 		}
 		if err := h.sendRequest(ctx, request); err != nil {
 			h.log.Error("[bot] failed to queue request",
 				"error", err.Error(), "request", request.LogValue())
-			h.sendMessage(ctx, b, update.Message.Chat, msgErr(err))
+			h.sendMessage(ctx, b, update.Message.Chat, msgErr(m, err))
+		}
+	}
+}
+
+// CmdAdd handles the /add command to download a URL with the optional title and episode number overrides.
+// Usage: /add <url> [| title] [| episode number]
+func (h *Handlers) CmdAdd() bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		if update.Message == nil {
+			return
+		}
+		h.log.Info("[bot] add command received", "update_id", update.ID, "message", logMessage(update.Message))
+		m := h.messages(update.Message.From)
+
+		u, title, number, err := parseAddCommand(update.Message.Text)
+		if err != nil {
+			h.log.Info("[bot] invalid add command", "error", err.Error())
+			h.sendMessage(ctx, b, update.Message.Chat, m.AddUsage)
+			return
 		}
+
+		request := entities.Request{
+			UserID:        update.Message.From.ID,
+			ChatID:        update.Message.Chat.ID,
+			MessageID:     update.Message.ID,
+			Url:           u,
+			TitleOverride: title,
+			EpisodeNumber: number,
+			Lang:          update.Message.From.LanguageCode,
+		}
+		if err = h.sendRequest(ctx, request); err != nil {
+			h.log.Error("[bot] failed to queue request",
+				"error", err.Error(), "request", request.LogValue())
+			h.sendMessage(ctx, b, update.Message.Chat, msgErr(m, err))
+		}
+	}
+}
+
+// parseAddCommand parses the /add command text in the form "/add <url> [| title] [| episode number]".
+// Empty title and episode number parts are allowed.
+func parseAddCommand(text string) (u, title string, number int, err error) {
+	// Strip the command itself, e.g. "/add" or "/add@bot_name"
+	_, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	parts := strings.Split(args, "|")
+	if len(parts) > 3 {
+		return "", "", 0, errors.New("too many arguments")
+	}
+
+	u = strings.TrimSpace(parts[0])
+	if u == "" {
+		return "", "", 0, errors.New("url is required")
+	}
+	if len(parts) > 1 {
+		title = strings.TrimSpace(parts[1])
+	}
+	if len(parts) > 2 && strings.TrimSpace(parts[2]) != "" {
+		number, err = strconv.Atoi(strings.TrimSpace(parts[2]))
+		if err != nil || number < 1 {
+			return "", "", 0, fmt.Errorf("invalid episode number: %q", strings.TrimSpace(parts[2]))
+		}
+	}
+	return u, title, number, nil
+}
+
+// messages returns the bot messages in the language of the user.
+// The configured bot language is used if the user language is not supported.
+func (h *Handlers) messages(u *models.User) *locales.Messages {
+	var code string
+	if u != nil {
+		code = u.LanguageCode
 	}
+	return locales.Get(code, h.cfg.BotLanguage)
 }
 
 // sendRequest tries to send the request to the processor safely.
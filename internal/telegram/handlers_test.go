@@ -3,16 +3,20 @@ package telegram
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/go-telegram/bot/models"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/ofstudio/voxify/internal/config"
 	"github.com/ofstudio/voxify/internal/entities"
 	"github.com/ofstudio/voxify/internal/mocks"
+	"github.com/ofstudio/voxify/internal/services"
 )
 
 // TestHandlersSuite is a test suite for Handlers
@@ -24,6 +28,8 @@ type TestHandlersSuite struct {
 	handlers    *Handlers
 	requestChan chan entities.Request
 	mockFeeder  *mocks.MockFeeder
+
+	mockProcessor *mocks.MockProcessor
 }
 
 // SetupSuite is called once before the entire test suite runs
@@ -39,7 +45,8 @@ func (suite *TestHandlersSuite) SetupSuite() {
 func (suite *TestHandlersSuite) SetupTest() {
 	suite.requestChan = make(chan entities.Request, 10)
 	suite.mockFeeder = mocks.NewMockFeeder(suite.T())
-	suite.handlers = NewHandlers(suite.cfg, suite.log, suite.requestChan, suite.mockFeeder)
+	suite.mockProcessor = mocks.NewMockProcessor(suite.T())
+	suite.handlers = NewHandlers(suite.cfg, suite.log, suite.requestChan, suite.mockFeeder, suite.mockProcessor)
 }
 
 // TestSendRequest tests the sendRequest method
@@ -134,7 +141,7 @@ func (suite *TestHandlersSuite) TestGetInfoMessage() {
 		// Arrange: use isolated mock and handlers
 		mockFeeder := mocks.NewMockFeeder(suite.T())
 		reqCh := make(chan entities.Request, 1)
-		h := NewHandlers(suite.cfg, suite.log, reqCh, mockFeeder)
+		h := NewHandlers(suite.cfg, suite.log, reqCh, mockFeeder, nil)
 
 		feed := &entities.Feed{
 			Title:       "My podcast",
@@ -146,16 +153,21 @@ func (suite *TestHandlersSuite) TestGetInfoMessage() {
 			},
 			Keywords:     "Tech,Talks",
 			Author:       "Alice",
+			Owner:        &entities.FeedOwner{Name: "Alice", Email: "alice@site.example"},
+			Copyright:    "2025 Alice",
 			Explicit:     true,
 			WebsiteLink:  "https://site.example",
 			ImageUrl:     "https://site.example/cover.jpg",
 			EpisodeCount: 3,
 			RSSLink:      "https://site.example/rss.xml",
+
+			FeedCompleted: true,
+			FeedBlocked:   true,
 		}
 		mockFeeder.On("Feed", suite.ctx).Return(feed, nil).Once()
 
 		// Act
-		msg, err := h.getInfoMessage(suite.ctx)
+		msg, err := h.getInfoMessage(suite.ctx, msgEn)
 
 		// Assert
 		suite.NoError(err)
@@ -163,6 +175,8 @@ func (suite *TestHandlersSuite) TestGetInfoMessage() {
 		suite.Contains(msg, "My podcast")
 		suite.Contains(msg, "Awesome show")
 		suite.Contains(msg, "By Alice")
+		suite.Contains(msg, "Owner: Alice (alice@site.example)")
+		suite.Contains(msg, "©️ 2025 Alice")
 		suite.Contains(msg, "Language: en")
 		suite.Contains(msg, "Categories: Science, Physics, Astronomy, Technology")
 		suite.Contains(msg, "Keywords: Tech,Talks")
@@ -170,6 +184,8 @@ func (suite *TestHandlersSuite) TestGetInfoMessage() {
 		suite.Contains(msg, "<a href=\"https://site.example\">Website</a>")
 		suite.Contains(msg, "Number of episodes: 3")
 		suite.Contains(msg, "Explicit content")
+		suite.Contains(msg, "Show completed")
+		suite.Contains(msg, "Hidden from Apple Podcasts")
 		suite.Contains(msg, "RSS: https://site.example/rss.xml")
 	})
 
@@ -177,19 +193,388 @@ func (suite *TestHandlersSuite) TestGetInfoMessage() {
 		// Arrange: use isolated mock and handlers
 		mockFeeder := mocks.NewMockFeeder(suite.T())
 		reqCh := make(chan entities.Request, 1)
-		h := NewHandlers(suite.cfg, suite.log, reqCh, mockFeeder)
+		h := NewHandlers(suite.cfg, suite.log, reqCh, mockFeeder, nil)
 
 		expectedErr := errors.New("feed error")
 		mockFeeder.On("Feed", suite.ctx).Return((*entities.Feed)(nil), expectedErr).Once()
 
 		// Act
-		msg, err := h.getInfoMessage(suite.ctx)
+		msg, err := h.getInfoMessage(suite.ctx, msgEn)
 
 		// Assert
 		suite.Error(err)
 		suite.Equal("", msg)
 		suite.Contains(err.Error(), "failed to get feed info")
 	})
+
+	suite.Run("Escaped", func() {
+		// Arrange: use isolated mock and handlers
+		mockFeeder := mocks.NewMockFeeder(suite.T())
+		reqCh := make(chan entities.Request, 1)
+		h := NewHandlers(suite.cfg, suite.log, reqCh, mockFeeder, nil)
+
+		feed := &entities.Feed{
+			Title:     "Q&A <Live>",
+			Language:  "en",
+			Owner:     &entities.FeedOwner{Name: "Alice & Bob", Email: "<alice@site.example>"},
+			Copyright: "© 2025 <Alice & Bob>",
+			RSSLink:   "https://site.example/rss.xml?a=1&b=2",
+		}
+		mockFeeder.On("Feed", suite.ctx).Return(feed, nil).Once()
+
+		// Act
+		msg, err := h.getInfoMessage(suite.ctx, msgEn)
+
+		// Assert
+		suite.NoError(err)
+		suite.Contains(msg, "<b>Q&amp;A &lt;Live&gt;</b>")
+		suite.Contains(msg, "Owner: Alice &amp; Bob (&lt;alice@site.example&gt;)")
+		suite.Contains(msg, "© 2025 &lt;Alice &amp; Bob&gt;")
+		suite.Contains(msg, "RSS: https://site.example/rss.xml?a=1&amp;b=2")
+		suite.NotContains(msg, "<Alice")
+	})
+}
+
+// TestGetBuildMessage tests the getBuildMessage method
+func (suite *TestHandlersSuite) TestGetBuildMessage() {
+	suite.Run("Success", func() {
+		// Arrange
+		mockFeeder := mocks.NewMockFeeder(suite.T())
+		h := NewHandlers(suite.cfg, suite.log, make(chan entities.Request, 1), mockFeeder, nil)
+		report := &entities.FeedBuildReport{EpisodeCount: 42, FeedSize: 1536, Duration: 1234567 * time.Microsecond}
+		mockFeeder.On("BuildReport", suite.ctx).Return(report, nil).Once()
+
+		// Act
+		msg := h.getBuildMessage(suite.ctx, msgEn)
+
+		// Assert
+		suite.Contains(msg, msgEn.BuildSuccess)
+		suite.Contains(msg, "Episodes: 42")
+		suite.Contains(msg, "Feed size: 1.5 KB")
+		suite.Contains(msg, "Built in 1.235s")
+	})
+
+	suite.Run("Failure", func() {
+		// Arrange
+		mockFeeder := mocks.NewMockFeeder(suite.T())
+		h := NewHandlers(suite.cfg, suite.log, make(chan entities.Request, 1), mockFeeder, nil)
+		mockFeeder.On("BuildReport", suite.ctx).Return(nil, services.ErrEmptyFeed).Once()
+
+		// Act
+		msg := h.getBuildMessage(suite.ctx, msgEn)
+
+		// Assert
+		suite.Equal(msgEn.EmptyFeed, msg)
+	})
+}
+
+// TestGetValidateMessage tests the getValidateMessage method
+func (suite *TestHandlersSuite) TestGetValidateMessage() {
+	suite.Run("Valid", func() {
+		// Arrange
+		mockFeeder := mocks.NewMockFeeder(suite.T())
+		h := NewHandlers(suite.cfg, suite.log, make(chan entities.Request, 1), mockFeeder, nil)
+		mockFeeder.On("Validate", suite.ctx).Return(nil).Once()
+
+		// Act
+		msg := h.getValidateMessage(suite.ctx, msgEn)
+
+		// Assert
+		suite.Equal(msgEn.ValidateSuccess, msg)
+	})
+
+	suite.Run("Invalid", func() {
+		// Arrange
+		mockFeeder := mocks.NewMockFeeder(suite.T())
+		h := NewHandlers(suite.cfg, suite.log, make(chan entities.Request, 1), mockFeeder, nil)
+		validationErr := fmt.Errorf("%w: %w", services.ErrFeedInvalid,
+			errors.New("invalid item 0: item enclosure length must be greater than zero"))
+		mockFeeder.On("Validate", suite.ctx).Return(validationErr).Once()
+
+		// Act
+		msg := h.getValidateMessage(suite.ctx, msgEn)
+
+		// Assert
+		suite.Contains(msg, "RSS feed is invalid")
+		suite.Contains(msg, "item enclosure length must be greater than zero")
+	})
+
+	suite.Run("EmptyFeed", func() {
+		// Arrange
+		mockFeeder := mocks.NewMockFeeder(suite.T())
+		h := NewHandlers(suite.cfg, suite.log, make(chan entities.Request, 1), mockFeeder, nil)
+		mockFeeder.On("Validate", suite.ctx).Return(services.ErrEmptyFeed).Once()
+
+		// Act
+		msg := h.getValidateMessage(suite.ctx, msgEn)
+
+		// Assert
+		suite.Equal(msgEn.EmptyFeed, msg)
+	})
+}
+
+// TestGetListMessage tests the getListMessage method
+func (suite *TestHandlersSuite) TestGetListMessage() {
+	cfg := suite.cfg
+	publicUrl, err := url.Parse("https://site.example/public")
+	suite.Require().NoError(err)
+	cfg.PublicUrl = *publicUrl
+
+	// newEpisodes creates n episodes numbered from first
+	newEpisodes := func(first, n int) []*entities.Episode {
+		var episodes []*entities.Episode
+		for i := first; i < first+n; i++ {
+			episodes = append(episodes, &entities.Episode{
+				ID:            int64(i),
+				Title:         fmt.Sprintf("Episode %d", i),
+				MediaFile:     fmt.Sprintf("episode%d.mp3", i),
+				MediaDuration: 3723,
+			})
+		}
+		return episodes
+	}
+
+	suite.Run("Populated", func() {
+		// Arrange
+		mockFeeder := mocks.NewMockFeeder(suite.T())
+		h := NewHandlers(cfg, suite.log, make(chan entities.Request, 1), mockFeeder, nil)
+		episodes := newEpisodes(1, listPageSize+1)
+		episodes[0].Title = "Q&A <live>"
+		episodes[0].MediaDuration = 62
+		mockFeeder.On("RecentEpisodes", suite.ctx, listPageSize+1, 0).Return(episodes, nil).Once()
+
+		// Act
+		msg, markup, err := h.getListMessage(suite.ctx, msgEn, 0)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Contains(msg, "Recent episodes")
+		suite.Contains(msg, `1. <a href="https://site.example/public/episode1.mp3">Q&amp;A &lt;live&gt;</a> (1:02)`)
+		suite.Contains(msg, `2. <a href="https://site.example/public/episode2.mp3">Episode 2</a> (1:02:03)`)
+		suite.Contains(msg, fmt.Sprintf("%d. ", listPageSize))
+		suite.NotContains(msg, fmt.Sprintf("Episode %d", listPageSize+1), "extra episode must not be listed")
+
+		keyboard, ok := markup.(*models.InlineKeyboardMarkup)
+		suite.Require().True(ok)
+		suite.Require().Len(keyboard.InlineKeyboard, 1)
+		suite.Require().Len(keyboard.InlineKeyboard[0], 1)
+		suite.Equal(msgEn.ListNext, keyboard.InlineKeyboard[0][0].Text)
+		suite.Equal(fmt.Sprintf("list:%d", listPageSize), keyboard.InlineKeyboard[0][0].CallbackData)
+	})
+
+	suite.Run("LastPage", func() {
+		// Arrange
+		mockFeeder := mocks.NewMockFeeder(suite.T())
+		h := NewHandlers(cfg, suite.log, make(chan entities.Request, 1), mockFeeder, nil)
+		mockFeeder.On("RecentEpisodes", suite.ctx, listPageSize+1, listPageSize).
+			Return(newEpisodes(listPageSize+1, 2), nil).Once()
+
+		// Act
+		msg, markup, err := h.getListMessage(suite.ctx, msgEn, listPageSize)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Contains(msg, fmt.Sprintf("%d. <a href=", listPageSize+1))
+		suite.Contains(msg, fmt.Sprintf("%d. <a href=", listPageSize+2))
+		suite.Nil(markup)
+	})
+
+	suite.Run("Empty", func() {
+		// Arrange
+		mockFeeder := mocks.NewMockFeeder(suite.T())
+		h := NewHandlers(cfg, suite.log, make(chan entities.Request, 1), mockFeeder, nil)
+		mockFeeder.On("RecentEpisodes", suite.ctx, listPageSize+1, 0).Return([]*entities.Episode{}, nil).Once()
+
+		// Act
+		msg, markup, err := h.getListMessage(suite.ctx, msgEn, 0)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(msgEn.ListEmpty, msg)
+		suite.Nil(markup)
+	})
+
+	suite.Run("Error", func() {
+		// Arrange
+		mockFeeder := mocks.NewMockFeeder(suite.T())
+		h := NewHandlers(cfg, suite.log, make(chan entities.Request, 1), mockFeeder, nil)
+		mockFeeder.On("RecentEpisodes", suite.ctx, listPageSize+1, 0).Return(nil, services.ErrEpisodeList).Once()
+
+		// Act
+		_, _, err := h.getListMessage(suite.ctx, msgEn, 0)
+
+		// Assert
+		suite.ErrorIs(err, services.ErrEpisodeList)
+	})
+}
+
+// TestGetStatusMessage tests the getStatusMessage method
+func (suite *TestHandlersSuite) TestGetStatusMessage() {
+	now := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	// newHandlers creates the handlers with the mock processor and the fixed current time
+	newHandlers := func() (*Handlers, *mocks.MockProcessor) {
+		mockProcessor := mocks.NewMockProcessor(suite.T())
+		h := NewHandlers(suite.cfg, suite.log, make(chan entities.Request, 1), nil, mockProcessor)
+		h.now = func() time.Time { return now }
+		return h, mockProcessor
+	}
+
+	suite.Run("ActiveProcesses", func() {
+		// Arrange
+		h, mockProcessor := newHandlers()
+		mockProcessor.On("Active", suite.ctx).Return([]*entities.Process{
+			{
+				ID:        1,
+				Request:   entities.Request{Url: "https://example.com/watch?v=1&t=2"},
+				Step:      entities.StepDownloading,
+				Status:    entities.StatusInProgress,
+				CreatedAt: now.Add(-90 * time.Second),
+			},
+			{
+				ID:        2,
+				Request:   entities.Request{Url: "https://example.com/watch?v=2"},
+				Step:      entities.StepCreating,
+				Status:    entities.StatusInProgress,
+				CreatedAt: now.Add(-12 * time.Second),
+			},
+		}, nil).Once()
+
+		// Act
+		msg, err := h.getStatusMessage(suite.ctx, msgEn)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal("⏳ <b>Active downloads</b>\n\n"+
+			"1. https://example.com/watch?v=1&amp;t=2 — downloading, 1m30s\n"+
+			"2. https://example.com/watch?v=2 — creating, 12s\n", msg)
+	})
+
+	suite.Run("NoProcesses", func() {
+		// Arrange
+		h, mockProcessor := newHandlers()
+		mockProcessor.On("Active", suite.ctx).Return([]*entities.Process{}, nil).Once()
+
+		// Act
+		msg, err := h.getStatusMessage(suite.ctx, msgEn)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal(msgEn.StatusEmpty, msg)
+	})
+
+	suite.Run("Error", func() {
+		// Arrange
+		h, mockProcessor := newHandlers()
+		mockProcessor.On("Active", suite.ctx).Return(nil, errors.New("db error")).Once()
+
+		// Act
+		msg, err := h.getStatusMessage(suite.ctx, msgEn)
+
+		// Assert
+		suite.Require().Error(err)
+		suite.Empty(msg)
+	})
+}
+
+// TestParseAddCommand tests the parseAddCommand helper
+func (suite *TestHandlersSuite) TestParseAddCommand() {
+	suite.Run("UrlOnly", func() {
+		u, title, number, err := parseAddCommand("/add https://example.com/video")
+		suite.NoError(err)
+		suite.Equal("https://example.com/video", u)
+		suite.Empty(title)
+		suite.Zero(number)
+	})
+
+	suite.Run("WithTitle", func() {
+		u, title, number, err := parseAddCommand("/add https://example.com/video | My Title ")
+		suite.NoError(err)
+		suite.Equal("https://example.com/video", u)
+		suite.Equal("My Title", title)
+		suite.Zero(number)
+	})
+
+	suite.Run("WithTitleAndNumber", func() {
+		u, title, number, err := parseAddCommand("/add@voxify_bot https://example.com/video | My Title | 12")
+		suite.NoError(err)
+		suite.Equal("https://example.com/video", u)
+		suite.Equal("My Title", title)
+		suite.Equal(12, number)
+	})
+
+	suite.Run("NumberOnly", func() {
+		u, title, number, err := parseAddCommand("/add https://example.com/video || 3")
+		suite.NoError(err)
+		suite.Equal("https://example.com/video", u)
+		suite.Empty(title)
+		suite.Equal(3, number)
+	})
+
+	suite.Run("Invalid", func() {
+		for _, text := range []string{
+			"/add",
+			"/add   ",
+			"/add | Title",
+			"/add https://example.com/video | Title | abc",
+			"/add https://example.com/video | Title | 0",
+			"/add https://example.com/video | Title | -1",
+			"/add https://example.com/video | Title | 1 | extra",
+		} {
+			_, _, _, err := parseAddCommand(text)
+			suite.Error(err, text)
+		}
+	})
+}
+
+// TestMessages tests that messages are selected by the user language
+func (suite *TestHandlersSuite) TestMessages() {
+	suite.Run("UserLanguage", func() {
+		// Act
+		m := suite.handlers.messages(&models.User{LanguageCode: "ru"})
+
+		// Assert
+		suite.Equal("✅ RSS-лента успешно собрана!", m.BuildSuccess)
+	})
+
+	suite.Run("UnknownLanguageFallsBackToConfigured", func() {
+		// Arrange
+		cfg := suite.cfg
+		cfg.BotLanguage = "ru"
+		h := NewHandlers(cfg, suite.log, suite.requestChan, suite.mockFeeder, nil)
+
+		// Act
+		m := h.messages(&models.User{LanguageCode: "de"})
+
+		// Assert
+		suite.Equal("✅ RSS-лента успешно собрана!", m.BuildSuccess)
+	})
+
+	suite.Run("NoUser", func() {
+		// Act
+		m := suite.handlers.messages(nil)
+
+		// Assert
+		suite.Equal(msgEn.BuildSuccess, m.BuildSuccess)
+	})
+}
+
+// TestFormatDuration tests the formatDuration helper
+func (suite *TestHandlersSuite) TestFormatDuration() {
+	suite.Equal("0:00", formatDuration(0))
+	suite.Equal("0:59", formatDuration(59))
+	suite.Equal("42:10", formatDuration(2530))
+	suite.Equal("1:00:00", formatDuration(3600))
+	suite.Equal("10:02:03", formatDuration(36123))
+}
+
+// TestFormatSize tests the formatSize helper
+func (suite *TestHandlersSuite) TestFormatSize() {
+	suite.Equal("0 B", formatSize(0))
+	suite.Equal("1023 B", formatSize(1023))
+	suite.Equal("1.0 KB", formatSize(1024))
+	suite.Equal("250.5 KB", formatSize(256512))
+	suite.Equal("3.0 MB", formatSize(3*1024*1024))
 }
 
 // TestCategoriesToString tests the categoriesToString helper
@@ -216,6 +601,13 @@ func (suite *TestHandlersSuite) TestCategoriesToString() {
 	})
 }
 
+// TestOwnerToString tests the ownerToString helper
+func (suite *TestHandlersSuite) TestOwnerToString() {
+	suite.Equal("Alice (alice@site.example)", ownerToString(&entities.FeedOwner{Name: "Alice", Email: "alice@site.example"}))
+	suite.Equal("Alice", ownerToString(&entities.FeedOwner{Name: "Alice"}))
+	suite.Equal("alice@site.example", ownerToString(&entities.FeedOwner{Email: "alice@site.example"}))
+}
+
 // Run the test suite
 func TestHandlers(t *testing.T) {
 	suite.Run(t, new(TestHandlersSuite))
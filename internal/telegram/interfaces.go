@@ -5,3 +5,5 @@ import (
 )
 
 type Feeder = services.Feeder
+
+type Processor = services.Processor
@@ -1,29 +1,50 @@
 package telegram
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"html"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
+	"github.com/ofstudio/voxify/internal/config"
 	"github.com/ofstudio/voxify/internal/entities"
 	"github.com/ofstudio/voxify/internal/locales"
 )
 
+// chatActionInterval is the interval of resending the chat action during the download.
+// Telegram shows the action for 5 seconds or until a message is sent.
+const chatActionInterval = 4 * time.Second
+
+// defaultChatActionTimeout is the maximum duration of sending the chat action
+// if DownloadTimeout is not set.
+const defaultChatActionTimeout = time.Hour
+
 // Notifications handles sending notifications to users about process updates.
 type Notifications struct {
-	log *slog.Logger
-	bot *bot.Bot
-	in  <-chan entities.Process
+	cfg            config.Settings
+	log            *slog.Logger
+	bot            *bot.Bot
+	in             <-chan entities.Process
+	actions        map[int64]context.CancelFunc // Chat actions of the downloading processes by process ID
+	actionInterval time.Duration
+	actionTimeout  time.Duration // Maximum duration of sending the chat action of a process
 }
 
 // NewNotifications creates a new Notifications instance.
-func NewNotifications(log *slog.Logger, bot *bot.Bot, in <-chan entities.Process) *Notifications {
+func NewNotifications(cfg config.Settings, log *slog.Logger, bot *bot.Bot, in <-chan entities.Process) *Notifications {
 	return &Notifications{
-		log: log,
-		bot: bot,
-		in:  in,
+		cfg:            cfg,
+		log:            log,
+		bot:            bot,
+		in:             in,
+		actions:        make(map[int64]context.CancelFunc),
+		actionInterval: chatActionInterval,
+		actionTimeout:  cmp.Or(cfg.DownloadTimeout, defaultChatActionTimeout),
 	}
 }
 
@@ -36,6 +57,7 @@ func (n *Notifications) Start(ctx context.Context) {
 				n.log.Info("[bot] notifications stopped")
 				return
 			case process := <-n.in:
+				n.trackChatAction(ctx, process)
 				msg := n.getMessage(process)
 				n.replyMessage(ctx, process, msg)
 			}
@@ -43,25 +65,108 @@ func (n *Notifications) Start(ctx context.Context) {
 	}()
 }
 
+// getMessage returns the notification message in the language of the request.
 func (n *Notifications) getMessage(process entities.Process) string {
+	m := locales.Get(process.Request.Lang, n.cfg.BotLanguage)
 	switch {
 	case process.Step == entities.StepDownloading && process.Status == entities.StatusInProgress:
-		return locales.MsgDownloadStarted
+		return m.DownloadStarted
+	case process.Step == entities.StepCreating && process.Status == entities.StatusInProgress && process.QueuePosition > 0:
+		return fmt.Sprintf(m.DownloadQueued, process.QueuePosition)
+	case process.Status == entities.StatusSuccess && process.Expanded > 0:
+		return fmt.Sprintf(m.DownloadPlaylist, process.Expanded)
 	case process.Status == entities.StatusSuccess:
-		return n.getSuccessMessage(process)
+		return n.getSuccessMessage(m, process)
 	case process.Status == entities.StatusFailed:
-		return msgErr(process.Error)
+		return msgErr(m, process.Error)
 	default:
 		return ""
 	}
 }
 
-func (n *Notifications) getSuccessMessage(process entities.Process) string {
-	var title string
+func (n *Notifications) getSuccessMessage(m *locales.Messages, process entities.Process) string {
+	var title, links string
 	if process.Episode != nil {
-		title = process.Episode.Title
+		title = html.EscapeString(process.Episode.Title)
+		links = n.getLinks(m, process.Episode)
+	}
+	return fmt.Sprintf(m.DownloadSuccess, title) + links + getStepTimings(m, process)
+}
+
+// getLinks returns the links to the media file of the episode and to the feed,
+// or empty string if the media file is not known.
+func (n *Notifications) getLinks(m *locales.Messages, episode *entities.Episode) string {
+	if episode.MediaFile == "" {
+		return ""
+	}
+	return fmt.Sprintf(m.DownloadLinks,
+		html.EscapeString(n.cfg.MediaUrl(episode.MediaFile)),
+		html.EscapeString(n.cfg.PublicUrl.JoinPath(n.cfg.FeedFileName).String()))
+}
+
+// getStepTimings returns the durations of the process steps, or empty string if there are no timings.
+func getStepTimings(m *locales.Messages, process entities.Process) string {
+	steps := []struct {
+		step entities.Step
+		name string
+	}{
+		{entities.StepCreating, m.StepCreating},
+		{entities.StepDownloading, m.StepDownloading},
+		{entities.StepPublishing, m.StepPublishing},
+	}
+
+	var timings []string
+	for _, s := range steps {
+		if d, ok := process.StepTimings[s.step]; ok {
+			timings = append(timings, s.name+" "+d.Round(100*time.Millisecond).String())
+		}
+	}
+	if len(timings) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(m.StepTimings, strings.Join(timings, ", "))
+}
+
+// trackChatAction starts sending the chat action to the chat of the request
+// when the process starts downloading, and stops it on any other update of the process
+// or after actionTimeout, so the action is not sent forever if the update is lost.
+func (n *Notifications) trackChatAction(ctx context.Context, process entities.Process) {
+	cancel, running := n.actions[process.ID]
+	if process.Step == entities.StepDownloading && process.Status == entities.StatusInProgress {
+		if !running {
+			ctxAction, cancel := context.WithTimeout(ctx, n.actionTimeout)
+			n.actions[process.ID] = cancel
+			go n.sendChatActions(ctxAction, process.Request)
+		}
+		return
+	}
+	if running {
+		cancel()
+		delete(n.actions, process.ID)
+	}
+}
+
+// sendChatActions periodically sends the "upload voice" chat action to the chat of the request
+// until the context is canceled, so the user sees the bot is busy with the download.
+// Errors are logged and do not stop the sending.
+func (n *Notifications) sendChatActions(ctx context.Context, request entities.Request) {
+	ticker := time.NewTicker(n.actionInterval)
+	defer ticker.Stop()
+	params := &bot.SendChatActionParams{
+		ChatID: request.ChatID,
+		Action: models.ChatActionUploadVoice,
+	}
+	for {
+		if _, err := n.bot.SendChatAction(ctx, params); err != nil && ctx.Err() == nil {
+			n.log.Warn("[bot] failed to send chat action",
+				"error", err, "request", request.LogValue())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
-	return fmt.Sprintf(locales.MsgDownloadSuccess, title)
 }
 
 func (n *Notifications) replyMessage(ctx context.Context, process entities.Process, text string) {
@@ -72,6 +177,7 @@ func (n *Notifications) replyMessage(ctx context.Context, process entities.Proce
 	params := &bot.SendMessageParams{
 		ChatID:          process.Request.ChatID,
 		Text:            text,
+		ParseMode:       models.ParseModeHTML,
 		ReplyParameters: &models.ReplyParameters{MessageID: process.Request.MessageID},
 	}
 
@@ -2,12 +2,20 @@ package telegram
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/go-telegram/bot"
+	"github.com/ofstudio/voxify/internal/config"
 	"github.com/ofstudio/voxify/internal/entities"
-	"github.com/ofstudio/voxify/internal/locales"
 	"github.com/ofstudio/voxify/internal/services"
 	"github.com/stretchr/testify/suite"
 )
@@ -33,7 +41,7 @@ func (suite *TestNotificationsSuite) SetupSubTest() {
 	suite.processChannel = make(chan entities.Process, 10)
 
 	// Create notifications with nil bot since we're not testing bot methods
-	suite.notifications = NewNotifications(suite.log, nil, suite.processChannel)
+	suite.notifications = NewNotifications(config.Settings{BotLanguage: "en"}, suite.log, nil, suite.processChannel)
 }
 
 // TestGetMessage tests the getMessage method
@@ -69,7 +77,24 @@ func (suite *TestNotificationsSuite) TestGetMessage() {
 		message := suite.notifications.getMessage(process)
 
 		// Assert
-		suite.Equal(locales.MsgDownloadFailed, message)
+		suite.Equal(msgEn.DownloadFailed, message)
+	})
+
+	suite.Run("Success_NoMatchingPlatform", func() {
+		// Arrange
+		process := entities.Process{
+			Step:   entities.StepCreating,
+			Status: entities.StatusFailed,
+			Error:  fmt.Errorf("failed to create episode: %w", services.ErrNoMatchingPlatform),
+		}
+
+		// Act
+		message := suite.notifications.getMessage(process)
+
+		// Assert
+		suite.Equal(msgEn.NoMatchingPlatform, message)
+		suite.NotEqual(msgEn.DownloadFailed, message)
+		suite.NotEqual(msgEn.SomethingWentWrong, message)
 	})
 
 	suite.Run("Success_DownloadInProgress", func() {
@@ -83,7 +108,38 @@ func (suite *TestNotificationsSuite) TestGetMessage() {
 		message := suite.notifications.getMessage(process)
 
 		// Assert
-		suite.Equal(locales.MsgDownloadStarted, message)
+		suite.Equal(msgEn.DownloadStarted, message)
+	})
+
+	suite.Run("Success_Queued", func() {
+		// Arrange
+		process := entities.Process{
+			Step:          entities.StepCreating,
+			Status:        entities.StatusInProgress,
+			QueuePosition: 3,
+		}
+
+		// Act
+		message := suite.notifications.getMessage(process)
+
+		// Assert
+		suite.Equal(fmt.Sprintf(msgEn.DownloadQueued, 3), message)
+		suite.Contains(message, "position 3")
+	})
+
+	suite.Run("Success_PlaylistExpanded", func() {
+		// Arrange
+		process := entities.Process{
+			Step:     entities.StepCreating,
+			Status:   entities.StatusSuccess,
+			Expanded: 3,
+		}
+
+		// Act
+		message := suite.notifications.getMessage(process)
+
+		// Assert
+		suite.Equal(fmt.Sprintf(msgEn.DownloadPlaylist, 3), message)
 	})
 
 	suite.Run("Success_DefaultCase", func() {
@@ -99,6 +155,68 @@ func (suite *TestNotificationsSuite) TestGetMessage() {
 		// Assert
 		suite.Equal("", message) // Default case returns empty string
 	})
+
+	suite.Run("RequestLanguage", func() {
+		// Arrange
+		process := entities.Process{
+			Request: entities.Request{Lang: "ru"},
+			Step:    entities.StepDownloading,
+			Status:  entities.StatusInProgress,
+		}
+
+		// Act
+		message := suite.notifications.getMessage(process)
+
+		// Assert
+		suite.Equal("🔄 Начинаю скачивать подкаст...", message)
+	})
+
+	suite.Run("RequestLanguageError", func() {
+		// Arrange
+		process := entities.Process{
+			Request: entities.Request{Lang: "ru-RU"},
+			Step:    entities.StepDownloading,
+			Status:  entities.StatusFailed,
+			Error:   services.NewError(102, "download failed"),
+		}
+
+		// Act
+		message := suite.notifications.getMessage(process)
+
+		// Assert
+		suite.Equal("⚠️ Не удалось скачать. Возможно, медиафайл недоступен или защищён.", message)
+	})
+
+	suite.Run("UnknownLanguageFallsBackToConfigured", func() {
+		// Arrange
+		notifications := NewNotifications(config.Settings{BotLanguage: "ru"}, suite.log, nil, suite.processChannel)
+		process := entities.Process{
+			Request: entities.Request{Lang: "de"},
+			Step:    entities.StepDownloading,
+			Status:  entities.StatusInProgress,
+		}
+
+		// Act
+		message := notifications.getMessage(process)
+
+		// Assert
+		suite.Equal("🔄 Начинаю скачивать подкаст...", message)
+	})
+
+	suite.Run("UnknownLanguageFallsBackToDefault", func() {
+		// Arrange
+		process := entities.Process{
+			Request: entities.Request{Lang: "de"},
+			Step:    entities.StepDownloading,
+			Status:  entities.StatusInProgress,
+		}
+
+		// Act
+		message := suite.notifications.getMessage(process)
+
+		// Assert
+		suite.Equal(msgEn.DownloadStarted, message)
+	})
 }
 
 // TestGetSuccessMessage tests the getSuccessMessage method
@@ -112,7 +230,7 @@ func (suite *TestNotificationsSuite) TestGetSuccessMessage() {
 		}
 
 		// Act
-		message := suite.notifications.getSuccessMessage(process)
+		message := suite.notifications.getSuccessMessage(msgEn, process)
 
 		// Assert
 		expected := "✅ Podcast downloaded successfully!\n\n🎧 Amazing Podcast Episode"
@@ -126,7 +244,7 @@ func (suite *TestNotificationsSuite) TestGetSuccessMessage() {
 		}
 
 		// Act
-		message := suite.notifications.getSuccessMessage(process)
+		message := suite.notifications.getSuccessMessage(msgEn, process)
 
 		// Assert
 		expected := "✅ Podcast downloaded successfully!\n\n🎧 "
@@ -142,12 +260,55 @@ func (suite *TestNotificationsSuite) TestGetSuccessMessage() {
 		}
 
 		// Act
-		message := suite.notifications.getSuccessMessage(process)
+		message := suite.notifications.getSuccessMessage(msgEn, process)
 
 		// Assert
 		expected := "✅ Podcast downloaded successfully!\n\n🎧 "
 		suite.Equal(expected, message)
 	})
+
+	suite.Run("WithLinks", func() {
+		// Arrange
+		suite.notifications.cfg.PublicUrl = url.URL{Scheme: "https", Host: "example.com", Path: "/podcast"}
+		suite.notifications.cfg.FeedFileName = "rss.xml"
+		process := entities.Process{
+			Episode: &entities.Episode{
+				Title:     "Tom & Jerry <live>",
+				MediaFile: "episode.mp3",
+			},
+		}
+
+		// Act
+		message := suite.notifications.getSuccessMessage(msgEn, process)
+
+		// Assert
+		expected := "✅ Podcast downloaded successfully!\n\n🎧 Tom &amp; Jerry &lt;live&gt;" +
+			"\n\n🔗 <a href=\"https://example.com/podcast/episode.mp3\">Media file</a>" +
+			" · <a href=\"https://example.com/podcast/rss.xml\">RSS feed</a>"
+		suite.Equal(expected, message)
+	})
+
+	suite.Run("WithStepTimings", func() {
+		// Arrange
+		process := entities.Process{
+			Episode: &entities.Episode{
+				Title: "Amazing Podcast Episode",
+			},
+			StepTimings: map[entities.Step]time.Duration{
+				entities.StepCreating:    12 * time.Millisecond,
+				entities.StepDownloading: 62*time.Second + 340*time.Millisecond,
+				entities.StepPublishing:  3 * time.Second,
+			},
+		}
+
+		// Act
+		message := suite.notifications.getSuccessMessage(msgEn, process)
+
+		// Assert
+		expected := "✅ Podcast downloaded successfully!\n\n🎧 Amazing Podcast Episode" +
+			"\n\n⏱️ creating 0s, downloading 1m2.3s, publishing 3s"
+		suite.Equal(expected, message)
+	})
 }
 
 // TestMsgErr tests the msgErr function for different error types
@@ -157,10 +318,10 @@ func (suite *TestNotificationsSuite) TestMsgErr() {
 		testError := services.NewError(102, "download failed")
 
 		// Act
-		message := msgErr(testError)
+		message := msgErr(msgEn, testError)
 
 		// Assert
-		suite.Equal(locales.MsgDownloadFailed, message)
+		suite.Equal(msgEn.DownloadFailed, message)
 	})
 
 	suite.Run("ServiceError_EpisodeInProgress", func() {
@@ -168,10 +329,10 @@ func (suite *TestNotificationsSuite) TestMsgErr() {
 		testError := services.NewError(103, "episode in progress")
 
 		// Act
-		message := msgErr(testError)
+		message := msgErr(msgEn, testError)
 
 		// Assert
-		suite.Equal(locales.MsgEpisodeInProgress, message)
+		suite.Equal(msgEn.EpisodeInProgress, message)
 	})
 
 	suite.Run("ServiceError_UnknownCode", func() {
@@ -179,7 +340,7 @@ func (suite *TestNotificationsSuite) TestMsgErr() {
 		testError := services.NewError(999, "unknown error")
 
 		// Act
-		message := msgErr(testError)
+		message := msgErr(msgEn, testError)
 
 		// Assert
 		expected := "⚠️ Something went wrong while downloading the podcast (error 999)."
@@ -191,7 +352,7 @@ func (suite *TestNotificationsSuite) TestMsgErr() {
 		testError := services.NewError(0, "generic error")
 
 		// Act
-		message := msgErr(testError)
+		message := msgErr(msgEn, testError)
 
 		// Assert
 		suite.Equal("⚠️ Something went wrong while downloading the podcast (error 0).", message)
@@ -199,7 +360,7 @@ func (suite *TestNotificationsSuite) TestMsgErr() {
 
 	suite.Run("NilError", func() {
 		// Act
-		message := msgErr(nil)
+		message := msgErr(msgEn, nil)
 
 		// Assert
 		expected := "⚠️ Something went wrong while downloading the podcast (error 0)."
@@ -207,6 +368,122 @@ func (suite *TestNotificationsSuite) TestMsgErr() {
 	})
 }
 
+// TestChatAction tests the chat action sent during the download
+func (suite *TestNotificationsSuite) TestChatAction() {
+	downloading := entities.Process{
+		ID:      1,
+		Step:    entities.StepDownloading,
+		Status:  entities.StatusInProgress,
+		Request: entities.Request{ChatID: 123, MessageID: 456},
+	}
+	success := downloading
+	success.Step = entities.StepPublishing
+	success.Status = entities.StatusSuccess
+
+	suite.Run("SentDuringDownload", func() {
+		// Arrange
+		fake := newFakeBot(suite.T(), false)
+		ctx, cancel := context.WithCancel(suite.ctx)
+		defer cancel()
+		n := NewNotifications(config.Settings{BotLanguage: "en"}, suite.log, fake.bot, suite.processChannel)
+		n.actionInterval = 10 * time.Millisecond
+		n.Start(ctx)
+
+		// Act
+		suite.processChannel <- downloading
+		suite.Eventually(func() bool { return fake.actions.Load() >= 2 }, time.Second, 5*time.Millisecond)
+		suite.processChannel <- success
+		suite.Eventually(func() bool { return fake.messages.Load() == 2 }, time.Second, 5*time.Millisecond)
+
+		// Assert - no more actions after the download is finished
+		sent := fake.actions.Load()
+		time.Sleep(50 * time.Millisecond)
+		suite.Equal(sent, fake.actions.Load())
+		suite.Equal("123", fake.chatID.Load())
+	})
+
+	suite.Run("StoppedAfterTimeout", func() {
+		// Arrange
+		fake := newFakeBot(suite.T(), false)
+		ctx, cancel := context.WithCancel(suite.ctx)
+		defer cancel()
+		n := NewNotifications(config.Settings{BotLanguage: "en"}, suite.log, fake.bot, suite.processChannel)
+		n.actionInterval = 10 * time.Millisecond
+		n.actionTimeout = 50 * time.Millisecond
+		n.Start(ctx)
+
+		// Act - the process is never updated after the download started
+		suite.processChannel <- downloading
+		suite.Eventually(func() bool { return fake.actions.Load() >= 2 }, time.Second, 5*time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+
+		// Assert - no more actions after the timeout
+		sent := fake.actions.Load()
+		time.Sleep(50 * time.Millisecond)
+		suite.Equal(sent, fake.actions.Load())
+	})
+
+	suite.Run("SendErrorIgnored", func() {
+		// Arrange
+		fake := newFakeBot(suite.T(), true)
+		ctx, cancel := context.WithCancel(suite.ctx)
+		defer cancel()
+		n := NewNotifications(config.Settings{BotLanguage: "en"}, suite.log, fake.bot, suite.processChannel)
+		n.actionInterval = 10 * time.Millisecond
+		n.Start(ctx)
+
+		// Act
+		suite.processChannel <- downloading
+
+		// Assert - the action is retried after the failure
+		suite.Eventually(func() bool { return fake.actions.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	})
+}
+
+// fakeBot is a bot connected to a fake Telegram Bot API server
+// counting the sent chat actions and messages
+type fakeBot struct {
+	bot      *bot.Bot
+	actions  atomic.Int32
+	messages atomic.Int32
+	chatID   atomic.Value // Chat ID of the last chat action
+}
+
+// newFakeBot creates a bot with a fake Telegram Bot API server.
+// If failActions is set, the server responds to the chat actions with an error.
+func newFakeBot(t *testing.T, failActions bool) *fakeBot {
+	fake := &fakeBot{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/sendChatAction"):
+			fake.actions.Add(1)
+			_ = r.ParseMultipartForm(1 << 20)
+			fake.chatID.Store(r.FormValue("chat_id"))
+			if failActions {
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+		case strings.HasSuffix(r.URL.Path, "/sendMessage"):
+			fake.messages.Add(1)
+			_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":123,"type":"private"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":404,"description":"Not Found"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	b, err := bot.New("123:token", bot.WithServerURL(srv.URL), bot.WithSkipGetMe())
+	if err != nil {
+		t.Fatalf("failed to create bot: %v", err)
+	}
+	fake.bot = b
+	return fake
+}
+
 // Run the test suite
 func TestNotifications(t *testing.T) {
 	suite.Run(t, new(TestNotificationsSuite))
@@ -1,5 +1,10 @@
 package feedcast
 
+import (
+	_ "embed"
+	"strings"
+)
+
 // Category represents a podcast category with optional subcategories.
 //
 // Select the category that best reflects the content of your show.
@@ -145,11 +150,15 @@ package feedcast
 //	   Film Reviews
 //	   TV Reviews
 //
+// Feed validation checks categories against this list (case-sensitive).
+// Use NewRawCategory to bypass the check.
+//
 // See Apple Podcast categories:
 // https://podcasters.apple.com/support/1691-apple-podcasts-categories
 type Category struct {
 	Text          string
 	Subcategories []string
+	raw           bool // Skip validation against the taxonomy
 }
 
 // NewCategory creates a new Category with optional subcategories.
@@ -159,3 +168,63 @@ func NewCategory(text string, subcategories ...string) Category {
 		Subcategories: subcategories,
 	}
 }
+
+// NewRawCategory creates a new Category with optional subcategories
+// that is not validated against the Apple Podcasts taxonomy.
+// Use it for categories not yet known to this package.
+func NewRawCategory(text string, subcategories ...string) Category {
+	c := NewCategory(text, subcategories...)
+	c.raw = true
+	return c
+}
+
+// Validate checks that the category and its subcategories
+// are recognized by Apple Podcasts. Raw categories are always valid.
+func (c Category) Validate() error {
+	if c.raw {
+		return nil
+	}
+	return validateCategory(c.Text, c.Subcategories)
+}
+
+// validateCategory checks the category text and subcategories against the taxonomy.
+func validateCategory(text string, subcategories []string) error {
+	subs, ok := categoryTaxonomy[text]
+	if !ok {
+		return newValidationError("channel.itunes:category", "unknown itunes:category %q", text)
+	}
+	for _, sub := range subcategories {
+		if _, ok = subs[sub]; !ok {
+			return newValidationError("channel.itunes:category", "unknown itunes:category %q under %q", sub, text)
+		}
+	}
+	return nil
+}
+
+// categoriesTxt is the Apple Podcasts category taxonomy.
+// Each line is a category, subcategories are indented under their category.
+//
+//go:embed categories.txt
+var categoriesTxt string
+
+// categoryTaxonomy maps category text to the set of its subcategories.
+var categoryTaxonomy = parseTaxonomy(categoriesTxt)
+
+// parseTaxonomy parses the indented category list.
+func parseTaxonomy(txt string) map[string]map[string]struct{} {
+	taxonomy := make(map[string]map[string]struct{})
+	var current string
+	for _, line := range strings.Split(txt, "\n") {
+		text := strings.TrimSpace(line)
+		switch {
+		case text == "":
+			continue
+		case text != line && current != "": // Indented: subcategory
+			taxonomy[current][text] = struct{}{}
+		default:
+			current = text
+			taxonomy[current] = make(map[string]struct{})
+		}
+	}
+	return taxonomy
+}
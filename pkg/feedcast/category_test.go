@@ -1,6 +1,7 @@
 package feedcast
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -229,6 +230,28 @@ func TestCategoryValidationInFeed(t *testing.T) {
 			categories: []Category{},
 			shouldPass: false,
 		},
+		{
+			name: "misspelled category",
+			categories: []Category{
+				NewCategory("Techology"),
+			},
+			shouldPass: false,
+		},
+		{
+			name: "unknown subcategory",
+			categories: []Category{
+				NewCategory("Technology"),
+				NewCategory("Science", "Podcasts"),
+			},
+			shouldPass: false,
+		},
+		{
+			name: "raw category",
+			categories: []Category{
+				NewRawCategory("Techology", "Podcasts"),
+			},
+			shouldPass: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -245,19 +268,17 @@ func TestCategoryValidationInFeed(t *testing.T) {
 			feed := NewFeed(channelData)
 
 			// Add a valid item since it's required for validation
-			if tt.shouldPass {
-				itemData := ItemData{
-					Title: "Test Episode",
-					Guid:  "test-guid",
-					Enclosure: Enclosure{
-						URL:    "https://example.com/audio.mp3",
-						Length: 1024,
-						Type:   Mp3,
-					},
-				}
-				item := NewItem(itemData)
-				feed.AddItem(item)
+			itemData := ItemData{
+				Title: "Test Episode",
+				Guid:  "test-guid",
+				Enclosure: Enclosure{
+					URL:    "https://example.com/audio.mp3",
+					Length: 1024,
+					Type:   Mp3,
+				},
 			}
+			item := NewItem(itemData)
+			feed.AddItem(item)
 
 			err := feed.Validate()
 
@@ -270,6 +291,61 @@ func TestCategoryValidationInFeed(t *testing.T) {
 	}
 }
 
+// TestCategoryValidate tests Category.Validate against the Apple Podcasts taxonomy
+func TestCategoryValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		category Category
+		wantErr  string
+	}{
+		{name: "main category only", category: NewCategory("Technology")},
+		{name: "valid pair", category: NewCategory("Society & Culture", "Documentary")},
+		{name: "multiple valid subcategories", category: NewCategory("Sports", "Running", "Swimming")},
+		{name: "ampersand in subcategory", category: NewCategory("Leisure", "Home & Garden")},
+		{name: "invalid main category", category: NewCategory("Techology"), wantErr: `unknown itunes:category "Techology"`},
+		{name: "case mismatch", category: NewCategory("technology"), wantErr: `unknown itunes:category "technology"`},
+		{name: "invalid subcategory under valid main", category: NewCategory("Science", "Physics", "Podcasts"), wantErr: `unknown itunes:category "Podcasts" under "Science"`},
+		{name: "subcategory of another category", category: NewCategory("Technology", "Tech News"), wantErr: `unknown itunes:category "Tech News" under "Technology"`},
+		{name: "raw category", category: NewRawCategory("Techology", "Podcasts")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.category.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+
+			var ve *ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("Expected *ValidationError, got: %v", err)
+			}
+			if ve.Field != "channel.itunes:category" {
+				t.Errorf("Expected field 'channel.itunes:category', got '%s'", ve.Field)
+			}
+			if ve.Reason != tt.wantErr {
+				t.Errorf("Expected reason '%s', got '%s'", tt.wantErr, ve.Reason)
+			}
+		})
+	}
+}
+
+// TestCategoryTaxonomy tests that the embedded taxonomy is parsed completely
+func TestCategoryTaxonomy(t *testing.T) {
+	if len(categoryTaxonomy) != 19 {
+		t.Errorf("Expected 19 main categories, got %d", len(categoryTaxonomy))
+	}
+	if len(categoryTaxonomy["Sports"]) != 15 {
+		t.Errorf("Expected 15 Sports subcategories, got %d", len(categoryTaxonomy["Sports"]))
+	}
+	if len(categoryTaxonomy["Technology"]) != 0 {
+		t.Errorf("Expected no Technology subcategories, got %d", len(categoryTaxonomy["Technology"]))
+	}
+}
+
 // TestCategoryDocumentationCompliance ensures our categories match
 // the documented Apple Podcasts requirements
 func TestCategoryDocumentationCompliance(t *testing.T) {
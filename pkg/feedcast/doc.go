@@ -77,9 +77,12 @@
 //   - Required channel tags (title, description, image, language, explicit, categories)
 //   - Required episode tags (title, Guid, enclosure)
 //   - Proper MIME types for audio/video files
-//   - Valid category selections
+//   - Valid category selections from the Apple Podcasts taxonomy (see Category)
 //   - Correct namespace declarations and RSS structure
 //
+// Validation failures are reported as *ValidationError values carrying
+// the name of the invalid field. Use errors.As to inspect them.
+//
 // # References
 //
 // This package implements specifications from:
@@ -1,5 +1,10 @@
 package feedcast
 
+import (
+	"mime"
+	"time"
+)
+
 // ItunesType represents the type of show.
 // If your show is Serial you must use this tag.
 // Its values can be one of the following:
@@ -49,6 +54,18 @@ const (
 	CompleteYes    ItunesComplete = "Yes"
 )
 
+// DateFormat is the layout of the channel and item dates in the RFC 2822 format.
+//   - DateRFC1123Z keeps the numeric time zone offset of the date,
+//     e.g. "Mon, 02 Jan 2006 15:04:05 -0700". It is the same as time.RFC1123Z in Go.
+//   - DateRFC1123GMT converts the date to UTC with the named zone GMT,
+//     e.g. "Mon, 02 Jan 2006 15:04:05 GMT", which is preferred by some feed validators.
+type DateFormat string
+
+const (
+	DateRFC1123Z   DateFormat = time.RFC1123Z
+	DateRFC1123GMT DateFormat = "Mon, 02 Jan 2006 15:04:05 GMT"
+)
+
 // EnclosureType provides the correct category for the type of file you are using.
 // The type values for the supported file formats are:
 //   - M4a for audio/x-m4a
@@ -68,6 +85,84 @@ const (
 	Pdf EnclosureType = "application/pdf."
 )
 
+// contentTypeEnclosures maps the media types of the HTTP Content-Type header to the enclosure types.
+var contentTypeEnclosures = map[string]EnclosureType{
+	"audio/mpeg":      Mp3,
+	"audio/mp3":       Mp3,
+	"audio/mp4":       M4a,
+	"audio/m4a":       M4a,
+	"audio/x-m4a":     M4a,
+	"video/mp4":       Mp4,
+	"video/quicktime": Mov,
+	"video/x-m4v":     M4v,
+	"application/pdf": Pdf,
+}
+
+// EnclosureTypeFromContentType returns the enclosure type for the HTTP Content-Type header value,
+// e.g. Mp3 for "audio/mpeg; charset=binary". The parameters of the content type are ignored
+// and the media type is matched case-insensitively.
+// It returns false if the content type is invalid or does not match any enclosure type.
+func EnclosureTypeFromContentType(ct string) (EnclosureType, bool) {
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return "", false
+	}
+	t, ok := contentTypeEnclosures[mediaType]
+	return t, ok
+}
+
+// PodcastMedium tells podcast apps what the content of the feed is
+// and how to present it. It is encoded as the <podcast:medium> tag.
+// The medium value can be one of the following:
+//   - MediumPodcast. Describes a feed for a podcast show, the default if not set.
+//   - MediumMusic. A feed of music organized into an "album" with each item a song within the album.
+//   - MediumVideo. Like a "podcast" but used in a more visual experience.
+//   - MediumFilm. Specific types of videos with one item per feed.
+//   - MediumAudiobook. Specific types of audio with one item per feed, or where items represent chapters within the book.
+//   - MediumNewsletter. Describes a feed of curated written articles.
+//   - MediumBlog. Describes a feed of informally written articles.
+//   - MediumPublisher. Describes a feed that links to other feeds of the same publisher.
+//   - MediumCourse. A feed of training material (audio or video courses) with each item a lesson.
+//
+// Each medium has a list variant with the "L" suffix (e.g. MediumPodcastL) describing
+// a feed that links to other feeds of that medium, and MediumMixed describes
+// a list of feeds of different mediums.
+//
+// See https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/tags/medium.md
+type PodcastMedium string
+
+const (
+	MediumNotSet     PodcastMedium = ""
+	MediumPodcast    PodcastMedium = "podcast"
+	MediumMusic      PodcastMedium = "music"
+	MediumVideo      PodcastMedium = "video"
+	MediumFilm       PodcastMedium = "film"
+	MediumAudiobook  PodcastMedium = "audiobook"
+	MediumNewsletter PodcastMedium = "newsletter"
+	MediumBlog       PodcastMedium = "blog"
+	MediumPublisher  PodcastMedium = "publisher"
+	MediumCourse     PodcastMedium = "course"
+
+	MediumPodcastL    PodcastMedium = "podcastL"
+	MediumMusicL      PodcastMedium = "musicL"
+	MediumVideoL      PodcastMedium = "videoL"
+	MediumFilmL       PodcastMedium = "filmL"
+	MediumAudiobookL  PodcastMedium = "audiobookL"
+	MediumNewsletterL PodcastMedium = "newsletterL"
+	MediumBlogL       PodcastMedium = "blogL"
+	MediumPublisherL  PodcastMedium = "publisherL"
+	MediumCourseL     PodcastMedium = "courseL"
+	MediumMixed       PodcastMedium = "mixed"
+)
+
+// podcastMediums are the allowed values of the <podcast:medium> tag.
+var podcastMediums = map[PodcastMedium]bool{
+	MediumPodcast: true, MediumMusic: true, MediumVideo: true, MediumFilm: true, MediumAudiobook: true,
+	MediumNewsletter: true, MediumBlog: true, MediumPublisher: true, MediumCourse: true,
+	MediumPodcastL: true, MediumMusicL: true, MediumVideoL: true, MediumFilmL: true, MediumAudiobookL: true,
+	MediumNewsletterL: true, MediumBlogL: true, MediumPublisherL: true, MediumCourseL: true, MediumMixed: true,
+}
+
 // Explicit is the parental advisory information.
 // The explicit value can be one of the following:
 //   - ExplicitTrue. If you specify true, indicating the presence of explicit content,
@@ -84,6 +179,14 @@ const (
 	ExplicitFalse  Explicit = "false"
 )
 
+// ExplicitOf returns ExplicitTrue if explicit is true and ExplicitFalse otherwise.
+func ExplicitOf(explicit bool) Explicit {
+	if explicit {
+		return ExplicitTrue
+	}
+	return ExplicitFalse
+}
+
 // ItunesEpisodeType is the episode type. If an episode is a trailer or bonus content, use this tag.
 // Where the episodeType value can be one of the following:
 //   - EpisodeFull. Specify full when you are submitting the complete content of your show.
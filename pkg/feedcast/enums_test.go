@@ -24,6 +24,15 @@ func TestExplicitEnums(t *testing.T) {
 	}
 }
 
+func TestExplicitOf(t *testing.T) {
+	if got := ExplicitOf(true); got != ExplicitTrue {
+		t.Errorf("Expected %q for true, got %q", ExplicitTrue, got)
+	}
+	if got := ExplicitOf(false); got != ExplicitFalse {
+		t.Errorf("Expected %q for false, got %q", ExplicitFalse, got)
+	}
+}
+
 func TestItunesTypeEnums(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -105,6 +114,38 @@ func TestEnclosureTypeEnums(t *testing.T) {
 	}
 }
 
+func TestEnclosureTypeFromContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		expected    EnclosureType
+		ok          bool
+	}{
+		{"MP3", "audio/mpeg", Mp3, true},
+		{"MP3 alias", "audio/mp3", Mp3, true},
+		{"M4A", "audio/mp4", M4a, true},
+		{"M4A legacy", "audio/x-m4a", M4a, true},
+		{"MP4", "video/mp4", Mp4, true},
+		{"MOV", "video/quicktime", Mov, true},
+		{"M4V", "video/x-m4v", M4v, true},
+		{"PDF", "application/pdf", Pdf, true},
+		{"with parameters", "audio/mpeg; charset=binary", Mp3, true},
+		{"upper case", "Audio/MPEG", Mp3, true},
+		{"unknown", "audio/ogg", "", false},
+		{"empty", "", "", false},
+		{"invalid", "audio/mpeg; =", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := EnclosureTypeFromContentType(tt.contentType)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tt.expected, tt.ok, got, ok)
+			}
+		})
+	}
+}
+
 func TestItunesEpisodeTypeEnums(t *testing.T) {
 	tests := []struct {
 		name     string
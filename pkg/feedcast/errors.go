@@ -0,0 +1,29 @@
+package feedcast
+
+import "fmt"
+
+// ValidationError is returned by Validate methods when a feed or an item
+// does not meet Apple Podcasts requirements.
+// Use errors.As to extract the invalid field:
+//
+//	var ve *feedcast.ValidationError
+//	if errors.As(err, &ve) {
+//		fmt.Println(ve.Field) // e.g. "channel.title"
+//	}
+type ValidationError struct {
+	// Field is the path of the invalid element,
+	// e.g. "rss.version", "channel.title" or "item.enclosure.url".
+	Field string
+	// Reason is a human-readable description of the problem.
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return e.Reason
+}
+
+// newValidationError creates a new ValidationError for the given field.
+func newValidationError(field, format string, args ...any) *ValidationError {
+	return &ValidationError{Field: field, Reason: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,119 @@
+package feedcast
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestValidationError(t *testing.T) {
+	err := &ValidationError{Field: "channel.title", Reason: "channel title is required"}
+	if err.Error() != "channel title is required" {
+		t.Errorf("Expected error message to be the reason, got '%s'", err.Error())
+	}
+}
+
+func TestValidationErrorAs(t *testing.T) {
+	validItem := func() *Item {
+		return NewItem(ItemData{
+			Title:     "Test Episode",
+			Guid:      "test-guid",
+			Enclosure: NewEnclosure("https://example.com/audio.mp3", 1024, Mp3),
+		})
+	}
+	validFeed := func() *Feed {
+		return NewFeed(FeedData{
+			Title:       "Test Podcast",
+			Description: "Test description",
+			Image:       "https://example.com/image.jpg",
+			Language:    "en",
+			Explicit:    ExplicitFalse,
+			Categories:  []Category{NewCategory("Technology")},
+		})
+	}
+
+	tests := []struct {
+		name          string
+		validate      func() error
+		expectedField string
+	}{
+		{
+			name: "feed missing title",
+			validate: func() error {
+				f := validFeed()
+				f.xmlDoc.Channel.Title = ""
+				f.AddItem(validItem())
+				return f.Validate()
+			},
+			expectedField: "channel.title",
+		},
+		{
+			name: "feed missing language",
+			validate: func() error {
+				f := validFeed()
+				f.xmlDoc.Channel.Language = ""
+				f.AddItem(validItem())
+				return f.Validate()
+			},
+			expectedField: "channel.language",
+		},
+		{
+			name: "feed version mismatch",
+			validate: func() error {
+				f := validFeed()
+				f.xmlDoc.Version = "1.0"
+				f.AddItem(validItem())
+				return f.Validate()
+			},
+			expectedField: "rss.version",
+		},
+		{
+			name: "feed without items",
+			validate: func() error {
+				return validFeed().Validate()
+			},
+			expectedField: "channel.item",
+		},
+		{
+			name: "invalid nested item",
+			validate: func() error {
+				f := validFeed()
+				f.AddItem(validItem().WithEnclosure(NewEnclosure("https://example.com/audio.mp3", 0, Mp3)))
+				return f.Validate()
+			},
+			expectedField: "item.enclosure.length",
+		},
+		{
+			name: "item missing guid",
+			validate: func() error {
+				i := validItem()
+				i.xmlItem.Guid = ""
+				return i.Validate()
+			},
+			expectedField: "item.guid",
+		},
+		{
+			name: "encode wraps validation error",
+			validate: func() error {
+				return validFeed().Encode(&bytes.Buffer{})
+			},
+			expectedField: "channel.item",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate()
+			if err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			var ve *ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if ve.Field != tt.expectedField {
+				t.Errorf("Expected field '%s', got '%s'", tt.expectedField, ve.Field)
+			}
+		})
+	}
+}
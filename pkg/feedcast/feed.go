@@ -1,9 +1,16 @@
 package feedcast
 
 import (
+	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -11,6 +18,20 @@ import (
 // It contains the channel information and a list of items (episodes).
 type Feed struct {
 	xmlDoc
+
+	// UseCDATA controls how descriptions and summaries are encoded.
+	// If true (default), they are wrapped in CDATA sections.
+	// If false, they are encoded as entity-escaped text,
+	// which is preferred by some feed validators.
+	UseCDATA bool
+
+	// DateFormat is the layout of the channel and item dates.
+	// DateRFC1123Z is used by default. See DateFormat for possible values.
+	DateFormat DateFormat
+
+	// mu guards the items of the feed, so that items can be added
+	// from multiple goroutines. Other setters are not synchronized.
+	mu sync.Mutex
 }
 
 // NewFeed creates a new Feed instance with the provided channel data and categories.
@@ -18,14 +39,7 @@ type Feed struct {
 func NewFeed(channel FeedData) *Feed {
 	cat := make([]xmlItunesCategory, len(channel.Categories))
 	for i, c := range channel.Categories {
-		sub := make([]xmlItunesCategory, len(c.Subcategories))
-		for j, s := range c.Subcategories {
-			sub[j] = xmlItunesCategory{Text: s}
-		}
-		cat[i] = xmlItunesCategory{
-			Text:             c.Text,
-			ItunesCategories: sub,
-		}
+		cat[i] = newXmlItunesCategory(c)
 	}
 
 	return &Feed{
@@ -34,6 +48,7 @@ func NewFeed(channel FeedData) *Feed {
 			ItunesNS:  xmlItunesNS,
 			ContentNS: xmlContentNS,
 			PodcastNS: xmlPodcastNS,
+			AtomNS:    xmlAtomNS,
 			Channel: xmlChannel{
 				Title:          channel.Title,
 				Description:    xmlCDATA{Data: channel.Description},
@@ -44,6 +59,8 @@ func NewFeed(channel FeedData) *Feed {
 				ItunesCategory: cat,
 			},
 		},
+		UseCDATA:   true,
+		DateFormat: DateRFC1123Z,
 	}
 }
 
@@ -55,15 +72,97 @@ func (f *Feed) Validate() error {
 	return f.xmlDoc.validate()
 }
 
+// ValidateWithWarnings checks the feed as Validate does and additionally returns
+// the recommended tags missing from the feed: <itunes:author>, <link> and <itunes:owner>
+// of the channel, <pubDate>, <description> and <itunes:duration> of the items.
+// It also warns about <itunes:episode> and <itunes:season> numbering confusing the episode order:
+// seasons without episode numbers in serial shows, episode numbers without a season
+// in shows with seasons, and episode numbers of a season that are not sequential.
+// Warnings do not prevent the feed from being encoded, but may degrade its discoverability.
+func (f *Feed) ValidateWithWarnings() (warnings []string, err error) {
+	return f.xmlDoc.Channel.warnings(), f.Validate()
+}
+
+// ValidateStrict checks the feed as Validate does and additionally checks that the artwork is reachable:
+// a HEAD request to the <itunes:image> URL must succeed and return an image content type.
+// The request is made with the client, http.DefaultClient if nil.
+// Unlike Validate, it makes network requests, so use it when the artwork host is expected to be online.
+func (f *Feed) ValidateStrict(ctx context.Context, client *http.Client) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	return checkArtwork(ctx, client, f.xmlDoc.Channel.ItunesImage.Href)
+}
+
+// checkArtwork makes a HEAD request to the artwork URL and checks the response status and content type.
+func checkArtwork(ctx context.Context, client *http.Client, href string) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, href, nil)
+	if err != nil {
+		return newValidationError("channel.itunes:image", "invalid artwork url %q: %v", href, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return newValidationError("channel.itunes:image", "artwork %q is not reachable: %v", href, err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newValidationError("channel.itunes:image", "artwork %q is not reachable: %s", href, resp.Status)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if !strings.HasPrefix(mediaType, "image/") {
+		return newValidationError("channel.itunes:image",
+			"artwork %q must be an image, got content type %q", href, resp.Header.Get("Content-Type"))
+	}
+	return nil
+}
+
 // AddItem adds an episode item to the feed.
+// It is safe to call AddItem from multiple goroutines.
 func (f *Feed) AddItem(item *Item) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	f.xmlDoc.Channel.Items = append(f.xmlDoc.Channel.Items, item.xmlItem)
 }
 
+// RemoveItem removes the items with the given guid from the feed.
+// It returns false if no item has the guid.
+// It is safe to call RemoveItem from multiple goroutines.
+func (f *Feed) RemoveItem(guid string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := len(f.xmlDoc.Channel.Items)
+	f.xmlDoc.Channel.Items = slices.DeleteFunc(f.xmlDoc.Channel.Items, func(item xmlItem) bool {
+		return item.Guid == guid
+	})
+	return len(f.xmlDoc.Channel.Items) < n
+}
+
+// Items returns the copies of the feed items in the order they were added.
+// Changes to the returned items do not affect the feed.
+// It is safe to call Items from multiple goroutines.
+func (f *Feed) Items() []*Item {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	items := make([]*Item, len(f.xmlDoc.Channel.Items))
+	for i, item := range f.xmlDoc.Channel.Items {
+		items[i] = &Item{xmlItem: item}
+	}
+	return items
+}
+
+// Encode validates the feed and writes it to w as RSS XML.
+// It is safe to call Encode concurrently with AddItem and RemoveItem.
 func (f *Feed) Encode(w io.Writer) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	if err := f.Validate(); err != nil {
 		return fmt.Errorf("feed validation failed: %w", err)
 	}
+	f.xmlDoc.setCDATA(f.UseCDATA)
+	f.xmlDoc.setDateFormat(f.DateFormat)
 	if _, err := w.Write([]byte(xml.Header)); err != nil {
 		return fmt.Errorf("failed to write xml header: %w", err)
 	}
@@ -75,6 +174,35 @@ func (f *Feed) Encode(w io.Writer) error {
 	return nil
 }
 
+// DropInvalidItems removes the items that fail validation from the feed,
+// so that a single invalid item does not prevent the rest of the feed from being published.
+// It returns the joined errors of the removed items, or nil if all items are valid.
+// The errors keep the index of the item in the feed before removal.
+func (f *Feed) DropInvalidItems() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var errs []error
+	items := f.xmlDoc.Channel.Items[:0]
+	for i, item := range f.xmlDoc.Channel.Items {
+		if err := item.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid item %d: %w", i, err))
+			continue
+		}
+		items = append(items, item)
+	}
+	f.xmlDoc.Channel.Items = items
+	return errors.Join(errs...)
+}
+
+// EncodeLenient writes the feed to w as Encode does, but drops the invalid items
+// from the feed instead of failing. The remaining feed must still be valid.
+// It returns the joined errors of the dropped items as skipped for logging,
+// and err if the remaining feed is invalid or can not be written.
+func (f *Feed) EncodeLenient(w io.Writer) (skipped error, err error) {
+	skipped = f.DropInvalidItems()
+	return skipped, f.Encode(w)
+}
+
 // WithAuthor sets the <itunes:author> tag of the feed.
 // Author is the group responsible for creating the show.
 //
@@ -90,6 +218,7 @@ func (f *Feed) WithAuthor(name string) *Feed {
 // WithLink sets the <link> tag of the feed of the website associated with a podcast. Use the full URL.
 //
 // Typically, a home page for a podcast or a dedicated portion of a larger website.
+// Validation fails if the link is not an absolute http(s) URL.
 func (f *Feed) WithLink(link string) *Feed {
 	f.xmlDoc.Channel.Link = link
 	return f
@@ -100,9 +229,14 @@ func (f *Feed) WithLink(link string) *Feed {
 //
 // The value for this tag is typically the publication date of the most recent episode in the feed.
 //
-// The date should be in RFC 2822 format (e.g., "Sat, 01 Apr 2023 19:00:00 GMT")
-// which is the same as time.RFC1123Z in Go.
+// The date is encoded in RFC 2822 format (e.g., "Sat, 01 Apr 2023 19:00:00 GMT")
+// according to the DateFormat of the feed.
+// The tag is omitted if the date is zero, i.e. unknown.
 func (f *Feed) WithPubDate(pubDate time.Time) *Feed {
+	if pubDate.IsZero() {
+		f.xmlDoc.Channel.PubDate = ""
+		return f
+	}
 	f.xmlDoc.Channel.PubDate = pubDate.Format(time.RFC1123Z)
 	return f
 }
@@ -110,8 +244,8 @@ func (f *Feed) WithPubDate(pubDate time.Time) *Feed {
 // WithLastBuildDate sets the <lastBuildDate> tag of the feed.
 // It indicates the last time the content of the feed was modified.
 //
-// The date should be in RFC 2822 format (e.g., "Sat, 01 Apr 2023 19:00:00 GMT")
-// which is the same as time.RFC1123Z in Go.
+// The date is encoded in RFC 2822 format (e.g., "Sat, 01 Apr 2023 19:00:00 GMT")
+// according to the DateFormat of the feed.
 func (f *Feed) WithLastBuildDate(lastBuildDate time.Time) *Feed {
 	f.xmlDoc.Channel.LastBuildDate = lastBuildDate.Format(time.RFC1123Z)
 	return f
@@ -127,6 +261,22 @@ func (f *Feed) WithItunesTitle(title string) *Feed {
 	return f
 }
 
+// WithItunesImage sets the <itunes:image> tag containing the show artwork URL.
+// It replaces the artwork provided in FeedData.Image.
+// See FeedData.Image for the artwork requirements.
+func (f *Feed) WithItunesImage(href string) *Feed {
+	f.xmlDoc.Channel.ItunesImage.Href = href
+	return f
+}
+
+// WithItunesExplicit sets the <itunes:explicit> tag containing parental advisory information of the show.
+// It replaces the value provided in FeedData.Explicit.
+// See Explicit for supported values.
+func (f *Feed) WithItunesExplicit(explicit Explicit) *Feed {
+	f.xmlDoc.Channel.ItunesExplicit = explicit
+	return f
+}
+
 // WithItunesType sets the <itunes:type> tag of type of show.
 // If your show is Serial you must use this tag.
 // See ItunesType for possible values.
@@ -152,11 +302,21 @@ func (f *Feed) WithCopyright(copyright string) *Feed {
 //
 // Note: The <itunes:new-feed-url> tag reports new feed URLs
 // to Apple Podcasts and isn’t displayed in Apple Podcasts.
+//
+// While the feed is being migrated, the self link set by WithSelfLink
+// must still point to the current feed URL: validation fails if both URLs match.
 func (f *Feed) WithItunesNewFeedURL(newFeedURL string) *Feed {
 	f.xmlDoc.Channel.ItunesNewFeedURL = newFeedURL
 	return f
 }
 
+// WithCategory appends the category to the <itunes:category> tags of the feed.
+// Categories set via FeedData.Categories are kept.
+func (f *Feed) WithCategory(c Category) *Feed {
+	f.xmlDoc.Channel.ItunesCategory = append(f.xmlDoc.Channel.ItunesCategory, newXmlItunesCategory(c))
+	return f
+}
+
 // WithItunesBlock sets the <itunes:block> tag of the feed.
 // Use this tag if you want to block your podcast from appearing in Apple Podcasts.
 // See ItunesBlock for possible values.
@@ -167,6 +327,7 @@ func (f *Feed) WithItunesBlock(block ItunesBlock) *Feed {
 
 // WithItunesComplete sets the <itunes:complete> tag of the feed.
 // Use this tag if your podcast is complete and no new episodes will be added.
+// Validation of a complete feed fails if any item is published in the future.
 // See ItunesComplete for possible values.
 func (f *Feed) WithItunesComplete(complete ItunesComplete) *Feed {
 	f.xmlDoc.Channel.ItunesComplete = complete
@@ -207,6 +368,100 @@ func (f *Feed) WithItunesOwner(name, email string) *Feed {
 	return f
 }
 
+// WithImage sets the standard RSS <image> tag of the feed.
+// Apple Podcasts uses <itunes:image> (see FeedData.Image and WithItunesImage),
+// but some legacy feed readers display only the <image> tag.
+// All arguments are required: url of the image, title and link of the website
+// (typically the same as the feed title and link).
+func (f *Feed) WithImage(url, title, link string) *Feed {
+	f.xmlDoc.Channel.Image = &xmlImage{Url: url, Title: title, Link: link}
+	return f
+}
+
+// WithManagingEditor sets the <managingEditor> tag of the feed
+// containing the email address of the person responsible for the editorial content.
+// The tag is encoded as "email (name)". The name is optional.
+// The email must be a bare address (e.g. "editor@example.com"), otherwise the feed fails validation.
+func (f *Feed) WithManagingEditor(email, name string) *Feed {
+	f.xmlDoc.Channel.ManagingEditor = &xmlContact{Email: email, Name: name}
+	return f
+}
+
+// WithWebMaster sets the <webMaster> tag of the feed
+// containing the email address of the person responsible for technical issues with the feed.
+// The tag is encoded as "email (name)". The name is optional.
+// The email must be a bare address (e.g. "webmaster@example.com"), otherwise the feed fails validation.
+func (f *Feed) WithWebMaster(email, name string) *Feed {
+	f.xmlDoc.Channel.WebMaster = &xmlContact{Email: email, Name: name}
+	return f
+}
+
+// WithPodcastValue sets the <podcast:value> tag of the feed
+// listing the recipients of the payments sent by podcast apps (Value4Value),
+// e.g. streaming sats over the Lightning network while listening:
+//
+//	<podcast:value type="lightning" method="keysend" suggested="0.00000005000">
+//	    <podcast:valueRecipient name="Alice (Podcaster)" type="node" address="02d5c1bf..." split="40" />
+//	    <podcast:valueRecipient name="Bob (Producer)" type="node" address="032f4ffb..." split="10" />
+//	</podcast:value>
+//
+// The valueType is the service the payments are sent over (e.g. "lightning"),
+// and the method is the transport of the payments (e.g. "keysend").
+// The suggested amount per payment is optional and omitted if zero.
+// At least one recipient is required, and the split of each recipient
+// must be a positive integer, otherwise the feed fails validation.
+// See ValueRecipient for details.
+//
+// See https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/tags/value.md
+func (f *Feed) WithPodcastValue(valueType, method string, suggested float64, recipients []ValueRecipient) *Feed {
+	f.xmlDoc.Channel.PodcastValue = newXmlPodcastValue(valueType, method, suggested, recipients)
+	return f
+}
+
+// WithPodcastMedium sets the <podcast:medium> tag of the feed
+// telling podcast apps what the content of the feed is, e.g. MediumAudiobook or MediumMusic.
+// Podcast apps assume MediumPodcast if the tag is not set.
+// See PodcastMedium for supported values: validation fails for unknown mediums.
+//
+// See https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/tags/medium.md
+func (f *Feed) WithPodcastMedium(medium PodcastMedium) *Feed {
+	f.xmlDoc.Channel.PodcastMedium = medium
+	return f
+}
+
+// WithHub sets the <atom:link rel="hub"> tag of the feed.
+// It advertises a WebSub (formerly PubSubHubbub) hub that clients can subscribe to
+// in order to receive near-instant notifications when the feed is updated.
+// The publisher is expected to notify the hub each time the feed changes.
+// Calling WithHub again replaces the previously set hub.
+//
+// See https://www.w3.org/TR/websub/
+func (f *Feed) WithHub(hubURL string) *Feed {
+	f.setAtomLink(xmlAtomLink{Rel: "hub", Href: hubURL})
+	return f
+}
+
+// WithSelfLink sets the <atom:link rel="self"> tag of the feed.
+// It contains the URL of the feed itself, which is recommended by RSS validators
+// and required by WebSub subscribers to identify the feed.
+// Calling WithSelfLink again replaces the previously set link.
+func (f *Feed) WithSelfLink(feedURL string) *Feed {
+	f.setAtomLink(xmlAtomLink{Rel: "self", Href: feedURL, Type: "application/rss+xml"})
+	return f
+}
+
+// setAtomLink adds the <atom:link> tag to the feed
+// replacing the existing link with the same rel attribute.
+func (f *Feed) setAtomLink(link xmlAtomLink) {
+	for i, l := range f.xmlDoc.Channel.AtomLinks {
+		if l.Rel == link.Rel {
+			f.xmlDoc.Channel.AtomLinks[i] = link
+			return
+		}
+	}
+	f.xmlDoc.Channel.AtomLinks = append(f.xmlDoc.Channel.AtomLinks, link)
+}
+
 // FeedData holds the data for the <channel> element in the RSS feed.
 // It includes the minimal set of required tags as per Apple Podcasts specifications.
 type FeedData struct {
@@ -233,6 +488,7 @@ type FeedData struct {
 	// in JPEG or PNG format, 72 dpi, with appropriate file extensions (.jpg, .png), and in the RGB colorspace.
 	// Confirm your art does not contain an Alpha Channel.
 	// These requirements are different from the standard RSS image tag specifications.
+	// ValidateWithWarnings warns if the URL path does not end in .jpg, .jpeg or .png.
 	Image string
 
 	// The language spoken on the show.
@@ -259,3 +515,9 @@ type FeedData struct {
 	// See Category for possible values.
 	Categories []Category
 }
+
+// SetExplicit sets the parental advisory from a boolean:
+// ExplicitTrue if explicit is true and ExplicitFalse otherwise.
+func (d *FeedData) SetExplicit(explicit bool) {
+	d.Explicit = ExplicitOf(explicit)
+}
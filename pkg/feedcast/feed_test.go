@@ -2,12 +2,37 @@ package feedcast
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
 
+// testFeedData returns the channel data of a valid feed shared by the tests.
+func testFeedData() FeedData {
+	return FeedData{
+		Title:       "Test Podcast",
+		Description: "A test podcast description",
+		Image:       "https://example.com/artwork.jpg",
+		Language:    "en",
+		Explicit:    ExplicitFalse,
+		Categories:  []Category{NewCategory("Technology")},
+	}
+}
+
+// newTestFeed returns a new feed with testFeedData.
+func newTestFeed() *Feed {
+	return NewFeed(testFeedData())
+}
+
 func TestNewFeed(t *testing.T) {
 	channelData := FeedData{
 		Title:       "Test Podcast",
@@ -127,7 +152,7 @@ func TestFeedValidation_RequiredFields(t *testing.T) {
 				Categories:  []Category{NewCategory("Technology")},
 			},
 			expectError: true,
-			errorMsg:    "channel itunes:image is required",
+			errorMsg:    "channel itunes:image is required: artwork must be a JPEG or PNG image from 1400x1400 to 3000x3000 pixels",
 		},
 		{
 			name: "missing language",
@@ -191,14 +216,7 @@ func TestFeedValidation_RequiredFields(t *testing.T) {
 }
 
 func TestFeedWithOptionalFields(t *testing.T) {
-	channelData := FeedData{
-		Title:       "Test Podcast",
-		Description: "A test podcast description",
-		Image:       "https://example.com/artwork.jpg",
-		Language:    "en",
-		Explicit:    ExplicitFalse,
-		Categories:  []Category{NewCategory("Technology")},
-	}
+	channelData := testFeedData()
 
 	feed := NewFeed(channelData)
 
@@ -310,14 +328,7 @@ func TestFeedWithOptionalFields(t *testing.T) {
 
 func TestFeedCategories(t *testing.T) {
 	// Test single category
-	channelData := FeedData{
-		Title:       "Test Podcast",
-		Description: "A test podcast description",
-		Image:       "https://example.com/artwork.jpg",
-		Language:    "en",
-		Explicit:    ExplicitFalse,
-		Categories:  []Category{NewCategory("Technology")},
-	}
+	channelData := testFeedData()
 
 	feed := NewFeed(channelData)
 	if len(feed.xmlDoc.Channel.ItunesCategory) != 1 {
@@ -377,15 +388,103 @@ func TestFeedCategories(t *testing.T) {
 	}
 }
 
-func TestFeedAddItem(t *testing.T) {
-	channelData := FeedData{
-		Title:       "Test Podcast",
-		Description: "A test podcast description",
-		Image:       "https://example.com/artwork.jpg",
-		Language:    "en",
-		Explicit:    ExplicitFalse,
-		Categories:  []Category{NewCategory("Technology")},
+func TestFeedWithCategory(t *testing.T) {
+	feed := newTestFeed()
+	feed.WithCategory(NewCategory("Society & Culture", "Documentary"))
+	feed.AddItem(NewItem(ItemData{
+		Title: "Test Episode",
+		Guid:  "test-episode-1",
+		Enclosure: Enclosure{
+			URL:    "https://example.com/episode1.mp3",
+			Length: 1024,
+			Type:   Mp3,
+		},
+	}))
+
+	if len(feed.xmlDoc.Channel.ItunesCategory) != 2 {
+		t.Fatalf("Expected 2 categories, got %d", len(feed.xmlDoc.Channel.ItunesCategory))
+	}
+
+	var buf bytes.Buffer
+	if err := feed.Encode(&buf); err != nil {
+		t.Fatalf("Failed to encode feed: %v", err)
+	}
+	xmlContent := buf.String()
+
+	if !strings.Contains(xmlContent, `<itunes:category text="Technology"></itunes:category>`) {
+		t.Errorf("XML should contain the category set at construction, got:\n%s", xmlContent)
+	}
+	if !strings.Contains(xmlContent, `<itunes:category text="Society &amp; Culture">`) {
+		t.Errorf("XML should contain the appended category, got:\n%s", xmlContent)
+	}
+	if !strings.Contains(xmlContent, `<itunes:category text="Documentary"></itunes:category>`) {
+		t.Errorf("XML should contain the subcategory of the appended category, got:\n%s", xmlContent)
 	}
+}
+
+func TestFeedWithItunesExplicit(t *testing.T) {
+	newFeed := func() *Feed {
+		feed := newTestFeed()
+		feed.AddItem(NewItem(ItemData{
+			Title:     "Test Episode",
+			Guid:      "test-episode-1",
+			Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+		}))
+		return feed
+	}
+
+	t.Run("replaced", func(t *testing.T) {
+		feed := newFeed().WithItunesExplicit(ExplicitTrue)
+
+		var buf bytes.Buffer
+		if err := feed.Encode(&buf); err != nil {
+			t.Fatalf("Failed to encode feed: %v", err)
+		}
+		xmlContent := buf.String()
+
+		if !strings.Contains(xmlContent, "<itunes:explicit>true</itunes:explicit>") {
+			t.Errorf("XML should contain the new explicit value, got:\n%s", xmlContent)
+		}
+		if strings.Contains(xmlContent, "<itunes:explicit>false</itunes:explicit>") {
+			t.Errorf("XML should not contain the explicit value set at construction, got:\n%s", xmlContent)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := newFeed().WithItunesExplicit("yes").Validate()
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Field != "channel.itunes:explicit" {
+			t.Errorf("Expected channel.itunes:explicit validation error, got: %v", err)
+		}
+	})
+}
+
+func TestFeedDataSetExplicit(t *testing.T) {
+	tests := []struct {
+		name     string
+		explicit bool
+		expected Explicit
+	}{
+		{"true", true, ExplicitTrue},
+		{"false", false, ExplicitFalse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := FeedData{Explicit: ExplicitNotSet}
+			data.SetExplicit(tt.explicit)
+			if data.Explicit != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, data.Explicit)
+			}
+			if got := NewFeed(data).xmlDoc.Channel.ItunesExplicit; got != tt.expected {
+				t.Errorf("Expected channel itunes:explicit %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFeedAddItem(t *testing.T) {
+	channelData := testFeedData()
 
 	feed := NewFeed(channelData)
 
@@ -422,16 +521,73 @@ func TestFeedAddItem(t *testing.T) {
 	}
 }
 
-func TestFeedXMLGeneration(t *testing.T) {
-	channelData := FeedData{
-		Title:       "Test Podcast",
-		Description: "A test podcast description with <special> characters & ampersands",
-		Image:       "https://example.com/artwork.jpg",
-		Language:    "en",
-		Explicit:    ExplicitFalse,
-		Categories:  []Category{NewCategory("Technology")},
+func TestFeedAddItemConcurrent(t *testing.T) {
+	feed := newTestFeed()
+
+	const workers, perWorker = 8, 100
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				guid := fmt.Sprintf("episode-%d-%d", w, i)
+				feed.AddItem(NewItem(ItemData{
+					Title:     guid,
+					Guid:      guid,
+					Enclosure: NewEnclosure("https://example.com/"+guid+".mp3", 1024, Mp3),
+				}))
+			}
+		}()
+	}
+	// Encode concurrently with adding items
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			_ = feed.Encode(io.Discard)
+		}
+	}()
+	wg.Wait()
+
+	if got := len(feed.Items()); got != workers*perWorker {
+		t.Errorf("Expected %d items, got %d", workers*perWorker, got)
+	}
+}
+
+func TestFeedRemoveItem(t *testing.T) {
+	feed := newTestFeed()
+	for _, guid := range []string{"episode-1", "episode-2", "episode-3"} {
+		feed.AddItem(NewItem(ItemData{
+			Title:     guid,
+			Guid:      guid,
+			Enclosure: NewEnclosure("https://example.com/"+guid+".mp3", 1024, Mp3),
+		}))
+	}
+
+	if !feed.RemoveItem("episode-2") {
+		t.Error("Expected existing item to be removed")
+	}
+	if feed.RemoveItem("episode-42") {
+		t.Error("Expected no item to be removed for unknown guid")
 	}
 
+	items := feed.Items()
+	if len(items) != 2 || items[0].Guid != "episode-1" || items[1].Guid != "episode-3" {
+		t.Errorf("Unexpected items after removal: %+v", items)
+	}
+
+	// Returned items are copies
+	items[0].Title = "Changed"
+	if feed.Channel.Items[0].Title != "episode-1" {
+		t.Error("Changing the returned item must not affect the feed")
+	}
+}
+
+func TestFeedXMLGeneration(t *testing.T) {
+	channelData := testFeedData()
+	channelData.Description = "A test podcast description with <special> characters & ampersands"
+
 	feed := NewFeed(channelData)
 
 	// Add a valid item to pass validation
@@ -496,16 +652,715 @@ func TestFeedXMLGeneration(t *testing.T) {
 	}
 }
 
+func TestFeedWithHub(t *testing.T) {
+	feed := newTestFeed()
+	feed.AddItem(NewItem(ItemData{
+		Title:     "Test Episode",
+		Guid:      "test-episode-1",
+		Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+	}))
+
+	result := feed.WithHub("https://old-hub.example.com/").WithHub("https://pubsubhubbub.appspot.com/")
+	if result != feed {
+		t.Error("WithHub should return the same feed for chaining")
+	}
+	if len(feed.xmlDoc.Channel.AtomLinks) != 1 {
+		t.Fatalf("Expected exactly one atom link, got %d", len(feed.xmlDoc.Channel.AtomLinks))
+	}
+
+	var buf bytes.Buffer
+	if err := feed.Encode(&buf); err != nil {
+		t.Fatalf("Failed to encode feed: %v", err)
+	}
+	xmlContent := buf.String()
+
+	if !strings.Contains(xmlContent, `xmlns:atom="http://www.w3.org/2005/Atom"`) {
+		t.Error("Atom namespace should be present")
+	}
+	if !strings.Contains(xmlContent, `<atom:link rel="hub" href="https://pubsubhubbub.appspot.com/"></atom:link>`) {
+		t.Errorf("Hub link should be present, got: %s", xmlContent)
+	}
+	if strings.Contains(xmlContent, "old-hub") {
+		t.Error("Previous hub link should be replaced")
+	}
+}
+
+func TestFeedWithPubDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pubDate time.Time
+		want    string
+	}{
+		{"real", time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC), "<pubDate>Mon, 15 Jan 2024 10:30:00 +0000</pubDate>"},
+		{"zero", time.Time{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := newTestFeed().WithPubDate(time.Now()).WithPubDate(tt.pubDate)
+			feed.AddItem(NewItem(ItemData{
+				Title:     "Test Episode",
+				Guid:      "test-episode-1",
+				Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+			}))
+
+			var buf bytes.Buffer
+			if err := feed.Encode(&buf); err != nil {
+				t.Fatalf("Failed to encode feed: %v", err)
+			}
+			xmlContent := buf.String()
+
+			if tt.want == "" {
+				if strings.Contains(xmlContent, "pubDate") {
+					t.Errorf("Expected no pubDate tag, got: %s", xmlContent)
+				}
+				return
+			}
+			if !strings.Contains(xmlContent, tt.want) {
+				t.Errorf("Expected %s, got: %s", tt.want, xmlContent)
+			}
+		})
+	}
+}
+
+func TestFeedWithSelfLink(t *testing.T) {
+	feed := newTestFeed()
+	feed.AddItem(NewItem(ItemData{
+		Title:     "Test Episode",
+		Guid:      "test-episode-1",
+		Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+	}))
+
+	feed.WithSelfLink("https://example.com/old.xml").
+		WithHub("https://pubsubhubbub.appspot.com/").
+		WithSelfLink("https://example.com/rss.xml")
+	if len(feed.xmlDoc.Channel.AtomLinks) != 2 {
+		t.Fatalf("Expected self and hub atom links, got %d", len(feed.xmlDoc.Channel.AtomLinks))
+	}
+
+	var buf bytes.Buffer
+	if err := feed.Encode(&buf); err != nil {
+		t.Fatalf("Failed to encode feed: %v", err)
+	}
+	xmlContent := buf.String()
+
+	if !strings.Contains(xmlContent, `<atom:link rel="self" href="https://example.com/rss.xml" type="application/rss+xml"></atom:link>`) {
+		t.Errorf("Self link should be present, got: %s", xmlContent)
+	}
+	if strings.Contains(xmlContent, "old.xml") {
+		t.Error("Previous self link should be replaced")
+	}
+}
+
+func TestFeedWithManagingEditorAndWebMaster(t *testing.T) {
+	newFeed := func() *Feed {
+		feed := newTestFeed()
+		feed.AddItem(NewItem(ItemData{
+			Title:     "Test Episode",
+			Guid:      "test-episode-1",
+			Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+		}))
+		return feed
+	}
+	encode := func(t *testing.T, feed *Feed) string {
+		var buf bytes.Buffer
+		if err := feed.Encode(&buf); err != nil {
+			t.Fatalf("Failed to encode feed: %v", err)
+		}
+		return buf.String()
+	}
+
+	t.Run("formatting", func(t *testing.T) {
+		feed := newFeed().
+			WithManagingEditor("editor@example.com", "Jane Doe").
+			WithWebMaster("webmaster@example.com", "")
+		xmlContent := encode(t, feed)
+
+		if !strings.Contains(xmlContent, "<managingEditor>editor@example.com (Jane Doe)</managingEditor>") {
+			t.Errorf("Managing editor should be formatted as 'email (name)', got: %s", xmlContent)
+		}
+		if !strings.Contains(xmlContent, "<webMaster>webmaster@example.com</webMaster>") {
+			t.Errorf("Web master without name should contain only email, got: %s", xmlContent)
+		}
+	})
+
+	t.Run("escaping", func(t *testing.T) {
+		xmlContent := encode(t, newFeed().WithManagingEditor("editor@example.com", "Tom & Jerry"))
+
+		if !strings.Contains(xmlContent, "<managingEditor>editor@example.com (Tom &amp; Jerry)</managingEditor>") {
+			t.Errorf("Name should be escaped, got: %s", xmlContent)
+		}
+	})
+
+	t.Run("omitted", func(t *testing.T) {
+		xmlContent := encode(t, newFeed())
+
+		if strings.Contains(xmlContent, "managingEditor") || strings.Contains(xmlContent, "webMaster") {
+			t.Errorf("Unset tags should be omitted, got: %s", xmlContent)
+		}
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			feed  *Feed
+			field string
+		}{
+			{"managing editor empty", newFeed().WithManagingEditor("", "Jane Doe"), "channel.managingEditor"},
+			{"managing editor malformed", newFeed().WithManagingEditor("not-an-email", ""), "channel.managingEditor"},
+			{"web master with display name", newFeed().WithWebMaster("Jane <webmaster@example.com>", ""), "channel.webMaster"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := tt.feed.Validate()
+				var ve *ValidationError
+				if !errors.As(err, &ve) {
+					t.Fatalf("Expected validation error, got: %v", err)
+				}
+				if ve.Field != tt.field {
+					t.Errorf("Expected field %s, got %s", tt.field, ve.Field)
+				}
+			})
+		}
+	})
+}
+
+func TestFeedWithImage(t *testing.T) {
+	newFeed := func() *Feed {
+		feed := newTestFeed()
+		feed.AddItem(NewItem(ItemData{
+			Title:     "Test Episode",
+			Guid:      "test-episode-1",
+			Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+		}))
+		return feed
+	}
+
+	t.Run("set", func(t *testing.T) {
+		feed := newFeed().WithImage("https://example.com/artwork.jpg", "Test Podcast", "https://example.com")
+
+		var buf bytes.Buffer
+		if err := feed.Encode(&buf); err != nil {
+			t.Fatalf("Failed to encode feed: %v", err)
+		}
+		xmlContent := buf.String()
+
+		expected := "<image>\n      <url>https://example.com/artwork.jpg</url>\n      <title>Test Podcast</title>\n      <link>https://example.com</link>\n    </image>"
+		if !strings.Contains(xmlContent, expected) {
+			t.Errorf("Image should be present, got: %s", xmlContent)
+		}
+		if !strings.Contains(xmlContent, `<itunes:image href="https://example.com/artwork.jpg"></itunes:image>`) {
+			t.Errorf("iTunes image should be kept, got: %s", xmlContent)
+		}
+	})
+
+	t.Run("omitted", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := newFeed().Encode(&buf); err != nil {
+			t.Fatalf("Failed to encode feed: %v", err)
+		}
+		if strings.Contains(buf.String(), "<image>") {
+			t.Errorf("Image should be omitted, got: %s", buf.String())
+		}
+	})
+
+	t.Run("incomplete", func(t *testing.T) {
+		err := newFeed().WithImage("https://example.com/artwork.jpg", "", "https://example.com").Validate()
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Field != "channel.image" {
+			t.Errorf("Expected channel.image validation error, got: %v", err)
+		}
+	})
+}
+
+func TestFeedValidation_SerialEpisodes(t *testing.T) {
+	newFeed := func(episodes ...int) *Feed {
+		feed := newTestFeed().WithItunesType(TypeSerial)
+		for i, episode := range episodes {
+			feed.AddItem(NewItem(ItemData{
+				Title:     fmt.Sprintf("Test Episode %d", i+1),
+				Guid:      fmt.Sprintf("test-episode-%d", i+1),
+				Enclosure: NewEnclosure("https://example.com/episode.mp3", 1024, Mp3),
+			}).WithItunesEpisode(episode))
+		}
+		return feed
+	}
+
+	t.Run("compliant", func(t *testing.T) {
+		if err := newFeed(1, 2, 3).Validate(); err != nil {
+			t.Errorf("Expected serial feed to be valid, got: %v", err)
+		}
+	})
+
+	t.Run("missing episode", func(t *testing.T) {
+		err := newFeed(1, 0, 0).Validate()
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Field != "item.itunes:episode" {
+			t.Fatalf("Expected item.itunes:episode validation error, got: %v", err)
+		}
+		if !strings.Contains(err.Error(), "serial shows require itunes:episode on every item, missing on item 1") {
+			t.Errorf("Expected first offending item index in error, got: %v", err)
+		}
+	})
+
+	t.Run("episodic", func(t *testing.T) {
+		if err := newFeed(0).WithItunesType(TypeEpisodic).Validate(); err != nil {
+			t.Errorf("Expected episodic feed without episode numbers to be valid, got: %v", err)
+		}
+	})
+}
+
+func TestFeedValidation_CompleteFutureItems(t *testing.T) {
+	newFeed := func(pubDates ...time.Time) *Feed {
+		feed := newTestFeed().WithItunesComplete(CompleteYes)
+		for i, pubDate := range pubDates {
+			feed.AddItem(NewItem(ItemData{
+				Title:     fmt.Sprintf("Test Episode %d", i+1),
+				Guid:      fmt.Sprintf("test-episode-%d", i+1),
+				Enclosure: NewEnclosure("https://example.com/episode.mp3", 1024, Mp3),
+			}).WithPubDate(pubDate))
+		}
+		return feed
+	}
+	now := time.Now()
+
+	t.Run("past items", func(t *testing.T) {
+		if err := newFeed(now.Add(-48*time.Hour), now.Add(-time.Hour)).Validate(); err != nil {
+			t.Errorf("Expected complete feed with past items to be valid, got: %v", err)
+		}
+	})
+
+	t.Run("within clock skew", func(t *testing.T) {
+		if err := newFeed(now.Add(CompleteClockSkew / 2)).Validate(); err != nil {
+			t.Errorf("Expected item within clock skew to be valid, got: %v", err)
+		}
+	})
+
+	t.Run("future item", func(t *testing.T) {
+		err := newFeed(now.Add(-time.Hour), now.Add(24*time.Hour)).Validate()
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Field != "item.pubDate" {
+			t.Fatalf("Expected item.pubDate validation error, got: %v", err)
+		}
+		if !strings.Contains(err.Error(), "item 1") {
+			t.Errorf("Expected offending item index in error, got: %v", err)
+		}
+	})
+
+	t.Run("not complete", func(t *testing.T) {
+		if err := newFeed(now.Add(24 * time.Hour)).WithItunesComplete(CompleteNotSet).Validate(); err != nil {
+			t.Errorf("Expected future item in ongoing feed to be valid, got: %v", err)
+		}
+	})
+}
+
+func TestFeedValidation_NewFeedURL(t *testing.T) {
+	newFeed := func() *Feed {
+		feed := newTestFeed()
+		feed.AddItem(NewItem(ItemData{
+			Title:     "Test Episode",
+			Guid:      "test-episode-1",
+			Enclosure: NewEnclosure("https://example.com/episode.mp3", 1024, Mp3),
+		}))
+		return feed
+	}
+
+	t.Run("differs from self link", func(t *testing.T) {
+		feed := newFeed().
+			WithSelfLink("https://old.example.com/feed.xml").
+			WithItunesNewFeedURL("https://new.example.com/feed.xml")
+		if err := feed.Validate(); err != nil {
+			t.Errorf("Expected feed with different new feed URL to be valid, got: %v", err)
+		}
+	})
+
+	t.Run("matches self link", func(t *testing.T) {
+		feed := newFeed().
+			WithSelfLink("https://example.com/feed.xml").
+			WithItunesNewFeedURL("https://example.com/feed.xml")
+		err := feed.Validate()
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Field != "channel.itunes:new-feed-url" {
+			t.Fatalf("Expected channel.itunes:new-feed-url validation error, got: %v", err)
+		}
+	})
+
+	t.Run("matches hub link", func(t *testing.T) {
+		feed := newFeed().
+			WithHub("https://example.com/feed.xml").
+			WithItunesNewFeedURL("https://example.com/feed.xml")
+		if err := feed.Validate(); err != nil {
+			t.Errorf("Expected only the self link to be compared, got: %v", err)
+		}
+	})
+
+	t.Run("without self link", func(t *testing.T) {
+		feed := newFeed().WithItunesNewFeedURL("https://new.example.com/feed.xml")
+		if err := feed.Validate(); err != nil {
+			t.Errorf("Expected feed without self link to be valid, got: %v", err)
+		}
+	})
+}
+
+func TestFeedValidation_Link(t *testing.T) {
+	newFeed := func(itemLink string) *Feed {
+		feed := newTestFeed()
+		feed.AddItem(NewItem(ItemData{
+			Title:     "Test Episode",
+			Guid:      "test-episode-1",
+			Enclosure: NewEnclosure("https://example.com/episode.mp3", 1024, Mp3),
+		}).WithLink(itemLink))
+		return feed
+	}
+
+	tests := []struct {
+		name        string
+		channelLink string
+		itemLink    string
+		field       string // Expected field of the validation error, empty if valid
+	}{
+		{"absent links", "", "", ""},
+		{"valid links", "https://example.com", "http://example.com/episode-1", ""},
+		{"relative channel link", "/podcast", "", "channel.link"},
+		{"channel link without scheme", "example.com/podcast", "", "channel.link"},
+		{"channel link with other scheme", "ftp://example.com/podcast", "", "channel.link"},
+		{"relative item link", "https://example.com", "episode-1.html", "item.link"},
+		{"item link without host", "", "https://", "item.link"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newFeed(tt.itemLink).WithLink(tt.channelLink).Validate()
+			if tt.field == "" {
+				if err != nil {
+					t.Errorf("Expected feed to be valid, got: %v", err)
+				}
+				return
+			}
+			var ve *ValidationError
+			if !errors.As(err, &ve) || ve.Field != tt.field {
+				t.Fatalf("Expected %s validation error, got: %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestFeedValidation_ItunesImage(t *testing.T) {
+	newFeed := func(image, itemImage string) *Feed {
+		data := testFeedData()
+		data.Image = image
+		feed := NewFeed(data)
+		item := NewItem(ItemData{
+			Title:     "Test Episode",
+			Guid:      "test-episode-1",
+			Enclosure: NewEnclosure("https://example.com/episode.mp3", 1024, Mp3),
+		})
+		if itemImage != "" {
+			item.WithItunesImage(itemImage)
+		}
+		feed.AddItem(item)
+		return feed
+	}
+
+	tests := []struct {
+		name      string
+		image     string
+		itemImage string
+		warning   string // Expected prefix of the image warning, empty if none
+	}{
+		{"jpg", "https://example.com/artwork.jpg", "", ""},
+		{"jpeg uppercase", "https://example.com/artwork.JPEG", "", ""},
+		{"png with query", "https://example.com/artwork.png?v=2", "https://example.com/episode.png", ""},
+		{"webp", "https://example.com/artwork.webp", "", "channel itunes:image"},
+		{"gif", "https://example.com/artwork.gif", "", "channel itunes:image"},
+		{"no extension", "https://example.com/artwork", "", "channel itunes:image"},
+		{"extension in query only", "https://example.com/artwork?format=.jpg", "", "channel itunes:image"},
+		{"item webp", "https://example.com/artwork.jpg", "https://example.com/episode.webp", "item 0: item itunes:image"},
+		{"item no extension", "https://example.com/artwork.jpg", "https://example.com/episode", "item 0: item itunes:image"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := newFeed(tt.image, tt.itemImage).ValidateWithWarnings()
+			if err != nil {
+				t.Fatalf("Expected feed to be valid, got: %v", err)
+			}
+			var found []string
+			for _, w := range warnings {
+				if strings.Contains(w, "itunes:image") {
+					found = append(found, w)
+				}
+			}
+			if tt.warning == "" {
+				if len(found) > 0 {
+					t.Errorf("Expected no image warnings, got: %v", found)
+				}
+				return
+			}
+			if len(found) != 1 || !strings.HasPrefix(found[0], tt.warning) {
+				t.Errorf("Expected %q warning, got: %v", tt.warning, found)
+			}
+		})
+	}
+}
+
+func TestFeedValidateStrict(t *testing.T) {
+	newFeed := func(image string) *Feed {
+		data := testFeedData()
+		data.Image = image
+		feed := NewFeed(data)
+		feed.AddItem(NewItem(ItemData{
+			Title:     "Test Episode",
+			Guid:      "test-episode-1",
+			Enclosure: NewEnclosure("https://example.com/episode.mp3", 1024, Mp3),
+		}))
+		return feed
+	}
+
+	var method string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		switch r.URL.Path {
+		case "/artwork.jpg":
+			w.Header().Set("Content-Type", "image/jpeg")
+		case "/page.jpg":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// assertArtworkError checks that err is the channel.itunes:image validation error containing text
+	assertArtworkError := func(t *testing.T, err error, text string) {
+		t.Helper()
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Field != "channel.itunes:image" {
+			t.Fatalf("Expected channel.itunes:image validation error, got: %v", err)
+		}
+		if !strings.Contains(err.Error(), text) {
+			t.Errorf("Expected error containing '%s', got '%s'", text, err.Error())
+		}
+	}
+
+	t.Run("reachable image", func(t *testing.T) {
+		err := newFeed(server.URL+"/artwork.jpg").ValidateStrict(context.Background(), server.Client())
+		if err != nil {
+			t.Errorf("Expected reachable artwork to be valid, got: %v", err)
+		}
+		if method != http.MethodHead {
+			t.Errorf("Expected HEAD request, got %s", method)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := newFeed(server.URL+"/missing.jpg").ValidateStrict(context.Background(), server.Client())
+		assertArtworkError(t, err, "404 Not Found")
+	})
+
+	t.Run("not an image", func(t *testing.T) {
+		err := newFeed(server.URL+"/page.jpg").ValidateStrict(context.Background(), server.Client())
+		assertArtworkError(t, err, "must be an image")
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+		err := newFeed(closed.URL+"/artwork.jpg").ValidateStrict(context.Background(), nil)
+		assertArtworkError(t, err, "is not reachable")
+	})
+
+	t.Run("invalid feed", func(t *testing.T) {
+		method = ""
+		err := newFeed("").ValidateStrict(context.Background(), server.Client())
+		assertArtworkError(t, err, "channel itunes:image is required")
+		if method != "" {
+			t.Errorf("Expected no request for invalid feed, got %s", method)
+		}
+	})
+}
+
+func TestFeedWithItunesImage(t *testing.T) {
+	data := testFeedData()
+	data.Image = ""
+	feed := NewFeed(data)
+	feed.AddItem(NewItem(ItemData{
+		Title:     "Test Episode",
+		Guid:      "test-episode-1",
+		Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+	}))
+	if err := feed.Validate(); err == nil {
+		t.Fatal("Expected validation to fail without artwork")
+	}
+
+	result := feed.WithItunesImage("https://example.com/old.jpg").WithItunesImage("https://example.com/new.jpg")
+	if result != feed {
+		t.Error("WithItunesImage should return the same feed for chaining")
+	}
+	if err := feed.Validate(); err != nil {
+		t.Errorf("Expected feed to validate after setting artwork, got: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := feed.Encode(&buf); err != nil {
+		t.Fatalf("Failed to encode feed: %v", err)
+	}
+	xmlContent := buf.String()
+
+	if !strings.Contains(xmlContent, `<itunes:image href="https://example.com/new.jpg"></itunes:image>`) {
+		t.Errorf("New artwork should be present, got: %s", xmlContent)
+	}
+	if strings.Contains(xmlContent, "old.jpg") {
+		t.Error("Previous artwork should be replaced")
+	}
+}
+
+func TestFeedDateFormat(t *testing.T) {
+	pubDate := time.Date(2023, 4, 15, 12, 0, 0, 0, time.UTC)
+	buildDate := time.Date(2023, 4, 15, 14, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	tests := []struct {
+		name      string
+		format    DateFormat
+		pubDate   string
+		buildDate string
+	}{
+		{
+			name:      "RFC1123Z",
+			format:    DateRFC1123Z,
+			pubDate:   "Sat, 15 Apr 2023 12:00:00 +0000",
+			buildDate: "Sat, 15 Apr 2023 14:30:00 +0200",
+		},
+		{
+			name:      "RFC1123 GMT",
+			format:    DateRFC1123GMT,
+			pubDate:   "Sat, 15 Apr 2023 12:00:00 GMT",
+			buildDate: "Sat, 15 Apr 2023 12:30:00 GMT",
+		},
+		{
+			name:      "not set",
+			format:    "",
+			pubDate:   "Sat, 15 Apr 2023 12:00:00 +0000",
+			buildDate: "Sat, 15 Apr 2023 14:30:00 +0200",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := newTestFeed().WithPubDate(pubDate).WithLastBuildDate(buildDate)
+			feed.AddItem(NewItem(ItemData{
+				Title:     "Test Episode 1",
+				Guid:      "test-episode-1",
+				Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+			}).WithPubDate(pubDate))
+			feed.DateFormat = tt.format
+
+			var buf bytes.Buffer
+			if err := feed.Encode(&buf); err != nil {
+				t.Fatalf("Failed to encode feed: %v", err)
+			}
+			xmlContent := buf.String()
+
+			// Publication dates of both channel and item
+			if n := strings.Count(xmlContent, "<pubDate>"+tt.pubDate+"</pubDate>"); n != 2 {
+				t.Errorf("Expected XML to contain pubDate '%s' 2 times, got %d: %s", tt.pubDate, n, xmlContent)
+			}
+			if !strings.Contains(xmlContent, "<lastBuildDate>"+tt.buildDate+"</lastBuildDate>") {
+				t.Errorf("Expected XML to contain lastBuildDate '%s', got: %s", tt.buildDate, xmlContent)
+			}
+		})
+	}
+
+	t.Run("switch back", func(t *testing.T) {
+		feed := newTestFeed().WithPubDate(pubDate)
+		feed.AddItem(NewItem(ItemData{
+			Title:     "Test Episode 1",
+			Guid:      "test-episode-1",
+			Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+		}))
+		feed.DateFormat = DateRFC1123GMT
+		if err := feed.Encode(io.Discard); err != nil {
+			t.Fatalf("Failed to encode feed: %v", err)
+		}
+
+		feed.DateFormat = DateRFC1123Z
+		var buf bytes.Buffer
+		if err := feed.Encode(&buf); err != nil {
+			t.Fatalf("Failed to encode feed: %v", err)
+		}
+		if !strings.Contains(buf.String(), "<pubDate>Sat, 15 Apr 2023 12:00:00 +0000</pubDate>") {
+			t.Errorf("Expected RFC1123Z pubDate after switching back, got: %s", buf.String())
+		}
+	})
+}
+
+func TestFeedUseCDATA(t *testing.T) {
+	const text = "Tags like <b> & entities, and ]]> sequence"
+
+	tests := []struct {
+		name     string
+		useCDATA bool
+		expected string
+	}{
+		{
+			name:     "CDATA",
+			useCDATA: true,
+			expected: "<![CDATA[Tags like <b> & entities",
+		},
+		{
+			name:     "escaped text",
+			useCDATA: false,
+			expected: "Tags like &lt;b&gt; &amp; entities, and ]]&gt; sequence",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := testFeedData()
+			data.Description = text
+			feed := NewFeed(data).WithItunesSummary(text)
+			feed.AddItem(NewItem(ItemData{
+				Title:     "Test Episode",
+				Guid:      "test-episode-1",
+				Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+			}).WithDescription(text).WithItunesSummary(text))
+			feed.UseCDATA = tt.useCDATA
+
+			var buf bytes.Buffer
+			if err := feed.Encode(&buf); err != nil {
+				t.Fatalf("Failed to encode feed: %v", err)
+			}
+			xmlContent := buf.String()
+
+			// Descriptions and summaries of both channel and item
+			if n := strings.Count(xmlContent, tt.expected); n != 4 {
+				t.Errorf("Expected XML to contain '%s' 4 times, got %d: %s", tt.expected, n, xmlContent)
+			}
+			if !tt.useCDATA && strings.Contains(xmlContent, "<![CDATA[") {
+				t.Errorf("CDATA sections should not be used, got: %s", xmlContent)
+			}
+
+			var doc xmlDoc
+			if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+				t.Fatalf("Generated XML is not valid: %v", err)
+			}
+			if doc.Channel.Description.Data != text {
+				t.Errorf("Expected channel description %q, got %q", text, doc.Channel.Description.Data)
+			}
+			if len(doc.Channel.Items) != 1 {
+				t.Fatalf("Expected 1 item, got %d", len(doc.Channel.Items))
+			}
+			item := doc.Channel.Items[0]
+			if item.Description == nil || item.Description.Data != text {
+				t.Errorf("Expected item description %q, got %v", text, item.Description)
+			}
+		})
+	}
+}
+
 func TestFeedEncodeValidation(t *testing.T) {
 	// Test that Encode fails for invalid feeds
-	channelData := FeedData{
-		Title:       "Test Podcast",
-		Description: "A test podcast description",
-		Image:       "https://example.com/artwork.jpg",
-		Language:    "en",
-		Explicit:    ExplicitFalse,
-		Categories:  []Category{NewCategory("Technology")},
-	}
+	channelData := testFeedData()
 
 	feed := NewFeed(channelData)
 	// Don't add any items - this should fail validation
@@ -520,3 +1375,278 @@ func TestFeedEncodeValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestFeedEncodeLenient(t *testing.T) {
+	newFeed := func(items ...*Item) *Feed {
+		feed := newTestFeed()
+		for _, item := range items {
+			feed.AddItem(item)
+		}
+		return feed
+	}
+	newItem := func(title string, length int64) *Item {
+		return NewItem(ItemData{
+			Title:     title,
+			Guid:      "guid-" + title,
+			Enclosure: NewEnclosure("https://example.com/"+title+".mp3", length, Mp3),
+		})
+	}
+
+	t.Run("mixed items", func(t *testing.T) {
+		feed := newFeed(newItem("valid1", 1024), newItem("broken", 0), newItem("valid2", 2048), newItem("", 1024))
+
+		var buf bytes.Buffer
+		skipped, err := feed.EncodeLenient(&buf)
+		if err != nil {
+			t.Fatalf("Expected lenient encode to succeed, got: %v", err)
+		}
+
+		xmlContent := buf.String()
+		for _, title := range []string{"<title>valid1</title>", "<title>valid2</title>"} {
+			if !strings.Contains(xmlContent, title) {
+				t.Errorf("Expected feed XML to contain '%s', got: %s", title, xmlContent)
+			}
+		}
+		if strings.Contains(xmlContent, "broken") {
+			t.Errorf("Invalid item should be dropped, got: %s", xmlContent)
+		}
+		if len(feed.Channel.Items) != 2 {
+			t.Errorf("Expected 2 items left in the feed, got %d", len(feed.Channel.Items))
+		}
+
+		if skipped == nil {
+			t.Fatal("Expected errors of the dropped items")
+		}
+		for _, msg := range []string{
+			"invalid item 1: item enclosure length must be greater than zero",
+			"invalid item 3: item title is required",
+		} {
+			if !strings.Contains(skipped.Error(), msg) {
+				t.Errorf("Expected skipped errors to contain '%s', got: %v", msg, skipped)
+			}
+		}
+		var ve *ValidationError
+		if !errors.As(skipped, &ve) || ve.Field != "item.enclosure.length" {
+			t.Errorf("Expected validation error of the first dropped item, got: %v", skipped)
+		}
+	})
+
+	t.Run("all valid", func(t *testing.T) {
+		feed := newFeed(newItem("valid1", 1024), newItem("valid2", 2048))
+
+		var buf bytes.Buffer
+		skipped, err := feed.EncodeLenient(&buf)
+		if err != nil {
+			t.Fatalf("Expected lenient encode to succeed, got: %v", err)
+		}
+		if skipped != nil {
+			t.Errorf("Expected no dropped items, got: %v", skipped)
+		}
+	})
+
+	t.Run("all invalid", func(t *testing.T) {
+		feed := newFeed(newItem("broken1", 0), newItem("broken2", 0))
+
+		var buf bytes.Buffer
+		skipped, err := feed.EncodeLenient(&buf)
+		if err == nil || !strings.Contains(err.Error(), "at least one channel item is required") {
+			t.Errorf("Expected validation error of the empty feed, got: %v", err)
+		}
+		if skipped == nil {
+			t.Error("Expected errors of the dropped items")
+		}
+	})
+}
+
+func TestFeedValidateWithWarnings(t *testing.T) {
+	newFeed := func(item *Item) *Feed {
+		feed := newTestFeed()
+		feed.AddItem(item)
+		return feed
+	}
+	newItem := func() *Item {
+		return NewItem(ItemData{
+			Title:     "Test Episode",
+			Guid:      "test-episode-1",
+			Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+		})
+	}
+
+	t.Run("minimal feed", func(t *testing.T) {
+		warnings, err := newFeed(newItem()).ValidateWithWarnings()
+		if err != nil {
+			t.Fatalf("Expected feed to be valid, got: %v", err)
+		}
+		expected := []string{
+			"channel itunes:author is recommended",
+			"channel link is recommended",
+			"channel itunes:owner email is recommended to verify the show ownership",
+			"item 0: item pubDate is recommended",
+			"item 0: item description is recommended",
+			"item 0: item itunes:duration is recommended",
+		}
+		if fmt.Sprint(warnings) != fmt.Sprint(expected) {
+			t.Errorf("Unexpected warnings:\n got: %q\nwant: %q", warnings, expected)
+		}
+	})
+
+	t.Run("fully populated feed", func(t *testing.T) {
+		item := newItem().
+			WithPubDate(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)).
+			WithDescription("Test episode description").
+			WithItunesDuration(3600)
+		feed := newFeed(item).
+			WithAuthor("Test Author").
+			WithLink("https://example.com").
+			WithItunesOwner("Test Owner", "owner@example.com")
+
+		warnings, err := feed.ValidateWithWarnings()
+		if err != nil {
+			t.Fatalf("Expected feed to be valid, got: %v", err)
+		}
+		if len(warnings) != 0 {
+			t.Errorf("Expected no warnings, got: %q", warnings)
+		}
+	})
+
+	t.Run("invalid feed", func(t *testing.T) {
+		warnings, err := newFeed(newItem()).WithLink("/podcast").ValidateWithWarnings()
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Field != "channel.link" {
+			t.Errorf("Expected channel.link validation error, got: %v", err)
+		}
+		if len(warnings) == 0 {
+			t.Error("Expected warnings along with the validation error")
+		}
+	})
+}
+
+func TestFeedValidateWithWarnings_Numbering(t *testing.T) {
+	// item returns a fully populated item with the given season and episode numbers, 0 if not set
+	item := func(n, season, episode int) *Item {
+		return NewItem(ItemData{
+			Title:     fmt.Sprintf("Episode %d", n),
+			Guid:      fmt.Sprintf("episode-%d", n),
+			Enclosure: NewEnclosure(fmt.Sprintf("https://example.com/episode%d.mp3", n), 1024, Mp3),
+		}).
+			WithPubDate(time.Date(2024, 1, n, 12, 0, 0, 0, time.UTC)).
+			WithDescription("Test episode description").
+			WithItunesDuration(3600).
+			WithItunesSeason(season).
+			WithItunesEpisode(episode)
+	}
+	newFeed := func(itunesType ItunesType, items ...*Item) *Feed {
+		feed := newTestFeed().
+			WithAuthor("Test Author").
+			WithLink("https://example.com").
+			WithItunesOwner("Test Owner", "owner@example.com").
+			WithItunesType(itunesType)
+		for _, i := range items {
+			feed.AddItem(i)
+		}
+		return feed
+	}
+
+	tests := []struct {
+		name     string
+		feed     *Feed
+		expected []string
+	}{
+		{
+			name: "sequential",
+			feed: newFeed(TypeSerial, item(1, 1, 3), item(2, 1, 1), item(3, 1, 2), item(4, 2, 1)),
+		},
+		{
+			name: "sequential without seasons",
+			feed: newFeed(TypeEpisodic, item(1, 0, 2), item(2, 0, 1), item(3, 0, 0)),
+		},
+		{
+			name: "gap",
+			feed: newFeed(TypeSerial, item(1, 1, 1), item(2, 1, 2), item(3, 1, 4)),
+			expected: []string{
+				"season 1: itunes:episode numbers are not sequential, 2 is followed by 4",
+			},
+		},
+		{
+			name: "duplicate",
+			feed: newFeed(TypeEpisodic, item(1, 0, 1), item(2, 0, 1)),
+			expected: []string{
+				"items without itunes:season: itunes:episode numbers are not sequential, 1 is followed by 1",
+			},
+		},
+		{
+			name: "episode without season",
+			feed: newFeed(TypeEpisodic, item(1, 1, 1), item(2, 0, 7)),
+			expected: []string{
+				"item 1: itunes:episode 7 is set without itunes:season while other items have seasons",
+			},
+		},
+		{
+			name: "season without episode in episodic show",
+			feed: newFeed(TypeEpisodic, item(1, 1, 0), item(2, 1, 1)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := tt.feed.ValidateWithWarnings()
+			if err != nil {
+				t.Fatalf("Expected feed to be valid, got: %v", err)
+			}
+			if fmt.Sprint(warnings) != fmt.Sprint(tt.expected) {
+				t.Errorf("Unexpected warnings:\n got: %q\nwant: %q", warnings, tt.expected)
+			}
+		})
+	}
+
+	t.Run("season without episode in serial show", func(t *testing.T) {
+		warnings, err := newFeed(TypeSerial, item(1, 1, 1), item(2, 1, 0)).ValidateWithWarnings()
+		if err == nil {
+			t.Error("Expected validation error of the serial show item without itunes:episode")
+		}
+		want := "item 1: itunes:season 1 is set without itunes:episode"
+		if !slices.Contains(warnings, want) {
+			t.Errorf("Expected warning %q, got: %q", want, warnings)
+		}
+	})
+}
+
+func TestFeedWithPodcastMedium(t *testing.T) {
+	newFeed := func() *Feed {
+		feed := newTestFeed()
+		feed.AddItem(NewItem(ItemData{
+			Title:     "Test Episode",
+			Guid:      "test-episode-1",
+			Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+		}))
+		return feed
+	}
+
+	t.Run("valid medium", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := newFeed().WithPodcastMedium(MediumAudiobook).Encode(&buf); err != nil {
+			t.Fatalf("Failed to encode feed: %v", err)
+		}
+		if !strings.Contains(buf.String(), "<podcast:medium>audiobook</podcast:medium>") {
+			t.Errorf("Expected podcast:medium tag, got: %s", buf.String())
+		}
+	})
+
+	t.Run("invalid medium", func(t *testing.T) {
+		var ve *ValidationError
+		err := newFeed().WithPodcastMedium("radio").Validate()
+		if !errors.As(err, &ve) || ve.Field != "channel.podcast:medium" {
+			t.Errorf("Expected channel.podcast:medium validation error, got: %v", err)
+		}
+	})
+
+	t.Run("omitted", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := newFeed().Encode(&buf); err != nil {
+			t.Fatalf("Failed to encode feed: %v", err)
+		}
+		if strings.Contains(buf.String(), "podcast:medium") {
+			t.Errorf("Expected podcast:medium tag to be omitted, got: %s", buf.String())
+		}
+	})
+}
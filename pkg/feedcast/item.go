@@ -1,6 +1,8 @@
 package feedcast
 
 import (
+	"fmt"
+	"os"
 	"strconv"
 	"time"
 )
@@ -34,10 +36,28 @@ func (i *Item) Validate() error {
 	return i.xmlItem.validate()
 }
 
+// WithEnclosure sets the <enclosure> tag containing episode content, file size, and file type information.
+// RSS allows only one enclosure per item, so WithEnclosure replaces
+// the enclosure passed to NewItem rather than adding another one.
+// See Enclosure for details.
+func (i *Item) WithEnclosure(enclosure Enclosure) *Item {
+	i.xmlItem.Enclosure = xmlEnclosure{
+		URL:    enclosure.URL,
+		Length: enclosure.Length,
+		Type:   enclosure.Type,
+	}
+	return i
+}
+
 // WithPubDate sets the publication date for the episode.
-// The date should be in RFC1123 format, e.g., "Mon, 02 Jan 2006 15:04:05 MST"
-// which is equivalent to time.RFC1123Z in Go.
+// The date is encoded in RFC1123 format, e.g., "Mon, 02 Jan 2006 15:04:05 -0700",
+// according to the DateFormat of the feed the item is added to.
+// The tag is omitted if the date is zero, i.e. unknown.
 func (i *Item) WithPubDate(pubDate time.Time) *Item {
+	if pubDate.IsZero() {
+		i.xmlItem.PubDate = ""
+		return i
+	}
 	i.xmlItem.PubDate = pubDate.Format(time.RFC1123Z)
 	return i
 }
@@ -45,20 +65,32 @@ func (i *Item) WithPubDate(pubDate time.Time) *Item {
 // WithDescription sets the <description> tag containing one or more sentences
 // describing your episode to potential listeners. You can specify up to 10,000 characters.
 // You can use rich text formatting and some HTML (<p>, <ol>, <ul>, <li>, <a>)
-// if wrapped in the <CDATA> tag.
+// if wrapped in the <CDATA> tag. The description is set as is, see WithSanitizedDescription.
 func (i *Item) WithDescription(description string) *Item {
 	i.xmlItem.Description = &xmlCDATA{Data: description}
 	return i
 }
 
+// WithSanitizedDescription sets the <description> tag like WithDescription,
+// stripping the HTML tags not permitted by Apple Podcasts with SanitizeDescription.
+func (i *Item) WithSanitizedDescription(description string) *Item {
+	return i.WithDescription(SanitizeDescription(description))
+}
+
 // WithItunesDuration sets the <itunes:duration> tag containing the length of the episode in seconds.
+// The tag is omitted if the duration is zero or negative, i.e. unknown.
 func (i *Item) WithItunesDuration(duration int64) *Item {
+	if duration <= 0 {
+		i.xmlItem.ItunesDuration = ""
+		return i
+	}
 	i.xmlItem.ItunesDuration = strconv.FormatInt(duration, 10)
 	return i
 }
 
 // WithLink sets the <link> tag containing episode link URL.
-// This is used when an episode has a corresponding webpage. Use the full URL
+// This is used when an episode has a corresponding webpage. Use the full URL.
+// Validation fails if the link is not an absolute http(s) URL.
 func (i *Item) WithLink(link string) *Item {
 	i.xmlItem.Link = link
 	return i
@@ -80,11 +112,24 @@ func (i *Item) WithLink(link string) *Item {
 //     with appropriate file extensions (.jpg, .png), and in the RGB colorspace.
 //
 // Make sure the file type in the URL matches the actual file type of the image file.
+// Feed.ValidateWithWarnings warns if the URL path does not end in .jpg, .jpeg or .png.
 func (i *Item) WithItunesImage(href string) *Item {
 	i.xmlItem.ItunesImage = &xmlItunesImage{Href: href}
 	return i
 }
 
+// WithItunesImageAlt sets the <itunes:image> tag as WithItunesImage does
+// and adds the <podcast:images> tag carrying the same image with its alternative text
+// for accessibility-focused clients. If alt is empty, only <itunes:image> is set.
+func (i *Item) WithItunesImageAlt(href, alt string) *Item {
+	i.WithItunesImage(href)
+	i.xmlItem.PodcastImages = nil
+	if alt != "" {
+		i.xmlItem.PodcastImages = &xmlPodcastImages{Srcset: href, Alt: alt}
+	}
+	return i
+}
+
 // WithItunesExplicit sets the <itunes:explicit> tag containing parental advisory information.
 // See Explicit for supported values.
 func (i *Item) WithItunesExplicit(explicit Explicit) *Item {
@@ -133,6 +178,23 @@ func (i *Item) WithItunesSeason(season int) *Item {
 	return i
 }
 
+// WithPodcastSeason sets the <podcast:season> tag containing a non-zero integer season number
+// and an optional season name, e.g. "Race for the Whitehouse 2020".
+// Unlike <itunes:season>, it allows podcast apps to display the season by name.
+// If number is zero, the tag is omitted. Use it along with WithItunesSeason for Apple Podcasts.
+// See https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/tags/season.md
+func (i *Item) WithPodcastSeason(number int, name string) *Item {
+	if number <= 0 {
+		i.xmlItem.PodcastSeason = nil
+		return i
+	}
+	i.xmlItem.PodcastSeason = &xmlPodcastSeason{
+		Number: number,
+		Name:   name,
+	}
+	return i
+}
+
 // WithItunesEpisodeType sets the <itunes:episodeType> tag containing the episode type.
 // If an episode is a trailer or bonus content, use this tag.
 // See ItunesEpisodeType for supported values.
@@ -154,6 +216,15 @@ func (i *Item) WithPodcastTranscript(url string, transcriptType PodcastTranscrip
 	return i
 }
 
+// WithPodcastValue sets the <podcast:value> tag of the episode.
+// The episode value block replaces the one of the feed for this episode,
+// e.g. to add a guest to the recipients or to change their splits.
+// The arguments are the same as of Feed.WithPodcastValue.
+func (i *Item) WithPodcastValue(valueType, method string, suggested float64, recipients []ValueRecipient) *Item {
+	i.xmlItem.PodcastValue = newXmlPodcastValue(valueType, method, suggested, recipients)
+	return i
+}
+
 // WithItunesBlock sets the <itunes:block> tag for the episode.
 // If you want an episode removed from the Apple directory, use this tag.
 // For example, you might want to block a specific episode if you know that
@@ -176,11 +247,24 @@ func (i *Item) WithItunesAuthor(author string) *Item {
 // describing your episode to potential listeners. You can specify up to 4000 characters.
 // You can use rich text formatting and some HTML (<p>, <ol>, <ul>, <li>, <a>)
 // if wrapped in the <CDATA> tag.
+//
+// The summary is not truncated: validation fails if it exceeds MaxItemSummaryLen bytes.
+// Use TruncateSummary to shorten a long summary on a word boundary beforehand:
+//
+//	item.WithItunesSummary(feedcast.TruncateSummary(summary, feedcast.MaxItemSummaryLen))
 func (i *Item) WithItunesSummary(summary string) *Item {
 	i.xmlItem.ItunesSummary = &xmlCDATA{Data: summary}
 	return i
 }
 
+// WithItunesKeywords sets the <itunes:keywords> tag of the episode.
+// This tag is a comma-separated list of keywords that describe the episode.
+// Some podcast directories index episodes by these keywords.
+func (i *Item) WithItunesKeywords(keywords string) *Item {
+	i.xmlItem.ItunesKeywords = keywords
+	return i
+}
+
 // ItemData holds the data for the <item> element in the RSS feed.
 // It includes the minimal set of required tags as per Apple Podcasts specifications
 type ItemData struct {
@@ -254,3 +338,17 @@ func NewEnclosure(url string, length int64, enclosureType EnclosureType) Enclosu
 		Type:   enclosureType,
 	}
 }
+
+// NewEnclosureFromFile creates a new Enclosure as NewEnclosure does,
+// with the length filled in from the size of the local media file at path.
+// It returns an error if the file can not be stat-ed or is a directory.
+func NewEnclosureFromFile(url, path string, enclosureType EnclosureType) (Enclosure, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Enclosure{}, fmt.Errorf("failed to stat enclosure file: %w", err)
+	}
+	if info.IsDir() {
+		return Enclosure{}, fmt.Errorf("enclosure file %q is a directory", path)
+	}
+	return NewEnclosure(url, info.Size(), enclosureType), nil
+}
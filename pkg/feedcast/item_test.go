@@ -1,6 +1,11 @@
 package feedcast
 
 import (
+	"encoding/xml"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -45,6 +50,314 @@ func TestNewItem(t *testing.T) {
 	}
 }
 
+func TestItemWithEnclosure(t *testing.T) {
+	item := NewItem(ItemData{
+		Title: "Test Episode",
+		Guid:  "test-episode-1",
+		Enclosure: Enclosure{
+			URL:    "https://example.com/episode1.mp3",
+			Length: 1024000,
+			Type:   Mp3,
+		},
+	})
+
+	enclosure := NewEnclosure("https://example.com/episode1.m4a", 2048000, M4a)
+	result := item.WithEnclosure(enclosure)
+
+	if result != item {
+		t.Error("WithEnclosure should return the same item for chaining")
+	}
+	if item.xmlItem.Enclosure.URL != enclosure.URL {
+		t.Errorf("Expected enclosure URL %s, got %s", enclosure.URL, item.xmlItem.Enclosure.URL)
+	}
+	if item.xmlItem.Enclosure.Length != enclosure.Length {
+		t.Errorf("Expected enclosure length %d, got %d", enclosure.Length, item.xmlItem.Enclosure.Length)
+	}
+	if item.xmlItem.Enclosure.Type != enclosure.Type {
+		t.Errorf("Expected enclosure type %s, got %s", enclosure.Type, item.xmlItem.Enclosure.Type)
+	}
+	if err := item.Validate(); err != nil {
+		t.Errorf("Item with replaced enclosure should validate successfully: %v", err)
+	}
+
+	// Replacing with an invalid enclosure must fail validation
+	item.WithEnclosure(Enclosure{URL: "https://example.com/episode1.mp3", Type: Mp3})
+	if err := item.Validate(); err == nil {
+		t.Error("Expected validation error for enclosure without length")
+	}
+}
+
+func TestItemEnclosureEncodedOnce(t *testing.T) {
+	item := NewItem(ItemData{
+		Title:     "Test Episode",
+		Guid:      "test-episode-1",
+		Enclosure: NewEnclosure("https://example.com/old.mp3", 1024, Mp3),
+	}).WithEnclosure(NewEnclosure("https://example.com/new.mp3", 2048, Mp3))
+
+	data, err := xml.Marshal(item.xmlItem)
+	if err != nil {
+		t.Fatalf("Failed to marshal item: %v", err)
+	}
+	xmlStr := string(data)
+	if count := strings.Count(xmlStr, "<enclosure"); count != 1 {
+		t.Errorf("Expected exactly one enclosure, got %d", count)
+	}
+	if strings.Contains(xmlStr, "old.mp3") || !strings.Contains(xmlStr, "new.mp3") {
+		t.Errorf("Expected only the new enclosure in XML, got %s", xmlStr)
+	}
+}
+
+func TestNewEnclosureFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "episode1.mp3")
+	if err := os.WriteFile(path, make([]byte, 4096), 0644); err != nil {
+		t.Fatalf("Failed to write media file: %v", err)
+	}
+
+	t.Run("existing file", func(t *testing.T) {
+		enclosure, err := NewEnclosureFromFile("https://example.com/episode1.mp3", path, Mp3)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		expected := NewEnclosure("https://example.com/episode1.mp3", 4096, Mp3)
+		if enclosure != expected {
+			t.Errorf("Expected enclosure %+v, got %+v", expected, enclosure)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewEnclosureFromFile("https://example.com/missing.mp3", filepath.Join(dir, "missing.mp3"), Mp3)
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Expected not exist error, got: %v", err)
+		}
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		_, err := NewEnclosureFromFile("https://example.com/episode1.mp3", dir, Mp3)
+		if err == nil {
+			t.Error("Expected error for a directory")
+		}
+	})
+}
+
+func TestItemWithItunesKeywords(t *testing.T) {
+	newItem := func() *Item {
+		return NewItem(ItemData{
+			Title:     "Test Episode",
+			Guid:      "test-episode-1",
+			Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+		})
+	}
+
+	t.Run("set", func(t *testing.T) {
+		item := newItem().WithItunesKeywords("go,podcasts,rss")
+		data, err := xml.Marshal(item.xmlItem)
+		if err != nil {
+			t.Fatalf("Failed to marshal item: %v", err)
+		}
+		if !strings.Contains(string(data), "<itunes:keywords>go,podcasts,rss</itunes:keywords>") {
+			t.Errorf("Expected itunes:keywords tag, got %s", data)
+		}
+	})
+
+	t.Run("omitted", func(t *testing.T) {
+		data, err := xml.Marshal(newItem().xmlItem)
+		if err != nil {
+			t.Fatalf("Failed to marshal item: %v", err)
+		}
+		if strings.Contains(string(data), "itunes:keywords") {
+			t.Errorf("Expected no itunes:keywords tag, got %s", data)
+		}
+	})
+}
+
+func TestItemWithItunesDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration int64
+		want     string
+	}{
+		{"positive", 3600, "<itunes:duration>3600</itunes:duration>"},
+		{"zero", 0, ""},
+		{"negative", -1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := NewItem(ItemData{
+				Title:     "Test Episode",
+				Guid:      "test-episode-1",
+				Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+			}).WithItunesDuration(42).WithItunesDuration(tt.duration)
+
+			data, err := xml.Marshal(item.xmlItem)
+			if err != nil {
+				t.Fatalf("Failed to marshal item: %v", err)
+			}
+			if tt.want == "" {
+				if strings.Contains(string(data), "itunes:duration") {
+					t.Errorf("Expected no itunes:duration tag, got %s", data)
+				}
+				return
+			}
+			if !strings.Contains(string(data), tt.want) {
+				t.Errorf("Expected %s, got %s", tt.want, data)
+			}
+		})
+	}
+}
+
+func TestItemWithPubDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pubDate time.Time
+		want    string
+	}{
+		{"real", time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC), "<pubDate>Mon, 15 Jan 2024 10:30:00 +0000</pubDate>"},
+		{"zero", time.Time{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := NewItem(ItemData{
+				Title:     "Test Episode",
+				Guid:      "test-episode-1",
+				Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+			}).WithPubDate(time.Now()).WithPubDate(tt.pubDate)
+
+			data, err := xml.Marshal(item.xmlItem)
+			if err != nil {
+				t.Fatalf("Failed to marshal item: %v", err)
+			}
+			if tt.want == "" {
+				if strings.Contains(string(data), "pubDate") {
+					t.Errorf("Expected no pubDate tag, got %s", data)
+				}
+				return
+			}
+			if !strings.Contains(string(data), tt.want) {
+				t.Errorf("Expected %s, got %s", tt.want, data)
+			}
+		})
+	}
+}
+
+func TestItemWithItunesImageAlt(t *testing.T) {
+	newItem := func() *Item {
+		return NewItem(ItemData{
+			Title:     "Test Episode",
+			Guid:      "test-episode-1",
+			Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+		})
+	}
+
+	t.Run("with alt", func(t *testing.T) {
+		item := newItem().WithItunesImageAlt("https://example.com/ep1.jpg", "Host & guest at the mic")
+		data, err := xml.Marshal(item.xmlItem)
+		if err != nil {
+			t.Fatalf("Failed to marshal item: %v", err)
+		}
+		if !strings.Contains(string(data), `<itunes:image href="https://example.com/ep1.jpg"></itunes:image>`) {
+			t.Errorf("Expected itunes:image tag, got %s", data)
+		}
+		expected := `<podcast:images srcset="https://example.com/ep1.jpg" alt="Host &amp; guest at the mic"></podcast:images>`
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("Expected podcast:images tag with alt text, got %s", data)
+		}
+	})
+
+	t.Run("without alt", func(t *testing.T) {
+		item := newItem().WithItunesImageAlt("https://example.com/ep1.jpg", "")
+		data, err := xml.Marshal(item.xmlItem)
+		if err != nil {
+			t.Fatalf("Failed to marshal item: %v", err)
+		}
+		if !strings.Contains(string(data), `<itunes:image href="https://example.com/ep1.jpg"></itunes:image>`) {
+			t.Errorf("Expected itunes:image tag, got %s", data)
+		}
+		if strings.Contains(string(data), "podcast:images") {
+			t.Errorf("Expected no podcast:images tag, got %s", data)
+		}
+	})
+
+	t.Run("alt removed", func(t *testing.T) {
+		item := newItem().
+			WithItunesImageAlt("https://example.com/ep1.jpg", "Cover").
+			WithItunesImageAlt("https://example.com/ep2.jpg", "")
+		if item.xmlItem.PodcastImages != nil {
+			t.Errorf("Expected podcast:images to be reset, got %+v", item.xmlItem.PodcastImages)
+		}
+		if item.xmlItem.ItunesImage.Href != "https://example.com/ep2.jpg" {
+			t.Errorf("Expected itunes:image to be replaced, got %s", item.xmlItem.ItunesImage.Href)
+		}
+	})
+}
+
+func TestItemWithPodcastSeason(t *testing.T) {
+	newItem := func() *Item {
+		return NewItem(ItemData{
+			Title:     "Test Episode",
+			Guid:      "test-episode-1",
+			Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+		})
+	}
+
+	t.Run("with name", func(t *testing.T) {
+		item := newItem().WithPodcastSeason(3, "Race & Rivals")
+		data, err := xml.Marshal(item.xmlItem)
+		if err != nil {
+			t.Fatalf("Failed to marshal item: %v", err)
+		}
+		expected := `<podcast:season name="Race &amp; Rivals">3</podcast:season>`
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("Expected podcast:season tag with name, got %s", data)
+		}
+	})
+
+	t.Run("without name", func(t *testing.T) {
+		item := newItem().WithPodcastSeason(2, "")
+		data, err := xml.Marshal(item.xmlItem)
+		if err != nil {
+			t.Fatalf("Failed to marshal item: %v", err)
+		}
+		if !strings.Contains(string(data), `<podcast:season>2</podcast:season>`) {
+			t.Errorf("Expected podcast:season tag without name, got %s", data)
+		}
+	})
+
+	t.Run("zero number", func(t *testing.T) {
+		item := newItem().WithPodcastSeason(0, "Season name")
+		data, err := xml.Marshal(item.xmlItem)
+		if err != nil {
+			t.Fatalf("Failed to marshal item: %v", err)
+		}
+		if strings.Contains(string(data), "podcast:season") {
+			t.Errorf("Expected no podcast:season tag, got %s", data)
+		}
+	})
+
+	t.Run("zero number resets", func(t *testing.T) {
+		item := newItem().WithPodcastSeason(1, "First").WithPodcastSeason(0, "")
+		if item.xmlItem.PodcastSeason != nil {
+			t.Errorf("Expected podcast:season to be reset, got %+v", item.xmlItem.PodcastSeason)
+		}
+	})
+
+	t.Run("independent of itunes:season", func(t *testing.T) {
+		item := newItem().WithItunesSeason(4).WithPodcastSeason(5, "")
+		data, err := xml.Marshal(item.xmlItem)
+		if err != nil {
+			t.Fatalf("Failed to marshal item: %v", err)
+		}
+		if !strings.Contains(string(data), "<itunes:season>4</itunes:season>") {
+			t.Errorf("Expected itunes:season tag, got %s", data)
+		}
+		if !strings.Contains(string(data), "<podcast:season>5</podcast:season>") {
+			t.Errorf("Expected podcast:season tag, got %s", data)
+		}
+	})
+}
+
 func TestItemValidation_RequiredFields(t *testing.T) {
 	tests := []struct {
 		name        string
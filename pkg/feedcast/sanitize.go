@@ -0,0 +1,299 @@
+package feedcast
+
+import (
+	"html"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// allowedTags are the HTML tags permitted by Apple Podcasts in descriptions.
+var allowedTags = map[string]bool{
+	"p":  true,
+	"ol": true,
+	"ul": true,
+	"li": true,
+	"a":  true,
+}
+
+// droppedContentTags are the HTML tags removed together with their content.
+var droppedContentTags = map[string]bool{
+	"script": true,
+	"style":  true,
+}
+
+// blockTags are the HTML tags separating lines of text, which are replaced with a line break
+// when stripped, so that the text of adjacent blocks is not glued together.
+var blockTags = map[string]bool{
+	"br":         true,
+	"div":        true,
+	"h1":         true,
+	"h2":         true,
+	"h3":         true,
+	"h4":         true,
+	"h5":         true,
+	"h6":         true,
+	"hr":         true,
+	"blockquote": true,
+	"pre":        true,
+	"table":      true,
+	"tr":         true,
+	"section":    true,
+	"article":    true,
+	"header":     true,
+	"footer":     true,
+}
+
+// allowedLinkSchemes are the URL schemes kept in the href attribute of <a> tags.
+var allowedLinkSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+// SanitizeDescription strips the HTML tags not permitted by Apple Podcasts
+// from the description and keeps only <p>, <ol>, <ul>, <li> and <a> tags.
+// The text between the tags is preserved, except for the content of <script> and <style> tags.
+// Stripped block-level tags and <br> are replaced with a line break between the adjacent text.
+//
+// Attributes of the allowed tags are removed, except for href of <a>
+// with http, https or mailto URL. Comments are removed, and the '<' characters
+// that do not start a tag are escaped.
+func SanitizeDescription(description string) string {
+	var b strings.Builder
+	var lineBreak bool // Stripped block-level tag is pending to be replaced with a line break
+	write := func(text string) {
+		if text == "" {
+			return
+		}
+		if lineBreak && b.Len() > 0 && !endsWithSpace(b.String()) && !startsWithSpace(text) {
+			b.WriteByte('\n')
+		}
+		lineBreak = false
+		b.WriteString(text)
+	}
+
+	s := description
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			write(s)
+			break
+		}
+		write(s[:i])
+		s = s[i:]
+
+		// Remove comment
+		if strings.HasPrefix(s, "<!--") {
+			end := strings.Index(s[4:], "-->")
+			if end < 0 {
+				break
+			}
+			s = s[4+end+3:]
+			continue
+		}
+
+		end := tagEnd(s)
+		if end < 0 {
+			write("&lt;")
+			s = s[1:]
+			continue
+		}
+		name, closing, attrs, ok := parseTag(s[1:end])
+		if !ok {
+			write("&lt;")
+			s = s[1:]
+			continue
+		}
+		s = s[end+1:]
+
+		switch {
+		case droppedContentTags[name] && !closing:
+			// Skip the content up to the closing tag, which is removed on the next iteration
+			j := strings.Index(strings.ToLower(s), "</"+name)
+			if j < 0 {
+				s = ""
+			} else {
+				s = s[j:]
+			}
+		case allowedTags[name] && closing:
+			write("</" + name + ">")
+		case allowedTags[name]:
+			tag := "<" + name
+			if href, ok := attrs["href"]; ok && name == "a" && isAllowedLink(href) {
+				tag += ` href="` + html.EscapeString(href) + `"`
+			}
+			write(tag + ">")
+		case blockTags[name]:
+			lineBreak = true
+		}
+	}
+	return b.String()
+}
+
+// startsWithSpace reports whether s starts with a white space character.
+func startsWithSpace(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsSpace(r)
+}
+
+// endsWithSpace reports whether s ends with a white space character.
+func endsWithSpace(s string) bool {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return unicode.IsSpace(r)
+}
+
+// tagEnd returns the index of the '>' closing the tag at the beginning of s,
+// skipping the quoted attribute values. It returns -1 if the tag is not closed.
+func tagEnd(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i
+		}
+	}
+	return -1
+}
+
+// parseTag parses the tag without the angle brackets and returns its lowercase name,
+// whether it is a closing tag and its attributes with unescaped values.
+// Declarations and processing instructions (e.g. <!DOCTYPE html>) are returned with empty name.
+// It returns false if the text is not a tag.
+func parseTag(tag string) (name string, closing bool, attrs map[string]string, ok bool) {
+	if strings.HasPrefix(tag, "!") || strings.HasPrefix(tag, "?") {
+		return "", false, nil, true
+	}
+	if strings.HasPrefix(tag, "/") {
+		closing = true
+		tag = tag[1:]
+	}
+	n := 0
+	for n < len(tag) && isTagNameChar(tag[n], n == 0) {
+		n++
+	}
+	if n == 0 {
+		return "", false, nil, false
+	}
+	return strings.ToLower(tag[:n]), closing, parseAttrs(tag[n:]), true
+}
+
+// isTagNameChar reports whether c is allowed in the tag name: a letter, or a digit if not the first character.
+func isTagNameChar(c byte, first bool) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || !first && '0' <= c && c <= '9'
+}
+
+// parseAttrs parses the attributes of the tag. The names are lowercased and the values are unescaped.
+func parseAttrs(s string) map[string]string {
+	attrs := make(map[string]string)
+	for {
+		s = strings.TrimLeft(s, " \t\r\n\f/")
+		if s == "" {
+			return attrs
+		}
+		n := strings.IndexAny(s, " \t\r\n\f/=")
+		if n < 0 {
+			n = len(s)
+		}
+		name := strings.ToLower(s[:n])
+		s = strings.TrimLeft(s[n:], " \t\r\n\f")
+		if !strings.HasPrefix(s, "=") {
+			attrs[name] = ""
+			continue
+		}
+		s = strings.TrimLeft(s[1:], " \t\r\n\f")
+
+		var value string
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			end := strings.IndexByte(s[1:], s[0])
+			if end < 0 {
+				end = len(s) - 1
+			}
+			value, s = s[1:1+end], s[min(2+end, len(s)):]
+		} else {
+			end := strings.IndexAny(s, " \t\r\n\f")
+			if end < 0 {
+				end = len(s)
+			}
+			value, s = s[:end], s[end:]
+		}
+		if _, ok := attrs[name]; !ok {
+			attrs[name] = html.UnescapeString(value)
+		}
+	}
+}
+
+// isAllowedLink reports whether the link is an absolute URL with an allowed scheme.
+func isAllowedLink(link string) bool {
+	u, err := url.Parse(strings.TrimSpace(link))
+	return err == nil && allowedLinkSchemes[strings.ToLower(u.Scheme)]
+}
+
+// ellipsis is appended to the text truncated by TruncateSummary and TruncateTitle.
+const ellipsis = "…"
+
+// TruncateSummary shortens the summary to at most max bytes, e.g. MaxItemSummaryLen
+// before passing it to Item.WithItunesSummary, which does not truncate it.
+// The summary is cut on the last word boundary within the limit and the ellipsis is appended,
+// so the result including the ellipsis fits max. A single word longer than max
+// is cut without splitting multibyte characters. The summary not exceeding max is returned unchanged.
+func TruncateSummary(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	n := max - len(ellipsis)
+	if n <= 0 {
+		return Truncate(s, max)
+	}
+	return truncateWords(s, n, unicode.IsSpace)
+}
+
+// TruncateTitle shortens the title to at most max characters like TruncateSummary,
+// but counts characters instead of bytes and also drops the punctuation left before the ellipsis.
+// The title is returned unchanged if max is not positive.
+func TruncateTitle(s string, max int) string {
+	if max <= 0 || utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	// Byte length of the first max-1 characters, leaving one for the ellipsis
+	n := 0
+	for range max - 1 {
+		_, size := utf8.DecodeRuneInString(s[n:])
+		n += size
+	}
+	return truncateWords(s, n, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})
+}
+
+// truncateWords cuts s longer than n bytes on the last word boundary within n,
+// unless the text within the limit is a single word, trims the trailing characters
+// matching trim and appends the ellipsis.
+func truncateWords(s string, n int, trim func(rune) bool) string {
+	cut := Truncate(s, n)
+	if next, _ := utf8.DecodeRuneInString(s[len(cut):]); !unicode.IsSpace(next) {
+		if i := strings.LastIndexFunc(cut, unicode.IsSpace); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return strings.TrimRightFunc(cut, trim) + ellipsis
+}
+
+// Truncate shortens s to at most n bytes without splitting multibyte characters,
+// e.g. to fit the item description into MaxItemDescriptionLen.
+func Truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
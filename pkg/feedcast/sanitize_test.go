@@ -0,0 +1,208 @@
+package feedcast
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSanitizeDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "plain text",
+			input:    "Episode about Go & Rust",
+			expected: "Episode about Go & Rust",
+		},
+		{
+			name:     "allowed tags",
+			input:    "<p>Intro</p><ul><li>One</li><li>Two</li></ul><ol><li>First</li></ol>",
+			expected: "<p>Intro</p><ul><li>One</li><li>Two</li></ul><ol><li>First</li></ol>",
+		},
+		{
+			name:     "allowed tags in upper case with attributes",
+			input:    `<P class="intro" style="color: red">Intro</P>`,
+			expected: "<p>Intro</p>",
+		},
+		{
+			name:     "link",
+			input:    `<a href="https://example.com/?a=1&amp;b=2" target="_blank" onclick="alert(1)">Site</a>`,
+			expected: `<a href="https://example.com/?a=1&amp;b=2">Site</a>`,
+		},
+		{
+			name:     "mailto link",
+			input:    `<a href='mailto:host@example.com'>Mail</a>`,
+			expected: `<a href="mailto:host@example.com">Mail</a>`,
+		},
+		{
+			name:     "javascript link",
+			input:    `<a href="javascript:alert(1)">Click</a>`,
+			expected: "<a>Click</a>",
+		},
+		{
+			name:     "script",
+			input:    `<p>Before</p><script type="text/javascript">alert("<p>x</p>")</script><p>After</p>`,
+			expected: "<p>Before</p><p>After</p>",
+		},
+		{
+			name:     "unclosed script",
+			input:    "<p>Before</p><script>alert(1)",
+			expected: "<p>Before</p>",
+		},
+		{
+			name:     "style",
+			input:    "<STYLE>p { color: red }</STYLE>Text",
+			expected: "Text",
+		},
+		{
+			name:     "image",
+			input:    `<p>Cover: <img src="https://example.com/cover.jpg" alt="a > b"/></p>`,
+			expected: "<p>Cover: </p>",
+		},
+		{
+			name:     "formatting tags",
+			input:    "<div><b>Bold</b> and <i>italic</i><br>next line</div>",
+			expected: "Bold and italic\nnext line",
+		},
+		{
+			name:     "block tags",
+			input:    "<h1>Title</h1><div>First</div><div>Second</div>Text<hr>End",
+			expected: "Title\nFirst\nSecond\nText\nEnd",
+		},
+		{
+			name:     "block tags around white space",
+			input:    "Line one <br/> line two<br>\nline three",
+			expected: "Line one  line two\nline three",
+		},
+		{
+			name:     "comments and declarations",
+			input:    "<!DOCTYPE html><!-- note --><p>Text</p>",
+			expected: "<p>Text</p>",
+		},
+		{
+			name:     "less-than sign",
+			input:    "1 < 2 and 3 <4",
+			expected: "1 &lt; 2 and 3 &lt;4",
+		},
+		{
+			name:     "unclosed tag",
+			input:    "Text <p",
+			expected: "Text &lt;p",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeDescription(tt.input); got != tt.expected {
+				t.Errorf("SanitizeDescription(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestItemWithSanitizedDescription(t *testing.T) {
+	description := `<p>Intro</p><script>alert(1)</script><img src="cover.jpg">`
+
+	item := NewItem(ItemData{Title: "Test Episode", Guid: "test-episode-1"})
+	item.WithDescription(description)
+	if item.xmlItem.Description.Data != description {
+		t.Errorf("Expected description to be kept as is, got %q", item.xmlItem.Description.Data)
+	}
+
+	item.WithSanitizedDescription(description)
+	if item.xmlItem.Description.Data != "<p>Intro</p>" {
+		t.Errorf("Expected sanitized description, got %q", item.xmlItem.Description.Data)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		n        int
+		expected string
+	}{
+		{"within limit", "abc", 3, "abc"},
+		{"truncated", "abc", 2, "ab"},
+		{"multibyte characters", "яя", 3, "я"},
+		{"limit inside first character", "я", 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.input, tt.n); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTruncateSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		max      int
+		expected string
+	}{
+		{"short input", "Episode about Go", 20, "Episode about Go"},
+		{"exactly at limit", "Episode about Go", 16, "Episode about Go"},
+		{"truncated at word boundary", "Episode about Go and Rust", 20, "Episode about Go…"},
+		{"limit at word end", "Episode about Go and Rust", 19, "Episode about Go…"},
+		{"single long word", "Supercalifragilistic", 10, "Superca…"},
+		{"multibyte characters", "Выпуск про Go и Rust", 24, "Выпуск про…"},
+		{"limit shorter than ellipsis", "Episode", 2, "Ep"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateSummary(tt.input, tt.max)
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+			if len(got) > tt.max {
+				t.Errorf("Expected at most %d bytes, got %d", tt.max, len(got))
+			}
+		})
+	}
+
+	t.Run("summary limit", func(t *testing.T) {
+		input := strings.Repeat("word ", MaxItemSummaryLen)
+		got := TruncateSummary(input, MaxItemSummaryLen)
+		if len(got) > MaxItemSummaryLen || !strings.HasSuffix(got, "word…") {
+			t.Errorf("Expected summary truncated to %d bytes on a word boundary, got %d bytes: %q",
+				MaxItemSummaryLen, len(got), got[len(got)-10:])
+		}
+	})
+}
+
+func TestTruncateTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		max      int
+		expected string
+	}{
+		{"not limited", "Hello brave new world", 0, "Hello brave new world"},
+		{"within limit", "Hello world", 11, "Hello world"},
+		{"truncated at word boundary", "Hello brave new world", 14, "Hello brave…"},
+		{"limit at word end", "Hello brave new world", 12, "Hello brave…"},
+		{"trailing punctuation", "Hello, brave new world", 10, "Hello…"},
+		{"single long word", "Supercalifragilistic", 6, "Super…"},
+		{"multibyte characters", "Привет новый мир", 14, "Привет новый…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateTitle(tt.input, tt.max)
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+			if tt.max > 0 && utf8.RuneCountInString(got) > tt.max {
+				t.Errorf("Expected at most %d characters, got %d", tt.max, utf8.RuneCountInString(got))
+			}
+		})
+	}
+}
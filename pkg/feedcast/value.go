@@ -0,0 +1,114 @@
+package feedcast
+
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// ValueRecipient is a recipient of the payments sent by podcast apps
+// supporting the <podcast:value> tag (Value4Value). It is encoded as
+// the <podcast:valueRecipient> tag:
+//
+//	<podcast:valueRecipient name="Alice (Podcaster)" type="node" address="02d5c1bf..." split="40" />
+//
+// See https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/tags/value-recipient.md
+type ValueRecipient struct {
+	// Name of the recipient, e.g. "Alice (Podcaster)". Optional.
+	Name string
+
+	// Name of a custom record key to send along with the payment. Optional.
+	CustomKey string
+
+	// Custom value to pass along with the payment, keyed by CustomKey. Optional.
+	CustomValue string
+
+	// Type of the receiving address, e.g. "node" for a Lightning node. Required.
+	Type string
+
+	// Address of the recipient, e.g. the public key of the Lightning node. Required.
+	Address string
+
+	// Share of the payment the recipient receives, a positive integer.
+	// The shares of all recipients are added up, and each recipient
+	// receives its split of the total, e.g. 40 of 40+10.
+	Split int
+
+	// Whether the split is a fee taken off the top before other recipients are paid. Optional.
+	Fee bool
+}
+
+// xmlPodcastValue represents the <podcast:value> element in the RSS feed.
+type xmlPodcastValue struct {
+	Type       string                     `xml:"type,attr"`
+	Method     string                     `xml:"method,attr"`
+	Suggested  string                     `xml:"suggested,attr,omitempty"`
+	Recipients []xmlPodcastValueRecipient `xml:"podcast:valueRecipient"`
+}
+
+// xmlPodcastValueRecipient represents the <podcast:valueRecipient> element in the RSS feed.
+type xmlPodcastValueRecipient struct {
+	XMLName     xml.Name `xml:"podcast:valueRecipient"`
+	Name        string   `xml:"name,attr,omitempty"`
+	CustomKey   string   `xml:"customKey,attr,omitempty"`
+	CustomValue string   `xml:"customValue,attr,omitempty"`
+	Type        string   `xml:"type,attr"`
+	Address     string   `xml:"address,attr"`
+	Split       int      `xml:"split,attr"`
+	Fee         bool     `xml:"fee,attr,omitempty"`
+}
+
+// newXmlPodcastValue converts the value block with its recipients to the <podcast:value> element.
+// The suggested amount is encoded with 11 decimal places, i.e. in BTC with millisatoshi precision
+// for Lightning, and omitted if not positive.
+func newXmlPodcastValue(valueType, method string, suggested float64, recipients []ValueRecipient) *xmlPodcastValue {
+	v := &xmlPodcastValue{
+		Type:       valueType,
+		Method:     method,
+		Recipients: make([]xmlPodcastValueRecipient, len(recipients)),
+	}
+	if suggested > 0 {
+		v.Suggested = strconv.FormatFloat(suggested, 'f', 11, 64)
+	}
+	for i, r := range recipients {
+		v.Recipients[i] = xmlPodcastValueRecipient{
+			Name:        r.Name,
+			CustomKey:   r.CustomKey,
+			CustomValue: r.CustomValue,
+			Type:        r.Type,
+			Address:     r.Address,
+			Split:       r.Split,
+			Fee:         r.Fee,
+		}
+	}
+	return v
+}
+
+// validate checks the required attributes of the value block and its recipients.
+// A nil value block is valid, as the element is optional.
+func (v *xmlPodcastValue) validate(field string) error {
+	if v == nil {
+		return nil
+	}
+	if v.Type == "" {
+		return newValidationError(field+".type", "podcast:value type is required")
+	}
+	if v.Method == "" {
+		return newValidationError(field+".method", "podcast:value method is required")
+	}
+	if len(v.Recipients) == 0 {
+		return newValidationError(field+".podcast:valueRecipient", "podcast:value requires at least one recipient")
+	}
+	for i, r := range v.Recipients {
+		if r.Type == "" {
+			return newValidationError(field+".podcast:valueRecipient.type", "recipient %d type is required", i)
+		}
+		if r.Address == "" {
+			return newValidationError(field+".podcast:valueRecipient.address", "recipient %d address is required", i)
+		}
+		if r.Split <= 0 {
+			return newValidationError(field+".podcast:valueRecipient.split",
+				"recipient %d split must be a positive integer, got %d", i, r.Split)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package feedcast
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testValueRecipients are the recipients of a value block
+// modeled on the example of the podcast namespace documentation
+var testValueRecipients = []ValueRecipient{
+	{
+		Name:    "Alice (Podcaster)",
+		Type:    "node",
+		Address: "02d5c1bf8b940dc9cadca86d1b0a3c37fbe39cee4c7e839e33bef9174531d27f52",
+		Split:   40,
+	},
+	{
+		Name:        "Jimbob (Guest)",
+		Type:        "node",
+		Address:     "032f4ffbbafffbe51726ad3c164a3d0d37ec27bc67b29a159b0f49ae8ac21b8508",
+		CustomKey:   "696969",
+		CustomValue: "eChoVKtO1KujpAA5HCoB",
+		Split:       10,
+		Fee:         true,
+	},
+}
+
+func TestXmlPodcastValueStructure(t *testing.T) {
+	value := newXmlPodcastValue("lightning", "keysend", 0.00000005, testValueRecipients)
+
+	xmlData, err := xml.Marshal(value)
+	if err != nil {
+		t.Fatalf("Failed to marshal value: %v", err)
+	}
+
+	expected := `<xmlPodcastValue type="lightning" method="keysend" suggested="0.00000005000">` +
+		`<podcast:valueRecipient name="Alice (Podcaster)" type="node" ` +
+		`address="02d5c1bf8b940dc9cadca86d1b0a3c37fbe39cee4c7e839e33bef9174531d27f52" split="40"></podcast:valueRecipient>` +
+		`<podcast:valueRecipient name="Jimbob (Guest)" customKey="696969" customValue="eChoVKtO1KujpAA5HCoB" type="node" ` +
+		`address="032f4ffbbafffbe51726ad3c164a3d0d37ec27bc67b29a159b0f49ae8ac21b8508" split="10" fee="true"></podcast:valueRecipient>` +
+		`</xmlPodcastValue>`
+	if string(xmlData) != expected {
+		t.Errorf("Unexpected value XML:\n got: %s\nwant: %s", xmlData, expected)
+	}
+}
+
+func TestXmlPodcastValueSuggestedOmitted(t *testing.T) {
+	value := newXmlPodcastValue("lightning", "keysend", 0, testValueRecipients)
+
+	xmlData, err := xml.Marshal(value)
+	if err != nil {
+		t.Fatalf("Failed to marshal value: %v", err)
+	}
+	if strings.Contains(string(xmlData), "suggested=") {
+		t.Errorf("Suggested amount should be omitted, got: %s", xmlData)
+	}
+}
+
+func TestFeedWithPodcastValue(t *testing.T) {
+	newFeed := func(item *Item) *Feed {
+		feed := newTestFeed()
+		feed.AddItem(item)
+		return feed
+	}
+	newItem := func() *Item {
+		return NewItem(ItemData{
+			Title:     "Test Episode",
+			Guid:      "test-episode-1",
+			Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+		})
+	}
+
+	t.Run("channel", func(t *testing.T) {
+		feed := newFeed(newItem()).WithPodcastValue("lightning", "keysend", 0.00000005, testValueRecipients)
+
+		var buf bytes.Buffer
+		if err := feed.Encode(&buf); err != nil {
+			t.Fatalf("Failed to encode feed: %v", err)
+		}
+		xmlContent := buf.String()
+
+		expected := []string{
+			`<podcast:value type="lightning" method="keysend" suggested="0.00000005000">`,
+			`<podcast:valueRecipient name="Alice (Podcaster)" type="node" address="02d5c1bf8b940dc9cadca86d1b0a3c37fbe39cee4c7e839e33bef9174531d27f52" split="40"></podcast:valueRecipient>`,
+			`split="10" fee="true"></podcast:valueRecipient>`,
+		}
+		for _, elem := range expected {
+			if !strings.Contains(xmlContent, elem) {
+				t.Errorf("Expected feed XML to contain '%s', got: %s", elem, xmlContent)
+			}
+		}
+	})
+
+	t.Run("item", func(t *testing.T) {
+		feed := newFeed(newItem().WithPodcastValue("lightning", "keysend", 0, testValueRecipients[:1]))
+
+		var buf bytes.Buffer
+		if err := feed.Encode(&buf); err != nil {
+			t.Fatalf("Failed to encode feed: %v", err)
+		}
+		var doc xmlDoc
+		if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatalf("Failed to parse feed: %v", err)
+		}
+		if !strings.Contains(buf.String(), `<podcast:value type="lightning" method="keysend">`) {
+			t.Errorf("Expected item value block, got: %s", buf.String())
+		}
+		if doc.Channel.PodcastValue != nil {
+			t.Errorf("Channel value block should be omitted")
+		}
+	})
+
+	t.Run("invalid split", func(t *testing.T) {
+		recipients := []ValueRecipient{testValueRecipients[0], {Type: "node", Address: "03ae9f91", Split: 0}}
+		feed := newFeed(newItem()).WithPodcastValue("lightning", "keysend", 0, recipients)
+
+		var ve *ValidationError
+		err := feed.Validate()
+		if !errors.As(err, &ve) || ve.Field != "channel.podcast:value.podcast:valueRecipient.split" {
+			t.Errorf("Expected split validation error, got: %v", err)
+		}
+	})
+}
+
+func TestXmlPodcastValueValidation(t *testing.T) {
+	recipient := ValueRecipient{Type: "node", Address: "02d5c1bf", Split: 1}
+
+	tests := []struct {
+		name       string
+		valueType  string
+		method     string
+		recipients []ValueRecipient
+		field      string
+	}{
+		{"valid", "lightning", "keysend", []ValueRecipient{recipient}, ""},
+		{"missing type", "", "keysend", []ValueRecipient{recipient}, "item.podcast:value.type"},
+		{"missing method", "lightning", "", []ValueRecipient{recipient}, "item.podcast:value.method"},
+		{"no recipients", "lightning", "keysend", nil, "item.podcast:value.podcast:valueRecipient"},
+		{"missing recipient type", "lightning", "keysend", []ValueRecipient{{Address: "02d5c1bf", Split: 1}},
+			"item.podcast:value.podcast:valueRecipient.type"},
+		{"missing recipient address", "lightning", "keysend", []ValueRecipient{{Type: "node", Split: 1}},
+			"item.podcast:value.podcast:valueRecipient.address"},
+		{"negative split", "lightning", "keysend", []ValueRecipient{{Type: "node", Address: "02d5c1bf", Split: -5}},
+			"item.podcast:value.podcast:valueRecipient.split"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := NewItem(ItemData{
+				Title:     "Test Episode",
+				Guid:      "test-episode-1",
+				Enclosure: NewEnclosure("https://example.com/episode1.mp3", 1024, Mp3),
+			}).WithPodcastValue(tt.valueType, tt.method, 0, tt.recipients)
+
+			err := item.Validate()
+			if tt.field == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			var ve *ValidationError
+			if !errors.As(err, &ve) || ve.Field != tt.field {
+				t.Errorf("Expected %s validation error, got: %v", tt.field, err)
+			}
+		})
+	}
+}
@@ -2,17 +2,35 @@ package feedcast
 
 import (
 	"encoding/xml"
-	"errors"
 	"fmt"
+	"net/mail"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
 	xmlItunesNS   = "http://www.itunes.com/dtds/podcast-1.0.dtd"
 	xmlContentNS  = "http://purl.org/rss/1.0/modules/content/"
 	xmlPodcastNS  = "https://podcastindex.org/namespace/1.0"
+	xmlAtomNS     = "http://www.w3.org/2005/Atom"
 	xmlRssVersion = "2.0"
 )
 
+// Maximum text lengths in bytes as per Apple Podcasts specifications.
+const (
+	MaxChannelDescriptionLen = 4000  // <description> of the channel
+	MaxItemDescriptionLen    = 10000 // <description> of the item
+	MaxItemSummaryLen        = 4000  // <itunes:summary> of the item
+)
+
+// CompleteClockSkew is the tolerance for item pubDates ahead of the current time
+// in a feed marked as complete with CompleteYes.
+const CompleteClockSkew = 5 * time.Minute
+
 // xmlDoc represents the root <rss> element in the RSS feed.
 type xmlDoc struct {
 	XMLName   xml.Name   `xml:"rss"`
@@ -20,21 +38,22 @@ type xmlDoc struct {
 	ItunesNS  string     `xml:"xmlns:itunes,attr"`
 	ContentNS string     `xml:"xmlns:content,attr"`
 	PodcastNS string     `xml:"xmlns:podcast,attr"`
+	AtomNS    string     `xml:"xmlns:atom,attr,omitempty"`
 	Channel   xmlChannel `xml:"channel"`
 }
 
 func (d *xmlDoc) validate() error {
 	if d.Version != xmlRssVersion {
-		return errors.New("feed version mismatch")
+		return newValidationError("rss.version", "feed version mismatch")
 	}
 	if d.ItunesNS != xmlItunesNS {
-		return errors.New("itunes namespace mismatch")
+		return newValidationError("rss.xmlns:itunes", "itunes namespace mismatch")
 	}
 	if d.ContentNS != xmlContentNS {
-		return errors.New("content namespace mismatch")
+		return newValidationError("rss.xmlns:content", "content namespace mismatch")
 	}
 	if d.PodcastNS != xmlPodcastNS {
-		return errors.New("podcast namespace mismatch")
+		return newValidationError("rss.xmlns:podcast", "podcast namespace mismatch")
 	}
 	return d.Channel.validate()
 }
@@ -56,16 +75,22 @@ type xmlChannel struct {
 	LastBuildDate string `xml:"lastBuildDate,omitempty"`
 
 	// Situational tags
-	ItunesTitle      string          `xml:"itunes:title,omitempty"`
-	ItunesType       ItunesType      `xml:"itunes:type,omitempty"`
-	Copyright        string          `xml:"copyright,omitempty"`
-	ItunesNewFeedURL string          `xml:"itunes:new-feed-url,omitempty"`
-	ItunesBlock      ItunesBlock     `xml:"itunes:block,omitempty"`
-	ItunesComplete   ItunesComplete  `xml:"itunes:complete,omitempty"`
-	Generator        string          `xml:"generator,omitempty"`
-	ItunesSummary    *xmlCDATA       `xml:"itunes:summary,omitempty"`
-	ItunesKeywords   string          `xml:"itunes:keywords,omitempty"`
-	ItunesOwner      *xmlItunesOwner `xml:"itunes:owner,omitempty"`
+	ItunesTitle      string           `xml:"itunes:title,omitempty"`
+	ItunesType       ItunesType       `xml:"itunes:type,omitempty"`
+	Copyright        string           `xml:"copyright,omitempty"`
+	ItunesNewFeedURL string           `xml:"itunes:new-feed-url,omitempty"`
+	ItunesBlock      ItunesBlock      `xml:"itunes:block,omitempty"`
+	ItunesComplete   ItunesComplete   `xml:"itunes:complete,omitempty"`
+	Generator        string           `xml:"generator,omitempty"`
+	ItunesSummary    *xmlCDATA        `xml:"itunes:summary,omitempty"`
+	ItunesKeywords   string           `xml:"itunes:keywords,omitempty"`
+	ItunesOwner      *xmlItunesOwner  `xml:"itunes:owner,omitempty"`
+	AtomLinks        []xmlAtomLink    `xml:"atom:link,omitempty"`
+	Image            *xmlImage        `xml:"image,omitempty"`
+	ManagingEditor   *xmlContact      `xml:"managingEditor,omitempty"`
+	WebMaster        *xmlContact      `xml:"webMaster,omitempty"`
+	PodcastValue     *xmlPodcastValue `xml:"podcast:value,omitempty"`
+	PodcastMedium    PodcastMedium    `xml:"podcast:medium,omitempty"`
 
 	// Items (episodes)
 	Items []xmlItem `xml:"item"`
@@ -73,34 +98,156 @@ type xmlChannel struct {
 
 func (c *xmlChannel) validate() error {
 	if c.Title == "" {
-		return errors.New("channel title is required")
+		return newValidationError("channel.title", "channel title is required")
 	}
 	if c.Description.Data == "" {
-		return errors.New("channel description is required")
+		return newValidationError("channel.description", "channel description is required")
+	}
+	if len(c.Description.Data) > MaxChannelDescriptionLen {
+		return newValidationError("channel.description", "channel description must not exceed %d bytes, got %d", MaxChannelDescriptionLen, len(c.Description.Data))
 	}
 	if c.ItunesImage.Href == "" {
-		return errors.New("channel itunes:image is required")
+		return newValidationError("channel.itunes:image",
+			"channel itunes:image is required: artwork must be a JPEG or PNG image from 1400x1400 to 3000x3000 pixels")
 	}
 	if c.Language == "" {
-		return errors.New("channel language is required")
+		return newValidationError("channel.language", "channel language is required")
 	}
 	if c.ItunesExplicit != ExplicitTrue && c.ItunesExplicit != ExplicitFalse {
-		return errors.New("channel itunes:explicit must be either 'true' or 'false'")
+		return newValidationError("channel.itunes:explicit", "channel itunes:explicit must be either 'true' or 'false'")
 	}
 	if len(c.ItunesCategory) == 0 {
-		return errors.New("at least one itunes:category is required")
+		return newValidationError("channel.itunes:category", "at least one itunes:category is required")
+	}
+	for i := range c.ItunesCategory {
+		if err := c.ItunesCategory[i].validate(); err != nil {
+			return err
+		}
+	}
+	if err := validateLink("channel.link", c.Link); err != nil {
+		return err
+	}
+	if c.Image != nil && (c.Image.Url == "" || c.Image.Title == "" || c.Image.Link == "") {
+		return newValidationError("channel.image", "channel image requires url, title and link")
+	}
+	if err := c.ManagingEditor.validate("channel.managingEditor"); err != nil {
+		return err
+	}
+	if err := c.WebMaster.validate("channel.webMaster"); err != nil {
+		return err
+	}
+	if err := c.PodcastValue.validate("channel.podcast:value"); err != nil {
+		return err
+	}
+	if c.PodcastMedium != MediumNotSet && !podcastMediums[c.PodcastMedium] {
+		return newValidationError("channel.podcast:medium", "unknown podcast:medium %q", c.PodcastMedium)
+	}
+	if c.ItunesNewFeedURL != "" {
+		for _, l := range c.AtomLinks {
+			if l.Rel == "self" && l.Href == c.ItunesNewFeedURL {
+				return newValidationError("channel.itunes:new-feed-url",
+					"itunes:new-feed-url must differ from the atom self link, got %q for both", l.Href)
+			}
+		}
 	}
 	if len(c.Items) == 0 {
-		return errors.New("at least one channel item is required")
+		return newValidationError("channel.item", "at least one channel item is required")
 	}
 	for i, item := range c.Items {
 		if err := item.validate(); err != nil {
 			return fmt.Errorf("invalid item %d: %w", i, err)
 		}
 	}
+	if c.ItunesType == TypeSerial {
+		for i, item := range c.Items {
+			if item.ItunesEpisode == "" {
+				return newValidationError("item.itunes:episode",
+					"serial shows require itunes:episode on every item, missing on item %d", i)
+			}
+		}
+	}
+	if c.ItunesComplete == CompleteYes {
+		limit := time.Now().Add(CompleteClockSkew)
+		for i, item := range c.Items {
+			pubDate, err := parseDate(item.PubDate)
+			if err == nil && pubDate.After(limit) {
+				return newValidationError("item.pubDate",
+					"complete shows must not have future-dated items, item %d is published at %s", i, item.PubDate)
+			}
+		}
+	}
 	return nil
 }
 
+// warnings returns the recommended channel and item tags that are missing.
+// Unlike validate, it reports conditions which do not make the feed invalid.
+func (c *xmlChannel) warnings() []string {
+	var warnings []string
+	if c.ItunesAuthor == "" {
+		warnings = append(warnings, "channel itunes:author is recommended")
+	}
+	if c.Link == "" {
+		warnings = append(warnings, "channel link is recommended")
+	}
+	if c.ItunesOwner == nil || c.ItunesOwner.Email == "" {
+		warnings = append(warnings, "channel itunes:owner email is recommended to verify the show ownership")
+	}
+	if w := c.ItunesImage.warning("channel"); w != "" {
+		warnings = append(warnings, w)
+	}
+	for i, item := range c.Items {
+		for _, w := range item.warnings() {
+			warnings = append(warnings, fmt.Sprintf("item %d: %s", i, w))
+		}
+	}
+	return append(warnings, c.numberingWarnings()...)
+}
+
+// numberingWarnings returns the inconsistencies of the item episode and season numbers
+// that confuse the episode ordering: items of a serial show with a season but no episode number,
+// numbered items without a season in a show with seasons, and gaps or duplicates
+// in the episode numbers of a season.
+func (c *xmlChannel) numberingWarnings() []string {
+	var warnings []string
+	hasSeasons := slices.ContainsFunc(c.Items, func(item xmlItem) bool { return item.ItunesSeason != "" })
+	var seasons []string          // Seasons in the order of appearance, "" for the items without a season
+	numbers := map[string][]int{} // Episode numbers by season
+	for i, item := range c.Items {
+		switch {
+		case c.ItunesType == TypeSerial && item.ItunesSeason != "" && item.ItunesEpisode == "":
+			warnings = append(warnings, fmt.Sprintf(
+				"item %d: itunes:season %s is set without itunes:episode", i, item.ItunesSeason))
+		case hasSeasons && item.ItunesSeason == "" && item.ItunesEpisode != "":
+			warnings = append(warnings, fmt.Sprintf(
+				"item %d: itunes:episode %s is set without itunes:season while other items have seasons", i, item.ItunesEpisode))
+		}
+		n, err := strconv.Atoi(item.ItunesEpisode)
+		if err != nil {
+			continue
+		}
+		if _, ok := numbers[item.ItunesSeason]; !ok {
+			seasons = append(seasons, item.ItunesSeason)
+		}
+		numbers[item.ItunesSeason] = append(numbers[item.ItunesSeason], n)
+	}
+	for _, season := range seasons {
+		episodes := numbers[season]
+		slices.Sort(episodes)
+		for j := 1; j < len(episodes); j++ {
+			if episodes[j] != episodes[j-1]+1 {
+				name := "items without itunes:season"
+				if season != "" {
+					name = "season " + season
+				}
+				warnings = append(warnings, fmt.Sprintf(
+					"%s: itunes:episode numbers are not sequential, %d is followed by %d", name, episodes[j-1], episodes[j]))
+				break
+			}
+		}
+	}
+	return warnings
+}
+
 // xmlItem represents the <item> element in the RSS feed.
 type xmlItem struct {
 	// Required tags
@@ -120,46 +267,127 @@ type xmlItem struct {
 	ItunesTitle        string                 `xml:"itunes:title,omitempty"`
 	ItunesEpisode      string                 `xml:"itunes:episode,omitempty"`
 	ItunesSeason       string                 `xml:"itunes:season,omitempty"`
+	PodcastSeason      *xmlPodcastSeason      `xml:"podcast:season,omitempty"`
 	ItunesEpisodeType  ItunesEpisodeType      `xml:"itunes:episodeType,omitempty"`
 	PodcastTranscripts []xmlPodcastTranscript `xml:"podcast:transcript,omitempty"`
 	ItunesBlock        ItunesBlock            `xml:"itunes:block,omitempty"`
 	ItunesAuthor       string                 `xml:"itunes:author,omitempty"`
 	ItunesSummary      *xmlCDATA              `xml:"itunes:summary,omitempty"`
+	ItunesKeywords     string                 `xml:"itunes:keywords,omitempty"`
+	PodcastImages      *xmlPodcastImages      `xml:"podcast:images,omitempty"`
+	PodcastValue       *xmlPodcastValue       `xml:"podcast:value,omitempty"`
 }
 
 func (i *xmlItem) validate() error {
 	if i.Title == "" {
-		return errors.New("item title is required")
+		return newValidationError("item.title", "item title is required")
 	}
 	if i.Enclosure.URL == "" {
-		return errors.New("item enclosure url is required")
+		return newValidationError("item.enclosure.url", "item enclosure url is required")
 	}
 	if i.Enclosure.Length <= 0 {
-		return errors.New("item enclosure length must be greater than zero")
+		return newValidationError("item.enclosure.length", "item enclosure length must be greater than zero")
 	}
 	if i.Enclosure.Type == "" {
-		return errors.New("item enclosure type is required")
+		return newValidationError("item.enclosure.type", "item enclosure type is required")
 	}
 	if i.Guid == "" {
-		return errors.New("item guid is required")
+		return newValidationError("item.guid", "item guid is required")
 	}
 	if i.ItunesExplicit != "" && i.ItunesExplicit != ExplicitTrue && i.ItunesExplicit != ExplicitFalse {
-		return errors.New("item itunes:explicit must be either 'true' or 'false'")
+		return newValidationError("item.itunes:explicit", "item itunes:explicit must be either 'true' or 'false'")
+	}
+	if err := validateLink("item.link", i.Link); err != nil {
+		return err
+	}
+	if err := i.PodcastValue.validate("item.podcast:value"); err != nil {
+		return err
+	}
+	if i.Description != nil && len(i.Description.Data) > MaxItemDescriptionLen {
+		return newValidationError("item.description", "item description must not exceed %d bytes, got %d", MaxItemDescriptionLen, len(i.Description.Data))
+	}
+	if i.ItunesSummary != nil && len(i.ItunesSummary.Data) > MaxItemSummaryLen {
+		return newValidationError("item.itunes:summary", "item itunes:summary must not exceed %d bytes, got %d", MaxItemSummaryLen, len(i.ItunesSummary.Data))
 	}
 	return nil
 }
 
+// warnings returns the recommended item tags that are missing.
+func (i *xmlItem) warnings() []string {
+	var warnings []string
+	if i.PubDate == "" {
+		warnings = append(warnings, "item pubDate is recommended")
+	}
+	if i.Description == nil || i.Description.Data == "" {
+		warnings = append(warnings, "item description is recommended")
+	}
+	if i.ItunesDuration == "" {
+		warnings = append(warnings, "item itunes:duration is recommended")
+	}
+	if w := i.ItunesImage.warning("item"); w != "" {
+		warnings = append(warnings, w)
+	}
+	return warnings
+}
+
 // xmlItunesImage represents the <itunes:image> element in the RSS feed.
 type xmlItunesImage struct {
 	XMLName xml.Name `xml:"itunes:image"`
 	Href    string   `xml:"href,attr"`
 }
 
+// itunesImageExts are the file extensions of the artwork formats supported by Apple Podcasts.
+var itunesImageExts = []string{".jpg", ".jpeg", ".png"}
+
+// warning returns the warning if the artwork URL does not end in one of the itunesImageExts extensions,
+// or empty string otherwise. The artwork itself is not fetched, so the extension is only a sign
+// of its format: the URL without the extension may still serve a supported image.
+// Nil image has no warning.
+func (i *xmlItunesImage) warning(element string) string {
+	if i == nil {
+		return ""
+	}
+	p := i.Href
+	if u, err := url.Parse(i.Href); err == nil {
+		p = u.Path
+	}
+	if !slices.Contains(itunesImageExts, strings.ToLower(path.Ext(p))) {
+		return fmt.Sprintf("%s itunes:image href should end in .jpg, .jpeg or .png, got %q", element, i.Href)
+	}
+	return ""
+}
+
 // xmlItunesCategory represents the <itunes:category> element in the RSS feed.
 type xmlItunesCategory struct {
 	XMLName          xml.Name            `xml:"itunes:category"`
 	Text             string              `xml:"text,attr"`
 	ItunesCategories []xmlItunesCategory `xml:"itunes:category,omitempty"`
+	raw              bool                // Skip validation against the taxonomy
+}
+
+// newXmlItunesCategory converts the category with its subcategories to the <itunes:category> element.
+func newXmlItunesCategory(c Category) xmlItunesCategory {
+	sub := make([]xmlItunesCategory, len(c.Subcategories))
+	for i, s := range c.Subcategories {
+		sub[i] = xmlItunesCategory{Text: s}
+	}
+	return xmlItunesCategory{
+		Text:             c.Text,
+		ItunesCategories: sub,
+		raw:              c.raw,
+	}
+}
+
+// validate checks the category and its subcategories against the Apple Podcasts taxonomy.
+func (c *xmlItunesCategory) validate() error {
+	if c.raw {
+		return nil
+	}
+	subs := make([]string, len(c.ItunesCategories))
+	for i, sub := range c.ItunesCategories {
+		subs[i] = sub.Text
+	}
+	return validateCategory(c.Text, subs)
 }
 
 // xmlEnclosure represents the <enclosure> element in the RSS feed.
@@ -175,6 +403,73 @@ type xmlItunesOwner struct {
 	Email string `xml:"itunes:email"`
 }
 
+// xmlAtomLink represents the <atom:link> element in the RSS feed.
+type xmlAtomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// xmlImage represents the standard RSS <image> element of the channel.
+type xmlImage struct {
+	Url   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+// xmlContact represents the <managingEditor> and <webMaster> elements in the RSS feed.
+// It is encoded as "email (name)" text, or just "email" if the name is empty.
+type xmlContact struct {
+	Email string
+	Name  string
+}
+
+// MarshalXML encodes the contact as "email (name)" text.
+func (c xmlContact) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	text := c.Email
+	if c.Name != "" {
+		text += " (" + c.Name + ")"
+	}
+	return e.EncodeElement(text, start)
+}
+
+// validate checks that the contact email is a bare valid address.
+// A nil contact is valid, as the element is optional.
+func (c *xmlContact) validate(field string) error {
+	if c == nil {
+		return nil
+	}
+	addr, err := mail.ParseAddress(c.Email)
+	if err != nil || addr.Address != c.Email {
+		return newValidationError(field, "invalid email address: %q", c.Email)
+	}
+	return nil
+}
+
+// validateLink checks that the link is an absolute http(s) URL. Empty link is allowed as the tag is optional.
+func validateLink(field, link string) error {
+	if link == "" {
+		return nil
+	}
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return newValidationError(field, "link must be an absolute http(s) URL, got %q", link)
+	}
+	return nil
+}
+
+// xmlPodcastImages represents the <podcast:images> element in the RSS feed.
+type xmlPodcastImages struct {
+	Srcset string `xml:"srcset,attr"`
+	Alt    string `xml:"alt,attr,omitempty"`
+}
+
+// xmlPodcastSeason represents the <podcast:season> element in the RSS feed.
+type xmlPodcastSeason struct {
+	Number int    `xml:",chardata"`
+	Name   string `xml:"name,attr,omitempty"`
+}
+
 // xmlPodcastTranscript represents the <podcast:transcript> element in the RSS feed.
 type xmlPodcastTranscript struct {
 	Url  string `xml:"url,attr"`
@@ -182,6 +477,73 @@ type xmlPodcastTranscript struct {
 }
 
 // xmlCDATA is a custom type to handle CDATA sections in XML.
+// If text is set, the data is encoded as entity-escaped text instead of CDATA section.
 type xmlCDATA struct {
 	Data string `xml:",cdata"`
+	text bool
+}
+
+// MarshalXML encodes the data either as CDATA section or as escaped text.
+// The CDATA end sequence "]]>" inside the data is split by encoding/xml
+// into "]]]]><![CDATA[>", so the output stays well-formed.
+func (c xmlCDATA) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if c.text {
+		return e.EncodeElement(c.Data, start)
+	}
+	return e.EncodeElement(struct {
+		Data string `xml:",cdata"`
+	}{c.Data}, start)
+}
+
+// setDateFormat formats the channel and item dates with the given layout,
+// DateRFC1123Z if not set. Dates that cannot be parsed are kept as is.
+func (d *xmlDoc) setDateFormat(format DateFormat) {
+	if format == "" {
+		format = DateRFC1123Z
+	}
+	d.Channel.PubDate = formatDate(d.Channel.PubDate, format)
+	d.Channel.LastBuildDate = formatDate(d.Channel.LastBuildDate, format)
+	for i := range d.Channel.Items {
+		d.Channel.Items[i].PubDate = formatDate(d.Channel.Items[i].PubDate, format)
+	}
+}
+
+// formatDate reformats the date in any of DateFormat layouts with the given layout.
+// The date is converted to UTC for DateRFC1123GMT.
+func formatDate(date string, format DateFormat) string {
+	t, err := parseDate(date)
+	if err != nil {
+		return date
+	}
+	if format == DateRFC1123GMT {
+		t = t.UTC()
+	}
+	return t.Format(string(format))
+}
+
+// parseDate parses the date in any of DateFormat layouts.
+func parseDate(date string) (time.Time, error) {
+	t, err := time.Parse(string(DateRFC1123Z), date)
+	if err != nil {
+		return time.Parse(string(DateRFC1123GMT), date)
+	}
+	return t, nil
+}
+
+// setCDATA switches all text fields that may contain markup
+// (descriptions and summaries of the channel and items)
+// between CDATA sections and escaped text.
+func (d *xmlDoc) setCDATA(use bool) {
+	d.Channel.Description.text = !use
+	if d.Channel.ItunesSummary != nil {
+		d.Channel.ItunesSummary.text = !use
+	}
+	for i := range d.Channel.Items {
+		if d.Channel.Items[i].Description != nil {
+			d.Channel.Items[i].Description.text = !use
+		}
+		if d.Channel.Items[i].ItunesSummary != nil {
+			d.Channel.Items[i].ItunesSummary.text = !use
+		}
+	}
 }
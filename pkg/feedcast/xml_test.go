@@ -193,6 +193,47 @@ func TestXmlChannelValidation(t *testing.T) {
 			expectError: true,
 			errorMsg:    "channel title is required",
 		},
+		{
+			name: "description at max length",
+			channel: xmlChannel{
+				Title:          "Valid Podcast",
+				Description:    xmlCDATA{Data: strings.Repeat("a", 4000)},
+				ItunesImage:    xmlItunesImage{Href: "https://example.com/image.jpg"},
+				Language:       "en",
+				ItunesExplicit: ExplicitFalse,
+				ItunesCategory: []xmlItunesCategory{{Text: "Technology"}},
+				Items:          []xmlItem{validItem},
+			},
+			expectError: false,
+		},
+		{
+			name: "description exceeds max length",
+			channel: xmlChannel{
+				Title:          "Valid Podcast",
+				Description:    xmlCDATA{Data: strings.Repeat("a", 4001)},
+				ItunesImage:    xmlItunesImage{Href: "https://example.com/image.jpg"},
+				Language:       "en",
+				ItunesExplicit: ExplicitFalse,
+				ItunesCategory: []xmlItunesCategory{{Text: "Technology"}},
+				Items:          []xmlItem{validItem},
+			},
+			expectError: true,
+			errorMsg:    "channel description must not exceed 4000 bytes",
+		},
+		{
+			name: "multibyte description exceeds max length in bytes",
+			channel: xmlChannel{
+				Title:          "Valid Podcast",
+				Description:    xmlCDATA{Data: strings.Repeat("я", 2001)}, // 2001 runes, 4002 bytes
+				ItunesImage:    xmlItunesImage{Href: "https://example.com/image.jpg"},
+				Language:       "en",
+				ItunesExplicit: ExplicitFalse,
+				ItunesCategory: []xmlItunesCategory{{Text: "Technology"}},
+				Items:          []xmlItem{validItem},
+			},
+			expectError: true,
+			errorMsg:    "channel description must not exceed 4000 bytes",
+		},
 		{
 			name: "empty description",
 			channel: xmlChannel{
@@ -219,7 +260,7 @@ func TestXmlChannelValidation(t *testing.T) {
 				Items:          []xmlItem{validItem},
 			},
 			expectError: true,
-			errorMsg:    "channel itunes:image is required",
+			errorMsg:    "channel itunes:image is required: artwork must be a JPEG or PNG image from 1400x1400 to 3000x3000 pixels",
 		},
 		{
 			name: "empty language",
@@ -422,6 +463,59 @@ func TestXmlItemValidation(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "description at max length",
+			item: xmlItem{
+				Title:       "Valid Episode",
+				Guid:        "valid-guid",
+				Enclosure:   xmlEnclosure{URL: "https://example.com/audio.mp3", Length: 1024, Type: Mp3},
+				Description: &xmlCDATA{Data: strings.Repeat("a", 10000)},
+			},
+			expectError: false,
+		},
+		{
+			name: "description exceeds max length",
+			item: xmlItem{
+				Title:       "Valid Episode",
+				Guid:        "valid-guid",
+				Enclosure:   xmlEnclosure{URL: "https://example.com/audio.mp3", Length: 1024, Type: Mp3},
+				Description: &xmlCDATA{Data: strings.Repeat("a", 10001)},
+			},
+			expectError: true,
+			errorMsg:    "item description must not exceed 10000 bytes",
+		},
+		{
+			name: "summary at max length",
+			item: xmlItem{
+				Title:         "Valid Episode",
+				Guid:          "valid-guid",
+				Enclosure:     xmlEnclosure{URL: "https://example.com/audio.mp3", Length: 1024, Type: Mp3},
+				ItunesSummary: &xmlCDATA{Data: strings.Repeat("a", 4000)},
+			},
+			expectError: false,
+		},
+		{
+			name: "summary exceeds max length",
+			item: xmlItem{
+				Title:         "Valid Episode",
+				Guid:          "valid-guid",
+				Enclosure:     xmlEnclosure{URL: "https://example.com/audio.mp3", Length: 1024, Type: Mp3},
+				ItunesSummary: &xmlCDATA{Data: strings.Repeat("a", 4001)},
+			},
+			expectError: true,
+			errorMsg:    "item itunes:summary must not exceed 4000 bytes",
+		},
+		{
+			name: "multibyte summary exceeds max length in bytes",
+			item: xmlItem{
+				Title:         "Valid Episode",
+				Guid:          "valid-guid",
+				Enclosure:     xmlEnclosure{URL: "https://example.com/audio.mp3", Length: 1024, Type: Mp3},
+				ItunesSummary: &xmlCDATA{Data: strings.Repeat("я", 2001)}, // 2001 runes, 4002 bytes
+			},
+			expectError: true,
+			errorMsg:    "item itunes:summary must not exceed 4000 bytes",
+		},
 		{
 			name: "invalid explicit value",
 			item: xmlItem{
@@ -475,6 +569,11 @@ func TestXmlCDATA(t *testing.T) {
 			data:     "Description with & ampersands and < > brackets",
 			expected: "<![CDATA[Description with & ampersands and < > brackets]]>",
 		},
+		{
+			name:     "text with CDATA end sequence",
+			data:     "Description with ]]> inside",
+			expected: "<![CDATA[Description with ]]]]><![CDATA[> inside]]>",
+		},
 		{
 			name:     "empty text",
 			data:     "",
@@ -500,6 +599,27 @@ func TestXmlCDATA(t *testing.T) {
 	}
 }
 
+func TestXmlCDATA_EndSequence(t *testing.T) {
+	const text = "a]]>b ]]]> c]]>"
+	type doc struct {
+		XMLName     xml.Name `xml:"doc"`
+		Description xmlCDATA `xml:"description"`
+	}
+
+	xmlData, err := xml.Marshal(doc{Description: xmlCDATA{Data: text}})
+	if err != nil {
+		t.Fatalf("Failed to marshal CDATA: %v", err)
+	}
+
+	var parsed doc
+	if err = xml.Unmarshal(xmlData, &parsed); err != nil {
+		t.Fatalf("Generated XML is not valid: %v\n%s", err, xmlData)
+	}
+	if parsed.Description.Data != text {
+		t.Errorf("Expected %q, got %q", text, parsed.Description.Data)
+	}
+}
+
 func TestXmlCategoryStructure(t *testing.T) {
 	// Test simple category
 	category := xmlItunesCategory{
@@ -34,12 +34,12 @@ func copyAndRemove(sourcePath, destPath string) error {
 	// Create destination directory if it doesn't exist
 	destDir := filepath.Dir(destPath)
 	if err := os.MkdirAll(destDir, 0755); err != nil {
-		return fmt.Errorf("failed to create destination directory: %v", err)
+		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
 	// Copy the file contents
 	if err := CopyFile(sourcePath, destPath); err != nil {
-		return fmt.Errorf("failed to copy file: %v", err)
+		return fmt.Errorf("failed to copy file: %w", err)
 	}
 
 	// Remove the original file